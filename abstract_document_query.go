@@ -48,6 +48,17 @@ type abstractDocumentQuery struct {
 
 	includes []string
 
+	counterIncludes     []string
+	allCountersIncluded bool
+	timeSeriesIncludes  []*TimeSeriesRange
+
+	highlightingTokens []*highlightingToken
+	queryHighlightings queryHighlightings
+
+	queryTimings     *QueryTimings
+	explanationToken *explanationToken
+	explanations     *Explanations
+
 	queryStats *QueryStatistics
 
 	disableEntitiesTracking bool
@@ -159,7 +170,12 @@ func (q *abstractDocumentQuery) initializeQueryOperation() (*queryOperation, err
 		return nil, err
 	}
 
-	return newQueryOperation(q.theSession, q.indexName, indexQuery, q.fieldsToFetchToken, q.disableEntitiesTracking, false, false)
+	op, err := newQueryOperation(q.theSession, q.indexName, indexQuery, q.fieldsToFetchToken, q.disableEntitiesTracking, false, false)
+	if err != nil {
+		return nil, err
+	}
+	op.includeAllCounters = q.allCountersIncluded
+	return op, nil
 }
 
 func (q *abstractDocumentQuery) GetIndexQuery() (*IndexQuery, error) {
@@ -367,6 +383,78 @@ func (q *abstractDocumentQuery) include(path string) {
 	q.includes = append(q.includes, path)
 }
 
+func (q *abstractDocumentQuery) includeCounter(name string) {
+	q.counterIncludes = append(q.counterIncludes, name)
+}
+
+func (q *abstractDocumentQuery) includeAllCounters() {
+	q.allCountersIncluded = true
+}
+
+func (q *abstractDocumentQuery) includeTimeSeries(name string, from *time.Time, to *time.Time) {
+	q.timeSeriesIncludes = append(q.timeSeriesIncludes, &TimeSeriesRange{
+		Name: name,
+		From: from,
+		To:   to,
+	})
+}
+
+func (q *abstractDocumentQuery) highlight(fieldName string, fragmentLength int, fragmentCount int, options *HighlightingOptions, highlightings **Highlightings) error {
+	if stringIsBlank(fieldName) {
+		return newIllegalArgumentError("fieldName cannot be empty")
+	}
+	if highlightings == nil {
+		return newIllegalArgumentError("highlightings cannot be nil")
+	}
+	*highlightings = q.queryHighlightings.add(fieldName)
+
+	optionsParameterName := ""
+	if options != nil {
+		optionsParameterName = q.addQueryParameter(options)
+	}
+	q.highlightingTokens = append(q.highlightingTokens, &highlightingToken{
+		fieldName:            fieldName,
+		fragmentLength:       fragmentLength,
+		fragmentCount:        fragmentCount,
+		optionsParameterName: optionsParameterName,
+	})
+	return nil
+}
+
+func (q *abstractDocumentQuery) includeTimings(timings **QueryTimings) error {
+	if timings == nil {
+		return newIllegalArgumentError("timings cannot be nil")
+	}
+	if q.queryTimings == nil {
+		q.queryTimings = &QueryTimings{}
+	}
+	*timings = q.queryTimings
+	return nil
+}
+
+func (q *abstractDocumentQuery) includeExplanations(options *ExplanationOptions, explanations **Explanations) error {
+	if explanations == nil {
+		return newIllegalArgumentError("explanations cannot be nil")
+	}
+	if q.explanationToken != nil {
+		return newIllegalStateError("Duplicate IncludeExplanations method calls are forbidden")
+	}
+	optionsParameterName := ""
+	if options != nil {
+		optionsParameterName = q.addQueryParameter(options)
+	}
+	q.explanationToken = &explanationToken{
+		optionsParameterName: optionsParameterName,
+	}
+	q.explanations = newExplanations()
+	*explanations = q.explanations
+	return nil
+}
+
+func (q *abstractDocumentQuery) hasCounterIncludes() bool {
+	return q.allCountersIncluded || len(q.counterIncludes) > 0
+}
+
 func (q *abstractDocumentQuery) take(count int) {
 	q.pageSize = &count
 }
@@ -1081,6 +1169,25 @@ func (q *abstractDocumentQuery) orderByDescendingWithOrdering(field string, orde
 	return nil
 }
 
+func (q *abstractDocumentQuery) orderByWithSorter(field string, sorterName string, descending bool) error {
+	if stringIsBlank(sorterName) {
+		return newIllegalArgumentError("sorterName cannot be empty")
+	}
+	if err := q.assertNoRawQuery(); err != nil {
+		return err
+	}
+	f, err := q.ensureValidFieldName(field, false)
+	if err != nil {
+		return err
+	}
+	if descending {
+		q.orderByTokens = append(q.orderByTokens, orderByTokenCreateDescendingWithSorter(f, sorterName))
+	} else {
+		q.orderByTokens = append(q.orderByTokens, orderByTokenCreateAscendingWithSorter(f, sorterName))
+	}
+	return nil
+}
+
 func (q *abstractDocumentQuery) orderByScore() error {
 	if err := q.assertNoRawQuery(); err != nil {
 		return err
@@ -1174,7 +1281,7 @@ func (q *abstractDocumentQuery) searchWithOperator(fieldName string, searchTerms
 
 func (q *abstractDocumentQuery) string() (string, error) {
 	if q.queryRaw != "" {
-		return q.queryRaw, nil
+		return q.buildRawQuery()
 	}
 
 	if q.currentClauseDepth != 0 {
@@ -1218,7 +1325,7 @@ func (q *abstractDocumentQuery) string() (string, error) {
 }
 
 func (q *abstractDocumentQuery) buildInclude(queryText *strings.Builder) error {
-	if len(q.includes) == 0 {
+	if len(q.includes) == 0 && !q.hasCounterIncludes() && len(q.timeSeriesIncludes) == 0 && len(q.highlightingTokens) == 0 && q.explanationToken == nil && q.queryTimings == nil {
 		return nil
 	}
 
@@ -1229,25 +1336,228 @@ func (q *abstractDocumentQuery) buildInclude(queryText *strings.Builder) error {
 			queryText.WriteString(",")
 		}
 
-		requiredQuotes := false
+		includesUtilWriteInclude(queryText, include)
+	}
+	q.buildCounterIncludes(queryText, len(q.includes) > 0)
+	q.buildTimeSeriesIncludes(queryText, len(q.includes) > 0 || q.hasCounterIncludes())
+	needsComma := len(q.includes) > 0 || q.hasCounterIncludes() || len(q.timeSeriesIncludes) > 0
+	for _, token := range q.highlightingTokens {
+		if needsComma {
+			queryText.WriteString(",")
+		}
+		needsComma = true
+		if err := token.writeTo(queryText); err != nil {
+			return err
+		}
+	}
+	return q.buildDiagnosticIncludes(queryText, needsComma)
+}
 
-		for _, ch := range include {
-			if !isLetterOrDigit(ch) && ch != '_' && ch != '.' {
-				requiredQuotes = true
-				break
+// buildDiagnosticIncludes writes explanations() and timings() includes
+func (q *abstractDocumentQuery) buildDiagnosticIncludes(queryText *strings.Builder, needsComma bool) error {
+	if q.explanationToken != nil {
+		if needsComma {
+			queryText.WriteString(",")
+		}
+		needsComma = true
+		if err := q.explanationToken.writeTo(queryText); err != nil {
+			return err
+		}
+	}
+	if q.queryTimings != nil {
+		if needsComma {
+			queryText.WriteString(",")
+		}
+		queryText.WriteString("timings()")
+	}
+	return nil
+}
+
+// buildRawQuery returns raw query with explanations() and timings() includes
+// appended to its include clause (or a new one). The include clause must be
+// before limit and offset clauses
+func (q *abstractDocumentQuery) buildRawQuery() (string, error) {
+	if q.explanationToken == nil && q.queryTimings == nil {
+		return q.queryRaw, nil
+	}
+	tokens := rawQueryTokenize(q.queryRaw)
+	hasInclude := false
+	// index of the token that starts limit (or offset) clause
+	limit := len(tokens)
+	for i, tok := range tokens {
+		switch {
+		case tok.isKeyword(q.queryRaw, tokens, i, "include"):
+			hasInclude = true
+			limit = len(tokens)
+		case limit == len(tokens) && i+1 < len(tokens) &&
+			(tok.isKeyword(q.queryRaw, tokens, i, "limit") || tok.isKeyword(q.queryRaw, tokens, i, "offset")):
+			// a field named limit is not followed by a number or a parameter
+			if c := q.queryRaw[tokens[i+1].start]; c == '$' || (c >= '0' && c <= '9') {
+				limit = i
 			}
 		}
+	}
+	pos := 0
+	if limit > 0 {
+		pos = tokens[limit-1].end
+	}
 
-		if requiredQuotes {
-			s := strings.Replace(include, "'", "\\'", -1)
-			queryText.WriteString("'")
-			queryText.WriteString(s)
-			queryText.WriteString("'")
-		} else {
-			queryText.WriteString(include)
+	queryText := &strings.Builder{}
+	queryText.WriteString(q.queryRaw[:pos])
+	if !hasInclude {
+		queryText.WriteString(" include ")
+	}
+	if err := q.buildDiagnosticIncludes(queryText, hasInclude); err != nil {
+		return "", err
+	}
+	queryText.WriteString(strings.TrimRight(q.queryRaw[pos:], " \t\r\n"))
+	return queryText.String(), nil
+}
+
+// rawQueryToken is a word, a string literal or a punctuation character of
+// a raw query
+type rawQueryToken struct {
+	start int
+	end   int
+	// depth is the number of enclosing parentheses, braces and brackets
+	depth int
+}
+
+// isKeyword returns true if tokens[i] is a given keyword. Words inside
+// parentheses and braces (function arguments, JavaScript in select etc.)
+// and property names (e.g. u.include) are not keywords
+func (t rawQueryToken) isKeyword(query string, tokens []rawQueryToken, i int, keyword string) bool {
+	if t.depth > 0 || !strings.EqualFold(query[t.start:t.end], keyword) {
+		return false
+	}
+	return i == 0 || query[tokens[i-1].start:tokens[i-1].end] != "."
+}
+
+// rawQueryTokenize splits raw query into tokens, skipping white space
+// and comments
+func rawQueryTokenize(query string) []rawQueryToken {
+	var res []rawQueryToken
+	depth := 0
+	n := len(query)
+	for i := 0; i < n; {
+		c := query[i]
+		start := i
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// skip to the closing quote, honoring escapes
+			i++
+			for i < n && query[i] != c {
+				if query[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+			if i > n {
+				i = n
+			}
+		case c == '/' && i+1 < n && query[i+1] == '/':
+			for i < n && query[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < n && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return res
+			}
+			i += 2 + end + 2
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+			continue
+		case rawQueryIsWordChar(c):
+			for i < n && rawQueryIsWordChar(query[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+		if c == ')' || c == '}' || c == ']' {
+			if depth > 0 {
+				depth--
+			}
+		}
+		res = append(res, rawQueryToken{start: start, end: i, depth: depth})
+		if c == '(' || c == '{' || c == '[' {
+			depth++
 		}
 	}
-	return nil
+	return res
+}
+
+// rawQueryHasInclude returns true if raw query has an include clause.
+// String literals, comments, property names (e.g. u.include) and everything
+// inside parentheses and braces (function arguments, JavaScript in select
+// etc.) are skipped
+func rawQueryHasInclude(query string) bool {
+	tokens := rawQueryTokenize(query)
+	for i, tok := range tokens {
+		if tok.isKeyword(query, tokens, i, "include") {
+			return true
+		}
+	}
+	return false
+}
+
+func rawQueryIsWordChar(c byte) bool {
+	return c == '_' || c == '$' || c == '@' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+var (
+	// the smallest and largest time accepted by the server, used for
+	// time series ranges that are not bounded
+	timeSeriesMinTime = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeSeriesMaxTime = time.Date(9999, 12, 31, 23, 59, 59, 999999900, time.UTC)
+)
+
+func (q *abstractDocumentQuery) buildTimeSeriesIncludes(queryText *strings.Builder, needsComma bool) {
+	for _, r := range q.timeSeriesIncludes {
+		if needsComma {
+			queryText.WriteString(",")
+		}
+		needsComma = true
+		from, to := timeSeriesMinTime, timeSeriesMaxTime
+		if r.From != nil {
+			from = *r.From
+		}
+		if r.To != nil {
+			to = *r.To
+		}
+		queryText.WriteString("timeseries('")
+		queryText.WriteString(strings.Replace(r.Name, "'", "\\'", -1))
+		queryText.WriteString("', '")
+		queryText.WriteString(timePtrToServerString(&from))
+		queryText.WriteString("', '")
+		queryText.WriteString(timePtrToServerString(&to))
+		queryText.WriteString("')")
+	}
+}
+
+func (q *abstractDocumentQuery) buildCounterIncludes(queryText *strings.Builder, needsComma bool) {
+	if q.allCountersIncluded {
+		if needsComma {
+			queryText.WriteString(",")
+		}
+		queryText.WriteString("counters()")
+		return
+	}
+
+	q.counterIncludes = stringArrayRemoveDuplicatesNoCase(q.counterIncludes)
+	for _, counter := range q.counterIncludes {
+		if needsComma {
+			queryText.WriteString(",")
+		}
+		needsComma = true
+		queryText.WriteString("counters('")
+		queryText.WriteString(strings.Replace(counter, "'", "\\'", -1))
+		queryText.WriteString("')")
+	}
 }
 
 func (q *abstractDocumentQuery) intersect() error {
@@ -1377,7 +1687,13 @@ func (q *abstractDocumentQuery) distinct() error {
 
 func (q *abstractDocumentQuery) updateStatsAndHighlightings(queryResult *QueryResult) {
 	q.queryStats.UpdateQueryStats(queryResult)
-	//TBD 4.1 Highlightings.Update(queryResult);
+	q.queryHighlightings.update(queryResult)
+	if q.queryTimings != nil {
+		q.queryTimings.update(queryResult)
+	}
+	if q.explanations != nil {
+		q.explanations.update(queryResult)
+	}
 }
 
 func (q *abstractDocumentQuery) buildSelect(writer *strings.Builder) error {
@@ -2085,7 +2401,7 @@ func (q *abstractDocumentQuery) executeActualQuery() error {
 		if err != nil {
 			return err
 		}
-		if err = q.theSession.GetRequestExecutor().ExecuteCommand(command, q.theSession.sessionInfo); err != nil {
+		if err = q.theSession.GetRequestExecutor().ExecuteCommandWithContext(q.theSession.ctx, command, q.theSession.sessionInfo); err != nil {
 			return err
 		}
 		if err = q.queryOperation.setResult(command.Result); err != nil {
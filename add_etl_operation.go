@@ -0,0 +1,70 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &AddEtlOperation{}
+)
+
+// AddEtlOperation creates a new ETL task
+type AddEtlOperation struct {
+	configuration interface{}
+
+	Command *AddEtlCommand
+}
+
+// AddEtlOperationResult is a result of AddEtlOperation
+type AddEtlOperationResult struct {
+	RaftCommandIndex int64 `json:"RaftCommandIndex"`
+	TaskID           int64 `json:"TaskId"`
+}
+
+// NewAddEtlOperation returns new AddEtlOperation. configuration should be
+// *RavenEtlConfiguration or *SqlEtlConfiguration
+func NewAddEtlOperation(configuration interface{}) *AddEtlOperation {
+	return &AddEtlOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *AddEtlOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if err := checkEtlConfiguration(o.configuration); err != nil {
+		return nil, err
+	}
+	o.Command = &AddEtlCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: o.configuration,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &AddEtlCommand{}
+
+// AddEtlCommand is a command for AddEtlOperation
+type AddEtlCommand struct {
+	RavenCommandBase
+
+	configuration interface{}
+
+	Result *AddEtlOperationResult
+}
+
+func (c *AddEtlCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/etl"
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPut(url, d)
+}
+
+func (c *AddEtlCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
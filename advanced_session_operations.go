@@ -26,6 +26,12 @@ func (o *AdvancedSessionOperations) Revisions() *RevisionsSessionOperations {
 	return o.s.Revisions()
 }
 
+// ClusterTransaction returns operations on compare exchange values that are
+// part of a cluster-wide transaction of the session
+func (o *AdvancedSessionOperations) ClusterTransaction() (*ClusterTransactionOperations, error) {
+	return o.s.ClusterTransaction()
+}
+
 func (o *AdvancedSessionOperations) Eagerly() *EagerSessionOperations {
 	return o.s.Eagerly()
 }
@@ -42,6 +48,36 @@ func (o *AdvancedSessionOperations) GetMetadataFor(instance interface{}) (*Metad
 	return o.s.GetMetadataFor(instance)
 }
 
+// SetExpiration sets @expires metadata of a tracked entity
+func (o *AdvancedSessionOperations) SetExpiration(entity interface{}, expires time.Time) error {
+	return o.s.SetExpiration(entity, expires)
+}
+
+// GetExpiration returns time from @expires metadata of a tracked entity
+func (o *AdvancedSessionOperations) GetExpiration(entity interface{}) (*time.Time, error) {
+	return o.s.GetExpiration(entity)
+}
+
+// ClearExpiration removes @expires metadata of a tracked entity
+func (o *AdvancedSessionOperations) ClearExpiration(entity interface{}) error {
+	return o.s.ClearExpiration(entity)
+}
+
+// SetRefresh sets @refresh metadata of a tracked entity
+func (o *AdvancedSessionOperations) SetRefresh(entity interface{}, refresh time.Time) error {
+	return o.s.SetRefresh(entity, refresh)
+}
+
+// GetRefresh returns time from @refresh metadata of a tracked entity
+func (o *AdvancedSessionOperations) GetRefresh(entity interface{}) (*time.Time, error) {
+	return o.s.GetRefresh(entity)
+}
+
+// ClearRefresh removes @refresh metadata of a tracked entity
+func (o *AdvancedSessionOperations) ClearRefresh(entity interface{}) error {
+	return o.s.ClearRefresh(entity)
+}
+
 func (o *AdvancedSessionOperations) GetRequestExecutor() *RequestExecutor {
 	return o.s.GetRequestExecutor()
 }
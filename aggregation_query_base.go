@@ -43,7 +43,7 @@ func (q *aggregationQueryBase) Execute() (map[string]*FacetResult, error) {
 	if err = q.session.incrementRequestCount(); err != nil {
 		return nil, err
 	}
-	if err = q.session.GetRequestExecutor().ExecuteCommand(command, nil); err != nil {
+	if err = q.session.GetRequestExecutor().ExecuteCommandWithContext(q.session.ctx, command, nil); err != nil {
 		return nil, err
 	}
 	return q.processResults(command.Result, q.session.GetConventions())
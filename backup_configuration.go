@@ -0,0 +1,69 @@
+package ravendb
+
+// BackupType describes a type of a backup
+type BackupType = string
+
+const (
+	// BackupTypeBackup is a backup of documents (and index definitions)
+	// which is portable between versions of the server
+	BackupTypeBackup BackupType = "Backup"
+	// BackupTypeSnapshot is a binary copy of database files which is faster
+	// to restore but only works with the same version of the server
+	BackupTypeSnapshot BackupType = "Snapshot"
+)
+
+// LocalSettings describes a local folder backups are written to
+type LocalSettings struct {
+	Disabled   bool   `json:"Disabled"`
+	FolderPath string `json:"FolderPath"`
+}
+
+// BackupConfiguration describes a backup
+type BackupConfiguration struct {
+	BackupType    BackupType     `json:"BackupType"`
+	LocalSettings *LocalSettings `json:"LocalSettings,omitempty"`
+}
+
+// PeriodicBackupConfiguration describes a backup task that runs periodically
+type PeriodicBackupConfiguration struct {
+	BackupConfiguration
+
+	// TaskID is 0 for a new task
+	TaskID     int64  `json:"TaskId"`
+	Name       string `json:"Name"`
+	Disabled   bool   `json:"Disabled"`
+	MentorNode string `json:"MentorNode,omitempty"`
+
+	// FullBackupFrequency and IncrementalBackupFrequency are cron
+	// expressions e.g. "0 2 * * *" for every day at 2AM
+	FullBackupFrequency        string `json:"FullBackupFrequency,omitempty"`
+	IncrementalBackupFrequency string `json:"IncrementalBackupFrequency,omitempty"`
+}
+
+// NewLocalPeriodicBackupConfiguration returns configuration of periodic
+// backups of a given type to a local folder
+func NewLocalPeriodicBackupConfiguration(name string, backupType BackupType, folderPath string) *PeriodicBackupConfiguration {
+	return &PeriodicBackupConfiguration{
+		BackupConfiguration: BackupConfiguration{
+			BackupType: backupType,
+			LocalSettings: &LocalSettings{
+				FolderPath: folderPath,
+			},
+		},
+		Name: name,
+	}
+}
+
+// RestoreBackupConfiguration describes restoring a backup into a new database
+type RestoreBackupConfiguration struct {
+	DatabaseName string `json:"DatabaseName"`
+	// BackupLocation is a folder with backup files
+	BackupLocation string `json:"BackupLocation"`
+	// LastFileNameToRestore is the last backup file to restore,
+	// all files are restored if empty
+	LastFileNameToRestore string `json:"LastFileNameToRestore,omitempty"`
+	DataDirectory         string `json:"DataDirectory,omitempty"`
+	EncryptionKey         string `json:"EncryptionKey,omitempty"`
+	DisableOngoingTasks   bool   `json:"DisableOngoingTasks"`
+	SkipIndexes           bool   `json:"SkipIndexes"`
+}
@@ -0,0 +1,85 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupCommandsRequests(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+
+	{
+		config := NewLocalPeriodicBackupConfiguration("backup", BackupTypeBackup, "/tmp/backups")
+		config.FullBackupFrequency = "0 2 * * *"
+		cmd, err := NewUpdatePeriodicBackupOperation(config).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/periodic-backup", req.URL.String())
+		d, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		exp := `{"BackupType":"Backup","LocalSettings":{"Disabled":false,"FolderPath":"/tmp/backups"},"TaskId":0,"Name":"backup","Disabled":false,"FullBackupFrequency":"0 2 * * *"}`
+		assert.Equal(t, exp, string(d))
+
+		_, err = NewUpdatePeriodicBackupOperation(nil).GetCommand(nil)
+		assert.Error(t, err)
+	}
+
+	{
+		cmd, err := NewStartBackupOperation(true, 5).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/backup/database?isFullBackup=true&taskId=5", req.URL.String())
+
+		err = cmd.setResponse([]byte(`{"ResponsibleNode":"A","OperationId":12}`), false)
+		assert.NoError(t, err)
+		res, err := getCommandOperationIDResult(cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), res.OperationID)
+	}
+
+	{
+		cmd, err := NewGetPeriodicBackupStatusOperation(5).GetCommand(nil)
+		assert.NoError(t, err)
+		assert.True(t, cmd.getBase().IsReadRequest)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/periodic-backup/status?name=db&taskId=5", req.URL.String())
+
+		err = cmd.setResponse([]byte(`{"Status":{"TaskId":5,"BackupType":"Backup","IsFull":true,"LastFullBackup":"2020-01-02T03:04:05.0000000Z","LocalBackup":{"BackupDirectory":"/tmp/backups/x"}}}`), false)
+		assert.NoError(t, err)
+		status := cmd.(*GetPeriodicBackupStatusCommand).Result.Status
+		assert.Equal(t, int64(5), status.TaskID)
+		assert.True(t, status.IsFull)
+		assert.Equal(t, "/tmp/backups/x", status.LocalBackup.BackupDirectory)
+		assert.Equal(t, 2020, status.LastFullBackup.toTime().Year())
+	}
+
+	{
+		config := &RestoreBackupConfiguration{
+			DatabaseName:   "restored",
+			BackupLocation: "/tmp/backups/x",
+		}
+		cmd, err := NewRestoreBackupOperation(config).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/admin/restore/database", req.URL.String())
+		d, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		exp := `{"DatabaseName":"restored","BackupLocation":"/tmp/backups/x","DisableOngoingTasks":false,"SkipIndexes":false}`
+		assert.Equal(t, exp, string(d))
+
+		_, err = NewRestoreBackupOperation(&RestoreBackupConfiguration{DatabaseName: "restored"}).GetCommand(nil)
+		assert.Error(t, err)
+	}
+}
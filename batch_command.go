@@ -23,12 +23,13 @@ type BatchCommand struct {
 	commands          []ICommandData
 	attachmentStreams []io.Reader
 	options           *BatchOptions
+	transactionMode   TransactionMode
 
 	Result *JSONArrayResult
 }
 
 // newBatchCommand returns new BatchCommand
-func newBatchCommand(conventions *DocumentConventions, commands []ICommandData, options *BatchOptions) (*BatchCommand, error) {
+func newBatchCommand(conventions *DocumentConventions, commands []ICommandData, options *BatchOptions, transactionMode TransactionMode) (*BatchCommand, error) {
 	if conventions == nil {
 		return nil, newIllegalStateError("conventions cannot be nil")
 	}
@@ -39,9 +40,10 @@ func newBatchCommand(conventions *DocumentConventions, commands []ICommandData,
 	cmd := &BatchCommand{
 		RavenCommandBase: NewRavenCommandBase(),
 
-		commands:    commands,
-		options:     options,
-		conventions: conventions,
+		commands:        commands,
+		options:         options,
+		conventions:     conventions,
+		transactionMode: transactionMode,
 	}
 
 	for i := 0; i < len(commands); i++ {
@@ -82,6 +84,9 @@ func (c *BatchCommand) createRequest(node *ServerNode) (*http.Request, error) {
 	v := map[string]interface{}{
 		"Commands": a,
 	}
+	if c.transactionMode == TransactionModeClusterWide {
+		v["TransactionMode"] = TransactionModeClusterWide
+	}
 	js, err := jsonMarshal(v)
 	if err != nil {
 		return nil, err
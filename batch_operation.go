@@ -1,10 +1,13 @@
 package ravendb
 
+import "strings"
+
 // BatchOperation represents a batch operation
 type BatchOperation struct {
 	session              *InMemoryDocumentSessionOperations
 	entities             []interface{}
 	sessionCommandsCount int
+	commands             []ICommandData
 }
 
 func newBatchOperation(session *InMemoryDocumentSessionOperations) *BatchOperation {
@@ -30,8 +33,9 @@ func (b *BatchOperation) createRequest() (*BatchCommand, error) {
 	}
 
 	b.entities = result.entities
+	b.commands = result.sessionCommands
 
-	return newBatchCommand(b.session.GetConventions(), result.sessionCommands, result.options)
+	return newBatchCommand(b.session.GetConventions(), result.sessionCommands, result.options, b.session.transactionMode)
 }
 
 func (b *BatchOperation) setResult(result []map[string]interface{}) error {
@@ -82,9 +86,73 @@ func (b *BatchOperation) setResult(result []map[string]interface{}) error {
 		afterSaveChangesEventArgs := newAfterSaveChangesEventArgs(b.session, documentInfo.id, documentInfo.entity)
 		b.session.onAfterSaveChangesInvoke(afterSaveChangesEventArgs)
 	}
+
+	for i := b.sessionCommandsCount; i < len(result); i++ {
+		batchResult := result[i]
+		if batchResult == nil {
+			continue
+		}
+		typ, _ := jsonGetAsText(batchResult, "Type")
+		switch typ {
+		case CommandCounters:
+			b.handleCounters(batchResult)
+		case CommandTimeSeries:
+			if i < len(b.commands) {
+				if command, ok := b.commands[i].(*TimeSeriesBatchCommandData); ok {
+					b.handleTimeSeries(command)
+				}
+			}
+		}
+	}
 	return nil
 }
 
+func (b *BatchOperation) handleCounters(batchResult map[string]interface{}) {
+	docID, _ := jsonGetAsText(batchResult, "Id")
+	countersDetailI, ok := batchResult["CountersDetail"]
+	if docID == "" || !ok {
+		return
+	}
+	var countersDetail *CountersDetail
+	if err := decodeJSONAsStruct(countersDetailI, &countersDetail); err != nil || countersDetail == nil {
+		return
+	}
+
+	cache := b.session.getOrCreateCountersCacheEntry(docID)
+	for _, counter := range countersDetail.Counters {
+		if counter == nil || counter.CounterName == "" {
+			continue
+		}
+		value := counter.TotalValue
+		cache.set(counter.CounterName, &value)
+	}
+}
+
+// handleTimeSeries drops cached entries of the modified time series and
+// adds it to @timeseries metadata of the document so that the session
+// knows it exists
+func (b *BatchOperation) handleTimeSeries(command *TimeSeriesBatchCommandData) {
+	b.session.removeTimeSeriesCacheEntry(command.ID, command.TimeSeries.Name)
+	if len(command.TimeSeries.Appends) == 0 {
+		return
+	}
+	documentInfo := b.session.documentsByID.getValue(command.ID)
+	if documentInfo == nil || documentInfo.metadata == nil {
+		return
+	}
+	names := getMetadataStrings(documentInfo.metadata, MetadataTimeSeries)
+	for _, name := range names {
+		if strings.EqualFold(name, command.TimeSeries.Name) {
+			return
+		}
+	}
+	var a []interface{}
+	for _, name := range names {
+		a = append(a, name)
+	}
+	documentInfo.metadata[MetadataTimeSeries] = append(a, command.TimeSeries.Name)
+}
+
 func throwOnNullResult() error {
 	return newIllegalStateError("Received empty response from the server. This is not supposed to happen and is likely a bug.")
 }
@@ -170,19 +170,8 @@ func (o *BulkInsertOperation) StoreWithID(entity interface{}, id string, metadat
 	if err != nil {
 		return err
 	}
-	o.err = o.WaitForID()
-	if o.err != nil {
-		return o.err
-	}
-	o.err = o.ensureCommand()
-	if o.err != nil {
-		return o.err
-	}
-
-	if o.bulkInsertExecuteTask.IsCompletedExceptionally() {
-		_, err = o.bulkInsertExecuteTask.Get()
-		panicIf(err == nil, "err should not be nil")
-		return o.throwBulkInsertAborted(err, nil)
+	if err = o.prepareForWrite(); err != nil {
+		return err
 	}
 
 	if metadata == nil {
@@ -206,6 +195,35 @@ func (o *BulkInsertOperation) StoreWithID(entity interface{}, id string, metadat
 	documentInfo.metadataInstance = metadata
 	jsNode := convertEntityToJSON(entity, documentInfo)
 
+	m := map[string]interface{}{}
+	m["Id"] = o.escapeID(id)
+	m["Type"] = "PUT"
+	m["Document"] = jsNode
+	return o.writeCommand(m)
+}
+
+// prepareForWrite starts the bulk insert command if necessary and checks
+// that it didn't fail
+func (o *BulkInsertOperation) prepareForWrite() error {
+	o.err = o.WaitForID()
+	if o.err != nil {
+		return o.err
+	}
+	o.err = o.ensureCommand()
+	if o.err != nil {
+		return o.err
+	}
+
+	if o.bulkInsertExecuteTask.IsCompletedExceptionally() {
+		_, err := o.bulkInsertExecuteTask.Get()
+		panicIf(err == nil, "err should not be nil")
+		return o.throwBulkInsertAborted(err, nil)
+	}
+	return nil
+}
+
+// writeCommand writes a command to the bulk insert stream
+func (o *BulkInsertOperation) writeCommand(m map[string]interface{}) error {
 	var b bytes.Buffer
 	if o.first {
 		b.WriteByte('[')
@@ -213,10 +231,6 @@ func (o *BulkInsertOperation) StoreWithID(entity interface{}, id string, metadat
 	} else {
 		b.WriteByte(',')
 	}
-	m := map[string]interface{}{}
-	m["Id"] = o.escapeID(id)
-	m["Type"] = "PUT"
-	m["Document"] = jsNode
 
 	d, err := jsonMarshal(m)
 	if err != nil {
@@ -262,6 +276,7 @@ func (o *BulkInsertOperation) ensureCommand() error {
 	bulkCommand := NewBulkInsertCommand(o.operationID, o.reader, o.useCompression)
 	panicIf(o.bulkInsertExecuteTask != nil, "already started _bulkInsertExecuteTask")
 	o.bulkInsertExecuteTask = newCompletableFuture()
+	o.conventions.GetLogger().Log(LogLevelDebug, "bulk insert started", "operationID", o.operationID)
 	go func() {
 		err := o.requestExecutor.ExecuteCommand(bulkCommand, nil)
 		if err != nil {
@@ -285,6 +300,7 @@ func (o *BulkInsertOperation) Abort() error {
 		return err
 	}
 
+	o.conventions.GetLogger().Log(LogLevelWarn, "aborting bulk insert", "operationID", o.operationID)
 	command, err := NewKillOperationCommand(i64toa(o.operationID))
 	if err != nil {
 		return err
@@ -317,9 +333,11 @@ func (o *BulkInsertOperation) Close() error {
 	}
 
 	if err != nil {
+		o.conventions.GetLogger().Log(LogLevelError, "bulk insert failed", "operationID", o.operationID, "error", err)
 		o.err = err
 		return err
 	}
+	o.conventions.GetLogger().Log(LogLevelDebug, "bulk insert finished", "operationID", o.operationID)
 	return nil
 }
 
@@ -0,0 +1,75 @@
+package ravendb
+
+import (
+	"time"
+)
+
+// BulkInsertTimeSeries appends entries to a time series of a document
+// as part of a bulk insert
+type BulkInsertTimeSeries struct {
+	operation *BulkInsertOperation
+	docID     string
+	name      string
+}
+
+// TimeSeriesFor returns a time series of a document with a given id to which
+// entries can be appended as part of this bulk insert
+func (o *BulkInsertOperation) TimeSeriesFor(docID string, name string) (*BulkInsertTimeSeries, error) {
+	if stringIsBlank(docID) {
+		return nil, newIllegalArgumentError("Document id cannot be empty")
+	}
+	if stringIsBlank(name) {
+		return nil, newIllegalArgumentError("Time series name cannot be empty")
+	}
+	return &BulkInsertTimeSeries{
+		operation: o,
+		docID:     docID,
+		name:      name,
+	}, nil
+}
+
+// Append appends an entry with given values
+func (t *BulkInsertTimeSeries) Append(timestamp time.Time, values ...float64) error {
+	return t.AppendWithTag(timestamp, "", values...)
+}
+
+// AppendWithTag appends an entry with given values and tag
+func (t *BulkInsertTimeSeries) AppendWithTag(timestamp time.Time, tag string, values ...float64) error {
+	if len(values) == 0 {
+		return newIllegalArgumentError("values cannot be empty")
+	}
+
+	o := t.operation
+	if !o.concurrentCheck.compareAndSet(0, 1) {
+		return newIllegalStateError("Bulk Insert Store methods cannot be executed concurrently.")
+	}
+	defer o.concurrentCheck.set(0)
+
+	// early exit if we failed previously
+	if o.err != nil {
+		return o.err
+	}
+	if err := o.prepareForWrite(); err != nil {
+		return err
+	}
+
+	// an entry is [<unix time in ms>, <number of values>, <values>..., <tag>]
+	entry := []interface{}{timestamp.UnixNano() / int64(time.Millisecond), len(values)}
+	for _, v := range values {
+		entry = append(entry, v)
+	}
+	if tag != "" {
+		entry = append(entry, tag)
+	}
+
+	m := map[string]interface{}{
+		"Id":   t.docID,
+		"Type": "TimeSeriesBulkInsert",
+		"TimeSeries": map[string]interface{}{
+			"Name":       t.name,
+			"TimeFormat": "UnixTimeInMs",
+			"Appends":    []interface{}{entry},
+		},
+	}
+	return o.writeCommand(m)
+}
@@ -0,0 +1,163 @@
+package ravendb
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ClusterTransactionOperations allows creating, updating and deleting
+// compare exchange values as part of a cluster-wide transaction of a session.
+// Changes are sent to the server on SaveChanges, together with changes to
+// documents, and are applied atomically
+type ClusterTransactionOperations struct {
+	session *InMemoryDocumentSessionOperations
+
+	// keyed by lower-cased key, keys keep the order of operations
+	storeCompareExchange      map[string]*CompareExchangeValue
+	storeCompareExchangeKeys  []string
+	deleteCompareExchange     map[string]*CompareExchangeValue
+	deleteCompareExchangeKeys []string
+}
+
+func newClusterTransactionOperations(session *InMemoryDocumentSessionOperations) *ClusterTransactionOperations {
+	return &ClusterTransactionOperations{
+		session:               session,
+		storeCompareExchange:  map[string]*CompareExchangeValue{},
+		deleteCompareExchange: map[string]*CompareExchangeValue{},
+	}
+}
+
+// ClusterTransaction returns operations on compare exchange values that are
+// part of a cluster-wide transaction. The session must be opened with
+// TransactionModeClusterWide
+func (s *DocumentSession) ClusterTransaction() (*ClusterTransactionOperations, error) {
+	if s.transactionMode != TransactionModeClusterWide {
+		return nil, newIllegalStateError("This function is part of cluster transaction session, in order to use it you have to open the Session with TransactionMode set to %s", TransactionModeClusterWide)
+	}
+	if s.clusterTransaction == nil {
+		s.clusterTransaction = newClusterTransactionOperations(s.InMemoryDocumentSessionOperations)
+	}
+	return s.clusterTransaction, nil
+}
+
+func (o *ClusterTransactionOperations) getNumberOfTrackedCompareExchangeValues() int {
+	return len(o.storeCompareExchangeKeys) + len(o.deleteCompareExchangeKeys)
+}
+
+func (o *ClusterTransactionOperations) clear() {
+	o.storeCompareExchange = map[string]*CompareExchangeValue{}
+	o.storeCompareExchangeKeys = nil
+	o.deleteCompareExchange = map[string]*CompareExchangeValue{}
+	o.deleteCompareExchangeKeys = nil
+}
+
+func (o *ClusterTransactionOperations) ensureNotDeleted(key string) error {
+	if _, ok := o.deleteCompareExchange[strings.ToLower(key)]; ok {
+		return newIllegalArgumentError("The key '%s' already deleted in this session", key)
+	}
+	return nil
+}
+
+func (o *ClusterTransactionOperations) ensureNotStored(key string) error {
+	if _, ok := o.storeCompareExchange[strings.ToLower(key)]; ok {
+		return newIllegalArgumentError("The key '%s' already exists in this session", key)
+	}
+	return nil
+}
+
+func (o *ClusterTransactionOperations) store(key string, value interface{}, index int64) {
+	k := strings.ToLower(key)
+	if _, ok := o.storeCompareExchange[k]; !ok {
+		o.storeCompareExchangeKeys = append(o.storeCompareExchangeKeys, k)
+	}
+	o.storeCompareExchange[k] = NewCompareExchangeValue(key, index, value)
+}
+
+// CreateCompareExchangeValue creates a compare exchange value on SaveChanges.
+// SaveChanges fails if a value with this key already exists
+func (o *ClusterTransactionOperations) CreateCompareExchangeValue(key string, value interface{}) error {
+	if stringIsEmpty(key) {
+		return newIllegalArgumentError("The key argument must have value")
+	}
+	if err := o.ensureNotDeleted(key); err != nil {
+		return err
+	}
+	if err := o.ensureNotStored(key); err != nil {
+		return err
+	}
+	o.store(key, value, 0)
+	return nil
+}
+
+// UpdateCompareExchangeValue updates a compare exchange value on SaveChanges.
+// SaveChanges fails if item.Index doesn't match the index of the value on the server
+func (o *ClusterTransactionOperations) UpdateCompareExchangeValue(item *CompareExchangeValue) error {
+	if item == nil {
+		return newIllegalArgumentError("item cannot be nil")
+	}
+	if err := o.ensureNotDeleted(item.Key); err != nil {
+		return err
+	}
+	o.store(item.Key, item.Value, item.Index)
+	return nil
+}
+
+// DeleteCompareExchangeValue deletes a compare exchange value on SaveChanges.
+// SaveChanges fails if index doesn't match the index of the value on the server
+func (o *ClusterTransactionOperations) DeleteCompareExchangeValue(key string, index int64) error {
+	if stringIsEmpty(key) {
+		return newIllegalArgumentError("The key argument must have value")
+	}
+	if err := o.ensureNotStored(key); err != nil {
+		return err
+	}
+	k := strings.ToLower(key)
+	if _, ok := o.deleteCompareExchange[k]; !ok {
+		o.deleteCompareExchangeKeys = append(o.deleteCompareExchangeKeys, k)
+	}
+	o.deleteCompareExchange[k] = NewCompareExchangeValue(key, index, nil)
+	return nil
+}
+
+// DeleteCompareExchangeValueItem is like DeleteCompareExchangeValue but takes
+// key and index from item
+func (o *ClusterTransactionOperations) DeleteCompareExchangeValueItem(item *CompareExchangeValue) error {
+	if item == nil {
+		return newIllegalArgumentError("item cannot be nil")
+	}
+	return o.DeleteCompareExchangeValue(item.Key, item.Index)
+}
+
+// GetCompareExchangeValue returns compare exchange value with a given key
+// or nil if it doesn't exist. clazz is the type of the value
+func (o *ClusterTransactionOperations) GetCompareExchangeValue(clazz reflect.Type, key string) (*CompareExchangeValue, error) {
+	op, err := NewGetCompareExchangeValueOperation(clazz, key)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.session.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	err = o.session.GetOperations().SendWithContext(o.session.ctx, op, o.session.sessionInfo)
+	if err != nil {
+		return nil, err
+	}
+	return op.Command.Result, nil
+}
+
+// GetCompareExchangeValues returns compare exchange values with given keys.
+// clazz is the type of the values
+func (o *ClusterTransactionOperations) GetCompareExchangeValues(clazz reflect.Type, keys []string) (map[string]*CompareExchangeValue, error) {
+	op, err := NewGetCompareExchangeValuesOperationWithKeys(clazz, keys)
+	if err != nil {
+		return nil, err
+	}
+	if err = o.session.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	err = o.session.GetOperations().SendWithContext(o.session.ctx, op, o.session.sessionInfo)
+	if err != nil {
+		return nil, err
+	}
+	return op.Command.Result, nil
+}
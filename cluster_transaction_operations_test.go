@@ -0,0 +1,79 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSession(transactionMode TransactionMode) *DocumentSession {
+	url := "http://localhost:8080"
+	store := NewDocumentStore([]string{url}, "db")
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(url, "db", nil, nil, store.GetConventions())
+	session := NewDocumentSession("db", store, "id", re)
+	session.transactionMode = transactionMode
+	return session
+}
+
+func TestClusterTransactionRequiresClusterWideMode(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	_, err := session.ClusterTransaction()
+	_, ok := err.(*IllegalStateError)
+	assert.True(t, ok, "err is %T", err)
+}
+
+func TestClusterTransactionBatch(t *testing.T) {
+	session := newTestSession(TransactionModeClusterWide)
+	session.useOptimisticConcurrency = false
+
+	ops, err := session.ClusterTransaction()
+	assert.NoError(t, err)
+	err = ops.CreateCompareExchangeValue("emails/foo@example.com", "users/1")
+	assert.NoError(t, err)
+	err = ops.CreateCompareExchangeValue("EMAILS/foo@example.com", "users/2")
+	assert.Error(t, err)
+	err = ops.DeleteCompareExchangeValue("emails/foo@example.com", 0)
+	assert.Error(t, err)
+	err = ops.DeleteCompareExchangeValue("emails/bar@example.com", 5)
+	assert.NoError(t, err)
+	err = ops.UpdateCompareExchangeValue(NewCompareExchangeValue("emails/bar@example.com", 5, "x"))
+	assert.Error(t, err)
+
+	result, err := session.prepareForSaveChanges()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(result.sessionCommands))
+	assert.Equal(t, 0, ops.getNumberOfTrackedCompareExchangeValues())
+
+	cmd, err := newBatchCommand(session.GetConventions(), result.sessionCommands, nil, session.GetTransactionMode())
+	assert.NoError(t, err)
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+	req, err := cmd.createRequest(node)
+	assert.NoError(t, err)
+	d, err := ioutil.ReadAll(req.Body)
+	assert.NoError(t, err)
+	exp := `{"Commands":[{"Document":{"Object":"users/1"},"Index":0,"Key":"emails/foo@example.com","Type":"CompareExchangePUT"},{"Index":5,"Key":"emails/bar@example.com","Type":"CompareExchangeDELETE"}],"TransactionMode":"ClusterWide"}`
+	assert.Equal(t, exp, string(d))
+}
+
+func TestClusterTransactionValidation(t *testing.T) {
+	session := newTestSession(TransactionModeClusterWide)
+	session.useOptimisticConcurrency = true
+	_, err := session.prepareForSaveChanges()
+	assert.Error(t, err)
+
+	session.useOptimisticConcurrency = false
+	cmd, err := NewCountersBatchCommandData("users/1", &CounterOperation{
+		Type:        CounterOperationTypeIncrement,
+		CounterName: "likes",
+		Delta:       1,
+	})
+	assert.NoError(t, err)
+	session.Defer(cmd)
+	_, err = session.prepareForSaveChanges()
+	_, ok := err.(*UnsupportedOperationError)
+	assert.True(t, ok, "err is %T", err)
+}
@@ -7,11 +7,15 @@ type CommandType = string
 // making them strings is better in Go
 const (
 	//CommandNone                = "NONE"
-	CommandPut                 = "PUT"
-	CommandPatch               = "PATCH"
-	CommandDelete              = "DELETE"
-	CommandAttachmentPut       = "ATTACHMENT_PUT"
-	CommandAttachmentDelete    = "ATTACHMENT_DELETE"
-	CommandClientAnyCommand    = "CLIENT_ANY_COMMAND"
-	CommandClientNotAttachment = "CLIENT_NOT_ATTACHMENT"
+	CommandPut                   = "PUT"
+	CommandPatch                 = "PATCH"
+	CommandDelete                = "DELETE"
+	CommandAttachmentPut         = "ATTACHMENT_PUT"
+	CommandAttachmentDelete      = "ATTACHMENT_DELETE"
+	CommandCounters              = "Counters"
+	CommandTimeSeries            = "TimeSeries"
+	CommandCompareExchangePut    = "CompareExchangePUT"
+	CommandCompareExchangeDelete = "CompareExchangeDELETE"
+	CommandClientAnyCommand      = "CLIENT_ANY_COMMAND"
+	CommandClientNotAttachment   = "CLIENT_NOT_ATTACHMENT"
 )
@@ -0,0 +1,65 @@
+package ravendb
+
+var (
+	_ ICommandData = &PutCompareExchangeCommandData{}
+	_ ICommandData = &DeleteCompareExchangeCommandData{}
+)
+
+// PutCompareExchangeCommandData represents data for a batch command that
+// creates or updates compare exchange value. Only valid in cluster-wide transactions
+type PutCompareExchangeCommandData struct {
+	CommandData
+
+	index int64
+	value interface{}
+}
+
+func newPutCompareExchangeCommandData(key string, value interface{}, index int64) *PutCompareExchangeCommandData {
+	return &PutCompareExchangeCommandData{
+		CommandData: CommandData{
+			ID:   key,
+			Type: CommandCompareExchangePut,
+		},
+		index: index,
+		value: value,
+	}
+}
+
+func (d *PutCompareExchangeCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	res := map[string]interface{}{
+		"Key":   d.ID,
+		"Index": d.index,
+		"Document": map[string]interface{}{
+			"Object": d.value,
+		},
+		"Type": CommandCompareExchangePut,
+	}
+	return res, nil
+}
+
+// DeleteCompareExchangeCommandData represents data for a batch command that
+// deletes compare exchange value. Only valid in cluster-wide transactions
+type DeleteCompareExchangeCommandData struct {
+	CommandData
+
+	index int64
+}
+
+func newDeleteCompareExchangeCommandData(key string, index int64) *DeleteCompareExchangeCommandData {
+	return &DeleteCompareExchangeCommandData{
+		CommandData: CommandData{
+			ID:   key,
+			Type: CommandCompareExchangeDelete,
+		},
+		index: index,
+	}
+}
+
+func (d *DeleteCompareExchangeCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	res := map[string]interface{}{
+		"Key":   d.ID,
+		"Index": d.index,
+		"Type":  CommandCompareExchangeDelete,
+	}
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package ravendb
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -144,3 +145,21 @@ func (f *completableFuture) GetWithTimeout(dur time.Duration) (interface{}, erro
 	_, res, err = f.getState()
 	return res, err
 }
+
+// getWithContext is like Get but gives up waiting when ctx is done
+func (f *completableFuture) getWithContext(ctx context.Context) (interface{}, error) {
+	done, res, err := f.getState()
+	if done {
+		return res, err
+	}
+
+	select {
+	case <-f.signalCompletion:
+		// completed, will return the result
+	case <-ctx.Done():
+		return nil, newContextDoneError(ctx, "waiting for completion")
+	}
+
+	_, res, err = f.getState()
+	return res, err
+}
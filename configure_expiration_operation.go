@@ -0,0 +1,68 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &ConfigureExpirationOperation{}
+)
+
+// ConfigureExpirationOperation sets ExpirationConfiguration of a database
+type ConfigureExpirationOperation struct {
+	configuration *ExpirationConfiguration
+
+	Command *ConfigureExpirationCommand
+}
+
+// ConfigureExpirationOperationResult is a result of ConfigureExpirationOperation
+type ConfigureExpirationOperationResult struct {
+	RaftCommandIndex int64 `json:"RaftCommandIndex"`
+}
+
+// NewConfigureExpirationOperation returns new ConfigureExpirationOperation
+func NewConfigureExpirationOperation(configuration *ExpirationConfiguration) *ConfigureExpirationOperation {
+	return &ConfigureExpirationOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *ConfigureExpirationOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if o.configuration == nil {
+		return nil, newIllegalArgumentError("Configuration cannot be nil")
+	}
+	o.Command = &ConfigureExpirationCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: o.configuration,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &ConfigureExpirationCommand{}
+
+// ConfigureExpirationCommand is a command for ConfigureExpirationOperation
+type ConfigureExpirationCommand struct {
+	RavenCommandBase
+
+	configuration *ExpirationConfiguration
+
+	Result *ConfigureExpirationOperationResult
+}
+
+func (c *ConfigureExpirationCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/expiration/config"
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPost(url, d)
+}
+
+func (c *ConfigureExpirationCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,68 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &ConfigureRefreshOperation{}
+)
+
+// ConfigureRefreshOperation sets RefreshConfiguration of a database
+type ConfigureRefreshOperation struct {
+	configuration *RefreshConfiguration
+
+	Command *ConfigureRefreshCommand
+}
+
+// ConfigureRefreshOperationResult is a result of ConfigureRefreshOperation
+type ConfigureRefreshOperationResult struct {
+	RaftCommandIndex int64 `json:"RaftCommandIndex"`
+}
+
+// NewConfigureRefreshOperation returns new ConfigureRefreshOperation
+func NewConfigureRefreshOperation(configuration *RefreshConfiguration) *ConfigureRefreshOperation {
+	return &ConfigureRefreshOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *ConfigureRefreshOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if o.configuration == nil {
+		return nil, newIllegalArgumentError("Configuration cannot be nil")
+	}
+	o.Command = &ConfigureRefreshCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: o.configuration,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &ConfigureRefreshCommand{}
+
+// ConfigureRefreshCommand is a command for ConfigureRefreshOperation
+type ConfigureRefreshCommand struct {
+	RavenCommandBase
+
+	configuration *RefreshConfiguration
+
+	Result *ConfigureRefreshOperationResult
+}
+
+func (c *ConfigureRefreshCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/refresh/config"
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPost(url, d)
+}
+
+func (c *ConfigureRefreshCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
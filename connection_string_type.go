@@ -1,6 +1,6 @@
 package ravendb
 
-// TODO: only used in ConnectionString which is unused
+// ConnectionStringType describes type of a connection string
 type ConnectionStringType = string
 
 const (
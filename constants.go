@@ -12,13 +12,19 @@ const (
 	MetadataIDProperty             = "Id"
 	MetadataFlags                  = "@flags"
 	MetadataAttachments            = "@attachments"
+	MetadataCounters               = "@counters"
+	MetadataTimeSeries             = "@timeseries"
 	MetadataInddexScore            = "@index-score"
 	MetadataLastModified           = "@last-modified"
 	MetadataRavenGoType            = "Raven-Go-Type"
 	MetadataChangeVector           = "@change-vector"
 	MetadataExpires                = "@expires"
+	MetadataRefresh                = "@refresh"
 	MetadataAllDocumentsCollection = "@all_docs"
 
+	// CountersAll is a special counter name that means all counters of a document
+	CountersAll = "@all_counters"
+
 	IndexingSideBySideIndexNamePrefix = "ReplacementOf/"
 	IndexingFieldNameDocumentID       = "id()"
 	IndexingFieldNameReduceKeyHash    = "hash(key())"
@@ -34,6 +40,7 @@ const (
 	headersClientConfigurationEtag    = "Client-Configuration-Etag"
 	headersRefreshClientConfiguration = "Refresh-Client-Configuration"
 	headersClientVersion              = "Raven-Client-Version"
+	headersTraceParent                = "traceparent"
 	headersEtag                       = "ETag"
 	headersIfNoneMatch                = "If-None-Match"
 )
@@ -0,0 +1,71 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IOperation = &CounterBatchOperation{}
+)
+
+// CounterBatchOperation executes a batch of operations on counters
+type CounterBatchOperation struct {
+	counterBatch *CounterBatch
+
+	Command *CounterBatchCommand
+}
+
+// NewCounterBatchOperation returns new CounterBatchOperation
+func NewCounterBatchOperation(counterBatch *CounterBatch) *CounterBatchOperation {
+	return &CounterBatchOperation{
+		counterBatch: counterBatch,
+	}
+}
+
+func (o *CounterBatchOperation) GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewCounterBatchCommand(o.counterBatch)
+	return o.Command, err
+}
+
+var _ RavenCommand = &CounterBatchCommand{}
+
+// CounterBatchCommand is a command for CounterBatchOperation
+type CounterBatchCommand struct {
+	RavenCommandBase
+
+	counterBatch *CounterBatch
+
+	Result *CountersDetail
+}
+
+// NewCounterBatchCommand returns new CounterBatchCommand
+func NewCounterBatchCommand(counterBatch *CounterBatch) (*CounterBatchCommand, error) {
+	if counterBatch == nil {
+		return nil, newIllegalArgumentError("counterBatch cannot be nil")
+	}
+
+	return &CounterBatchCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		counterBatch: counterBatch,
+	}, nil
+}
+
+func (c *CounterBatchCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/counters"
+
+	d, err := jsonMarshal(c.counterBatch)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPost(url, d)
+}
+
+func (c *CounterBatchCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,16 @@
+package ravendb
+
+import "strconv"
+
+// CounterChange describes a change to a counter of a document
+type CounterChange struct {
+	Type         CounterChangeTypes
+	Name         string
+	Value        int64
+	DocumentID   string `json:"DocumentId"`
+	ChangeVector *string
+}
+
+func (c *CounterChange) String() string {
+	return c.Type + " on counter " + c.Name + " of " + c.DocumentID + " (value: " + strconv.FormatInt(c.Value, 10) + ")"
+}
@@ -0,0 +1,48 @@
+package ravendb
+
+// CounterOperationType describes a type of operation on a counter
+type CounterOperationType = string
+
+const (
+	CounterOperationTypeNone      = "None"
+	CounterOperationTypeIncrement = "Increment"
+	CounterOperationTypeDelete    = "Delete"
+	CounterOperationTypeGet       = "Get"
+	CounterOperationTypePut       = "Put"
+)
+
+// CounterOperation describes an operation on a single counter
+type CounterOperation struct {
+	Type        CounterOperationType `json:"Type"`
+	CounterName string               `json:"CounterName"`
+	Delta       int64                `json:"Delta"`
+}
+
+// DocumentCountersOperation describes operations on counters of a single document
+type DocumentCountersOperation struct {
+	DocumentID string              `json:"DocumentId"`
+	Operations []*CounterOperation `json:"Operations"`
+}
+
+// CounterBatch describes a batch of operations on counters of multiple
+// documents, sent with CounterBatchOperation
+type CounterBatch struct {
+	ReplyWithAllNodesValues bool                         `json:"ReplyWithAllNodesValues"`
+	Documents               []*DocumentCountersOperation `json:"Documents"`
+	FromEtl                 bool                         `json:"FromEtl"`
+}
+
+// CounterDetail describes a value of a counter
+type CounterDetail struct {
+	DocumentID    string           `json:"DocumentId"`
+	CounterName   string           `json:"CounterName"`
+	TotalValue    int64            `json:"TotalValue"`
+	Etag          int64            `json:"Etag"`
+	CounterValues map[string]int64 `json:"CounterValues"`
+}
+
+// CountersDetail is a result of counter operations
+type CountersDetail struct {
+	// Note: server sends nil for counters that don't exist
+	Counters []*CounterDetail `json:"Counters"`
+}
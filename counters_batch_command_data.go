@@ -0,0 +1,65 @@
+package ravendb
+
+import "strings"
+
+var _ ICommandData = &CountersBatchCommandData{} // verify interface match
+
+// CountersBatchCommandData represents data for a batch command that modifies
+// counters of a document
+type CountersBatchCommandData struct {
+	CommandData
+
+	FromEtl  bool
+	Counters *DocumentCountersOperation
+}
+
+// NewCountersBatchCommandData creates ICommandData for operations on counters
+// of a document with a given id
+func NewCountersBatchCommandData(documentID string, counterOperations ...*CounterOperation) (*CountersBatchCommandData, error) {
+	if stringIsBlank(documentID) {
+		return nil, newIllegalArgumentError("DocumentId cannot be empty")
+	}
+
+	res := &CountersBatchCommandData{
+		CommandData: CommandData{
+			ID:   documentID,
+			Type: CommandCounters,
+		},
+		Counters: &DocumentCountersOperation{
+			DocumentID: documentID,
+			Operations: counterOperations,
+		},
+	}
+	return res, nil
+}
+
+// HasDelete returns true if there's a delete operation for a given counter
+func (d *CountersBatchCommandData) HasDelete(counterName string) bool {
+	return d.hasOperation(CounterOperationTypeDelete, counterName)
+}
+
+// HasIncrement returns true if there's an increment operation for a given counter
+func (d *CountersBatchCommandData) HasIncrement(counterName string) bool {
+	return d.hasOperation(CounterOperationTypeIncrement, counterName)
+}
+
+func (d *CountersBatchCommandData) hasOperation(typ CounterOperationType, counterName string) bool {
+	for _, op := range d.Counters.Operations {
+		if op.Type == typ && strings.EqualFold(op.CounterName, counterName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *CountersBatchCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	res := map[string]interface{}{
+		"Id":       d.ID,
+		"Counters": d.Counters,
+		"Type":     "Counters",
+	}
+	if d.FromEtl {
+		res["FromEtl"] = true
+	}
+	return res, nil
+}
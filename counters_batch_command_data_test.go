@@ -0,0 +1,46 @@
+package ravendb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountersBatchCommandDataSerialize(t *testing.T) {
+	op := &CounterOperation{
+		Type:        CounterOperationTypeIncrement,
+		CounterName: "likes",
+		Delta:       5,
+	}
+	cmd, err := NewCountersBatchCommandData("users/1", op)
+	assert.NoError(t, err)
+	assert.True(t, cmd.HasIncrement("LIKES"))
+	assert.False(t, cmd.HasDelete("likes"))
+
+	v, err := cmd.serialize(nil)
+	assert.NoError(t, err)
+	d, err := jsonMarshal(v)
+	assert.NoError(t, err)
+	exp := `{"Counters":{"DocumentId":"users/1","Operations":[{"Type":"Increment","CounterName":"likes","Delta":5}]},"Id":"users/1","Type":"Counters"}`
+	assert.Equal(t, exp, string(d))
+
+	_, err = NewCountersBatchCommandData("")
+	assert.Error(t, err)
+}
+
+func TestGetCountersCommandRequest(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+	cmd, err := NewGetCountersCommand("users/1", []string{"likes", "LIKES", "a b"}, false)
+	assert.NoError(t, err)
+	req, err := cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "/databases/db/counters", req.URL.Path)
+	q := req.URL.Query()
+	assert.Equal(t, "users/1", q.Get("docId"))
+	assert.Equal(t, 2, len(q["counter"]))
+}
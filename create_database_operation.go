@@ -42,6 +42,8 @@ type CreateDatabaseCommand struct {
 	databaseRecord    *DatabaseRecord
 	replicationFactor int
 	databaseName      string
+	// etag is set when updating a database record
+	etag *int64
 
 	Result *DatabasePutResult
 }
@@ -70,7 +72,14 @@ func (c *CreateDatabaseCommand) createRequest(node *ServerNode) (*http.Request,
 	if err != nil {
 		return nil, err
 	}
-	return newHttpPut(url, js)
+	request, err := newHttpPut(url, js)
+	if err != nil {
+		return nil, err
+	}
+	if c.etag != nil {
+		request.Header.Set(headersEtag, "\""+i64toa(*c.etag)+"\"")
+	}
+	return request, nil
 }
 
 func (c *CreateDatabaseCommand) setResponse(response []byte, fromCache bool) error {
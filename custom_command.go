@@ -0,0 +1,102 @@
+package ravendb
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+var (
+	_ RavenCommand = &CustomCommand{}
+)
+
+// CustomCommandHandler is implemented by commands defined outside of this
+// package, for server endpoints that the client doesn't wrap.
+// Wrap it with NewCustomCommand to execute it with RequestExecutor.
+type CustomCommandHandler interface {
+	// CreateRequest creates http request to be sent to a given node.
+	// Use ServerNode.GetDatabaseURL and NewHTTPRequest to build it
+	CreateRequest(node *ServerNode) (*http.Request, error)
+
+	// SetResponse is called with the body of the response if ResponseType
+	// is RavenCommandResponseTypeObject. fromCache is true if response
+	// was served from http cache. If server responded with 404 Not Found,
+	// it's called with nil response
+	SetResponse(response []byte, fromCache bool) error
+}
+
+// CustomCommandRawHandler must be implemented by CustomCommandHandler if
+// ResponseType of the command is RavenCommandResponseTypeRaw.
+// body must be fully consumed before returning as it's closed afterwards
+type CustomCommandRawHandler interface {
+	SetResponseRaw(response *http.Response, body io.Reader) error
+}
+
+// CustomCommandOperationIDHandler must be implemented by CustomCommandHandler
+// if command starts a long-running operation on the server, so that it can be
+// used with SendAsync and Operation.WaitForCompletion
+type CustomCommandOperationIDHandler interface {
+	GetOperationIDResult() *OperationIDResult
+}
+
+// CustomCommand adapts CustomCommandHandler to RavenCommand. It can be
+// executed with RequestExecutor.ExecuteCommand (which provides node selection,
+// failover, caching, authentication and error handling) and returned from
+// GetCommand of IOperation, IMaintenanceOperation or IServerOperation.
+// Configure it via RavenCommandBase fields e.g. IsReadRequest or ResponseType
+type CustomCommand struct {
+	RavenCommandBase
+
+	Handler CustomCommandHandler
+}
+
+// NewCustomCommand returns a new CustomCommand for a given handler
+func NewCustomCommand(handler CustomCommandHandler) *CustomCommand {
+	return &CustomCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		Handler: handler,
+	}
+}
+
+func (c *CustomCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	return c.Handler.CreateRequest(node)
+}
+
+func (c *CustomCommand) setResponse(response []byte, fromCache bool) error {
+	return c.Handler.SetResponse(response, fromCache)
+}
+
+func (c *CustomCommand) setResponseRaw(response *http.Response, body io.Reader) error {
+	h, ok := c.Handler.(CustomCommandRawHandler)
+	if !ok {
+		return newUnsupportedOperationError("%T must implement CustomCommandRawHandler when ResponseType is %s", c.Handler, c.ResponseType)
+	}
+	return h.SetResponseRaw(response, body)
+}
+
+// NewHTTPRequest creates a http request with headers expected by the server.
+// If body is not empty, it's sent as JSON
+func NewHTTPRequest(method string, uri string, body []byte) (*http.Request, error) {
+	var r io.Reader
+	if len(body) > 0 {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, uri, r)
+	if err != nil {
+		return nil, err
+	}
+	addCommonHeaders(req)
+	if len(body) > 0 {
+		req.Header.Add("Content-Type", "application/json; charset=UTF-8")
+	}
+	return req, nil
+}
+
+// ParseJSONResponse decodes JSON response of a command into v
+func ParseJSONResponse(response []byte, v interface{}) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, v)
+}
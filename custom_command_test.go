@@ -0,0 +1,83 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testNodeInfoHandler struct {
+	Result map[string]interface{}
+}
+
+func (h *testNodeInfoHandler) CreateRequest(node *ServerNode) (*http.Request, error) {
+	return NewHTTPRequest(http.MethodGet, node.GetDatabaseURL("/custom/info"), nil)
+}
+
+func (h *testNodeInfoHandler) SetResponse(response []byte, fromCache bool) error {
+	return ParseJSONResponse(response, &h.Result)
+}
+
+type testNodeInfoOperation struct {
+	handler *testNodeInfoHandler
+}
+
+func (o *testNodeInfoOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	cmd := NewCustomCommand(o.handler)
+	cmd.IsReadRequest = true
+	return cmd, nil
+}
+
+func TestCustomCommand(t *testing.T) {
+	var gotPath string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if r.URL.Path != "/databases/db/custom/info" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Name":"foo"}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	var op IMaintenanceOperation = &testNodeInfoOperation{handler: &testNodeInfoHandler{}}
+	cmd, err := op.GetCommand(re.GetConventions())
+	assert.NoError(t, err)
+	err = re.ExecuteCommand(cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/databases/db/custom/info", gotPath)
+	assert.Equal(t, http.StatusOK, cmd.(*CustomCommand).StatusCode)
+	assert.Equal(t, "foo", op.(*testNodeInfoOperation).handler.Result["Name"])
+}
+
+func TestCustomCommandRawWithoutRawHandler(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("raw data"))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	cmd := NewCustomCommand(&testNodeInfoHandler{})
+	cmd.ResponseType = RavenCommandResponseTypeRaw
+	err := re.ExecuteCommand(cmd, nil)
+	_, ok := err.(*UnsupportedOperationError)
+	assert.True(t, ok, "err is %T", err)
+}
+
+func TestCustomCommandOperationIDWithoutOperationIDHandler(t *testing.T) {
+	cmd := NewCustomCommand(&testNodeInfoHandler{})
+	res, err := getCommandOperationIDResult(cmd)
+	assert.Nil(t, res)
+	_, ok := err.(*IllegalArgumentError)
+	assert.True(t, ok, "err is %T", err)
+}
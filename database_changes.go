@@ -35,10 +35,13 @@ type changeSubscribers struct {
 	watchCommand   string
 	unwatchCommand string
 	commandValue   string
+	// if set, sent instead of commandValue
+	commandValues []string
 
 	onDocumentChange        sync.Map // int -> func(*DocumentChange)
 	onIndexChange           sync.Map // int -> func(*IndexChange)
 	onOperationStatusChange sync.Map // int -> func(*OperationStatusChange)
+	onCounterChange         sync.Map // int -> func(*CounterChange)
 
 	nextID int32 // atomic
 }
@@ -48,36 +51,22 @@ func (s *changeSubscribers) getNextID() int {
 	return int(n)
 }
 
-func (s *changeSubscribers) registerOnDocumentChange(fn func(*DocumentChange)) int {
-	id := s.getNextID()
-	s.onDocumentChange.Store(id, fn)
-	return id
-}
-
 func (s *changeSubscribers) unregisterOnDocumentChange(id int) {
 	s.onDocumentChange.Delete(id)
 }
 
-func (s *changeSubscribers) registerOnIndexChange(fn func(*IndexChange)) int {
-	id := s.getNextID()
-	s.onIndexChange.Store(id, fn)
-	return id
-}
-
 func (s *changeSubscribers) unregisterOnIndexChange(id int) {
 	s.onIndexChange.Delete(id)
 }
 
-func (s *changeSubscribers) registerOnOperationStatusChange(fn func(*OperationStatusChange)) int {
-	id := s.getNextID()
-	s.onOperationStatusChange.Store(id, fn)
-	return id
-}
-
 func (s *changeSubscribers) unregisterOnOperationStatusChange(id int) {
 	s.onOperationStatusChange.Delete(id)
 }
 
+func (s *changeSubscribers) unregisterOnCounterChange(id int) {
+	s.onCounterChange.Delete(id)
+}
+
 func (s *changeSubscribers) sendDocumentChange(change *DocumentChange) {
 	s.onDocumentChange.Range(func(k, v interface{}) bool {
 		f := v.(func(documentChange *DocumentChange))
@@ -102,6 +91,14 @@ func (s *changeSubscribers) sendOperationStatusChange(change *OperationStatusCha
 	})
 }
 
+func (s *changeSubscribers) sendCounterChange(change *CounterChange) {
+	s.onCounterChange.Range(func(k, v interface{}) bool {
+		f := v.(func(counterChange *CounterChange))
+		f(change)
+		return true
+	})
+}
+
 func (s *changeSubscribers) hasRegisteredHandlers() bool {
 	// there is no sync.Map.Count() so we have to enumerate to see
 	// if there are any registered handlers
@@ -114,14 +111,16 @@ func (s *changeSubscribers) hasRegisteredHandlers() bool {
 	s.onDocumentChange.Range(fn)
 	s.onIndexChange.Range(fn)
 	s.onOperationStatusChange.Range(fn)
+	s.onCounterChange.Range(fn)
 	return hasHandlers
 }
 
-func newDatabaseChangesCommand(id int, command string, value string) *databaseChangesCommand {
+func newDatabaseChangesCommand(id int, command string, value string, values []string) *databaseChangesCommand {
 	return &databaseChangesCommand{
 		id:        id,
 		command:   command,
 		value:     value,
+		values:    values,
 		timeStart: time.Now(),
 		ch:        make(chan bool, 1), // don't block the sender
 	}
@@ -132,6 +131,7 @@ type databaseChangesCommand struct {
 	id      int
 	command string
 	value   string
+	values  []string
 
 	// used to wait for notifications
 	timeStart    time.Time
@@ -177,7 +177,8 @@ type DatabaseChanges struct {
 
 	// will be notified if we connect or fail to connect
 	// allows waiting for connection being established
-	chIsConnected chan error
+	chIsConnected   chan error
+	isConnectedOnce sync.Once
 
 	chCommands      chan *databaseChangesCommand
 	chWorkCompleted chan error
@@ -192,8 +193,18 @@ type DatabaseChanges struct {
 	// TODO: why is this not used?
 	// immediateConnection int32 // atomic
 
-	connectionStatusChanged []func()
-	onError                 []func(error)
+	connectionStatusChanged  []func()
+	onError                  []func(error)
+	onConnectionStateChanged []func(*ChangesConnectionStateChange)
+	onPossibleMissedChanges  []func(*ChangesGap)
+
+	// protected by mu
+	connectionState ChangesConnectionState
+
+	// only accessed from doWork goroutine
+	wasConnected   bool
+	disconnectedAt time.Time
+	disconnectErr  error
 
 	lastError atomic.Value // error
 }
@@ -230,6 +241,7 @@ func newDatabaseChanges(requestExecutor *RequestExecutor, databaseName string, o
 		if err != nil {
 			dcdbg("newDatabaseChanges: getPreferredNode() failed with %s\n", err)
 			res.notifyAboutError(err)
+			res.setConnectionState(ChangesConnectionStateClosed, err)
 			res.chWorkCompleted <- err
 			close(res.chWorkCompleted)
 			return
@@ -278,26 +290,34 @@ func (c *DatabaseChanges) RemoveConnectionStatusChanged(handlerID int) {
 
 type CancelFunc func()
 
+// Callbacks registered with For* methods are called in a separate goroutine
+// for each registration, in order of changes. A slow callback delays
+// delivery of changes to other callbacks once its buffer of changes is full.
+
 // ForIndex registers a callback that will be called for changes in an index with a given name.
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForIndex(indexName string, cb func(*IndexChange)) (CancelFunc, error) {
-	subscribers, err := c.getOrAddSubscribers("indexes/"+indexName, "watch-index", "unwatch-index", indexName)
+	q, cancel, err := c.forIndex(indexName, nil)
 	if err != nil {
 		return nil, err
 	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*IndexChange))
+	})
+	return cancel, nil
+}
 
-	filtered := func(change *IndexChange) {
-		if strings.EqualFold(change.Name, indexName) {
-			cb(change)
-		}
-	}
-	idx := subscribers.registerOnIndexChange(filtered)
-	cancel := func() {
-		subscribers.unregisterOnIndexChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
+func (c *DatabaseChanges) forIndex(indexName string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("indexes/"+indexName, "watch-index", "unwatch-index", indexName)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return cancel, nil
+	filter := func(change *IndexChange) bool {
+		return strings.EqualFold(change.Name, indexName)
+	}
+	q, cancel := c.queueIndexChanges(subscribers, filter, opts)
+	return q, cancel, nil
 }
 
 func (c *DatabaseChanges) getLastConnectionStateError() error {
@@ -311,145 +331,186 @@ func (c *DatabaseChanges) getLastConnectionStateError() error {
 // ForDocument registers a callback that will be called for changes on a ocument with a given id
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForDocument(docID string, cb func(*DocumentChange)) (CancelFunc, error) {
-	subscribers, err := c.getOrAddSubscribers("docs/"+docID, "watch-doc", "unwatch-doc", docID)
+	q, cancel, err := c.forDocument(docID, nil)
 	if err != nil {
 		return nil, err
 	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*DocumentChange))
+	})
+	return cancel, nil
+}
 
-	filtered := func(change *DocumentChange) {
-		panicIf(change.ID != docID, "v.ID (%s) != docID (%s)", change.ID, docID)
-		cb(change)
+func (c *DatabaseChanges) forDocument(docID string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("docs/"+docID, "watch-doc", "unwatch-doc", docID)
+	if err != nil {
+		return nil, nil, err
 	}
-	idx := subscribers.registerOnDocumentChange(filtered)
-	cancel := func() {
-		subscribers.unregisterOnDocumentChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
+
+	filter := func(change *DocumentChange) bool {
+		panicIf(change.ID != docID, "v.ID (%s) != docID (%s)", change.ID, docID)
+		return true
 	}
-	return cancel, nil
+	q, cancel := c.queueDocumentChanges(subscribers, filter, opts)
+	return q, cancel, nil
 }
 
 // ForAllDocuments registers a callback that will be called for changes on all documents.
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForAllDocuments(cb func(*DocumentChange)) (CancelFunc, error) {
-	subscribers, err := c.getOrAddSubscribers("all-docs", "watch-docs", "unwatch-docs", "")
+	q, cancel, err := c.forAllDocuments(nil)
 	if err != nil {
 		return nil, err
 	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*DocumentChange))
+	})
+	return cancel, nil
+}
 
-	idx := subscribers.registerOnDocumentChange(cb)
-	cancel := func() {
-		subscribers.unregisterOnDocumentChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
+func (c *DatabaseChanges) forAllDocuments(opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("all-docs", "watch-docs", "unwatch-docs", "")
+	if err != nil {
+		return nil, nil, err
 	}
-	return cancel, nil
+
+	q, cancel := c.queueDocumentChanges(subscribers, nil, opts)
+	return q, cancel, nil
 }
 
 // ForOperationID registers a callback that will be called when a change happens to operation with a given id.
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForOperationID(operationID int64, cb func(*OperationStatusChange)) (CancelFunc, error) {
-	opIDStr := i64toa(operationID)
-	subscribers, err := c.getOrAddSubscribers("operations/"+opIDStr, "watch-operation", "unwatch-operation", opIDStr)
+	q, cancel, err := c.forOperationID(operationID, nil)
 	if err != nil {
 		return nil, err
 	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*OperationStatusChange))
+	})
+	return cancel, nil
+}
 
-	filtered := func(change *OperationStatusChange) {
-		if change.OperationID == operationID {
-			cb(change)
-		}
+func (c *DatabaseChanges) forOperationID(operationID int64, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	opIDStr := i64toa(operationID)
+	subscribers, err := c.getOrAddSubscribers("operations/"+opIDStr, "watch-operation", "unwatch-operation", opIDStr)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	idx := subscribers.registerOnOperationStatusChange(filtered)
-	cancel := func() {
-		subscribers.unregisterOnOperationStatusChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
+	filter := func(change *OperationStatusChange) bool {
+		return change.OperationID == operationID
 	}
-	return cancel, nil
+	q, cancel := c.queueOperationStatusChanges(subscribers, filter, opts)
+	return q, cancel, nil
 }
 
 // ForAllOperations registers a callback that will be called when any operation changes status.
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForAllOperations(cb func(change *OperationStatusChange)) (CancelFunc, error) {
-	subscribers, err := c.getOrAddSubscribers("all-operations", "watch-operations", "unwatch-operations", "")
+	q, cancel, err := c.forAllOperations(nil)
 	if err != nil {
 		return nil, err
 	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*OperationStatusChange))
+	})
+	return cancel, nil
+}
 
-	idx := subscribers.registerOnOperationStatusChange(cb)
-	cancel := func() {
-		subscribers.unregisterOnOperationStatusChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
+func (c *DatabaseChanges) forAllOperations(opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("all-operations", "watch-operations", "unwatch-operations", "")
+	if err != nil {
+		return nil, nil, err
 	}
-	return cancel, nil
+
+	q, cancel := c.queueOperationStatusChanges(subscribers, nil, opts)
+	return q, cancel, nil
 }
 
 // ForAllIndexes registers a callback that will be called when a change on any index happens.
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForAllIndexes(cb func(*IndexChange)) (CancelFunc, error) {
-	subscribers, err := c.getOrAddSubscribers("all-indexes", "watch-indexes", "unwatch-indexes", "")
+	q, cancel, err := c.forAllIndexes(nil)
 	if err != nil {
 		return nil, err
 	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*IndexChange))
+	})
+	return cancel, nil
+}
 
-	idx := subscribers.registerOnIndexChange(cb)
-	cancel := func() {
-		subscribers.unregisterOnIndexChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
+func (c *DatabaseChanges) forAllIndexes(opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("all-indexes", "watch-indexes", "unwatch-indexes", "")
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return cancel, nil
+	q, cancel := c.queueIndexChanges(subscribers, nil, opts)
+	return q, cancel, nil
 }
 
 // ForDocumentsStartingWith registers a callback that will be called for changes on documents whose id starts with
 // a given prefix. It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForDocumentsStartingWith(docIDPrefix string, cb func(*DocumentChange)) (CancelFunc, error) {
-	subscribers, err := c.getOrAddSubscribers("prefixes/"+docIDPrefix, "watch-prefix", "unwatch-prefix", docIDPrefix)
+	q, cancel, err := c.forDocumentsStartingWith(docIDPrefix, nil)
 	if err != nil {
 		return nil, err
 	}
-	filtered := func(change *DocumentChange) {
+	q.dispatch(func(change interface{}) {
+		cb(change.(*DocumentChange))
+	})
+	return cancel, nil
+}
+
+func (c *DatabaseChanges) forDocumentsStartingWith(docIDPrefix string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("prefixes/"+docIDPrefix, "watch-prefix", "unwatch-prefix", docIDPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter := func(change *DocumentChange) bool {
 		n := len(docIDPrefix)
 		if n > len(change.ID) {
-			return
+			return false
 		}
 		prefix := change.ID[:n]
-		if strings.EqualFold(prefix, docIDPrefix) {
-			cb(change)
-		}
+		return strings.EqualFold(prefix, docIDPrefix)
 	}
 
-	idx := subscribers.registerOnDocumentChange(filtered)
-	cancel := func() {
-		subscribers.unregisterOnDocumentChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
-	}
-	return cancel, nil
+	q, cancel := c.queueDocumentChanges(subscribers, filter, opts)
+	return q, cancel, nil
 }
 
 // ForDocumentsInCollection registers a callback that will be called on changes for documents in a given collection.
 // It returns a function to call to unregister the callback.
 func (c *DatabaseChanges) ForDocumentsInCollection(collectionName string, cb func(*DocumentChange)) (CancelFunc, error) {
+	q, cancel, err := c.forDocumentsInCollection(collectionName, nil)
+	if err != nil {
+		return nil, err
+	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*DocumentChange))
+	})
+	return cancel, nil
+}
+
+func (c *DatabaseChanges) forDocumentsInCollection(collectionName string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
 	if collectionName == "" {
-		return nil, newIllegalArgumentError("CollectionName cannot be empty")
+		return nil, nil, newIllegalArgumentError("CollectionName cannot be empty")
 	}
 
 	subscribers, err := c.getOrAddSubscribers("collections/"+collectionName, "watch-collection", "unwatch-collection", collectionName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	filtered := func(change *DocumentChange) {
-		if strings.EqualFold(collectionName, change.CollectionName) {
-			cb(change)
-		}
+	filter := func(change *DocumentChange) bool {
+		return strings.EqualFold(collectionName, change.CollectionName)
 	}
 
-	idx := subscribers.registerOnDocumentChange(filtered)
-	cancel := func() {
-		subscribers.unregisterOnDocumentChange(idx)
-		c.maybeDisconnectSubscribers(subscribers)
-	}
-	return cancel, nil
+	q, cancel := c.queueDocumentChanges(subscribers, filter, opts)
+	return q, cancel, nil
 }
 
 // ForDocumentsInCollectionOfType registers a callback that will be called on changes for documents of a given type.
@@ -459,6 +520,124 @@ func (c *DatabaseChanges) ForDocumentsInCollectionOfType(clazz reflect.Type, cb
 	return c.ForDocumentsInCollection(collectionName, cb)
 }
 
+// ForAllCounters registers a callback that will be called for changes of all counters.
+// It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForAllCounters(cb func(*CounterChange)) (CancelFunc, error) {
+	q, cancel, err := c.forAllCounters(nil)
+	if err != nil {
+		return nil, err
+	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*CounterChange))
+	})
+	return cancel, nil
+}
+
+func (c *DatabaseChanges) forAllCounters(opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	subscribers, err := c.getOrAddSubscribers("all-counters", "watch-counters", "unwatch-counters", "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q, cancel := c.queueCounterChanges(subscribers, nil, opts)
+	return q, cancel, nil
+}
+
+// ForCounter registers a callback that will be called for changes of counters with a given name
+// in any document. It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForCounter(counterName string, cb func(*CounterChange)) (CancelFunc, error) {
+	q, cancel, err := c.forCounter(counterName, nil)
+	if err != nil {
+		return nil, err
+	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*CounterChange))
+	})
+	return cancel, nil
+}
+
+func (c *DatabaseChanges) forCounter(counterName string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	if stringIsBlank(counterName) {
+		return nil, nil, newIllegalArgumentError("counterName cannot be empty")
+	}
+
+	subscribers, err := c.getOrAddSubscribers("counter/"+counterName, "watch-counter", "unwatch-counter", counterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := func(change *CounterChange) bool {
+		return strings.EqualFold(change.Name, counterName)
+	}
+	q, cancel := c.queueCounterChanges(subscribers, filter, opts)
+	return q, cancel, nil
+}
+
+// ForCounterOfDocument registers a callback that will be called for changes of a given counter
+// of a given document. It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForCounterOfDocument(documentID string, counterName string, cb func(*CounterChange)) (CancelFunc, error) {
+	q, cancel, err := c.forCounterOfDocument(documentID, counterName, nil)
+	if err != nil {
+		return nil, err
+	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*CounterChange))
+	})
+	return cancel, nil
+}
+
+func (c *DatabaseChanges) forCounterOfDocument(documentID string, counterName string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	if stringIsBlank(documentID) {
+		return nil, nil, newIllegalArgumentError("documentID cannot be empty")
+	}
+	if stringIsBlank(counterName) {
+		return nil, nil, newIllegalArgumentError("counterName cannot be empty")
+	}
+
+	name := "document/" + documentID + "/counter/" + counterName
+	values := []string{documentID, counterName}
+	subscribers, err := c.getOrAddSubscribersWithValues(name, "watch-document-counter", "unwatch-document-counter", "", values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := func(change *CounterChange) bool {
+		return strings.EqualFold(change.DocumentID, documentID) && strings.EqualFold(change.Name, counterName)
+	}
+	q, cancel := c.queueCounterChanges(subscribers, filter, opts)
+	return q, cancel, nil
+}
+
+// ForCountersOfDocument registers a callback that will be called for changes of all counters
+// of a given document. It returns a function to call to unregister the callback.
+func (c *DatabaseChanges) ForCountersOfDocument(documentID string, cb func(*CounterChange)) (CancelFunc, error) {
+	q, cancel, err := c.forCountersOfDocument(documentID, nil)
+	if err != nil {
+		return nil, err
+	}
+	q.dispatch(func(change interface{}) {
+		cb(change.(*CounterChange))
+	})
+	return cancel, nil
+}
+
+func (c *DatabaseChanges) forCountersOfDocument(documentID string, opts *ChangesChannelOptions) (*changesQueue, CancelFunc, error) {
+	if stringIsBlank(documentID) {
+		return nil, nil, newIllegalArgumentError("documentID cannot be empty")
+	}
+
+	subscribers, err := c.getOrAddSubscribers("document/"+documentID+"/counter", "watch-document-counters", "unwatch-document-counters", documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := func(change *CounterChange) bool {
+		return strings.EqualFold(change.DocumentID, documentID)
+	}
+	q, cancel := c.queueCounterChanges(subscribers, filter, opts)
+	return q, cancel, nil
+}
+
 func (c *DatabaseChanges) invokeConnectionStatusChanged() {
 	// make a copy of callers so that we can call outside of a lock
 	c.mu.Lock()
@@ -531,6 +710,10 @@ func fmtDCCommand(cmd, value string) string {
 }
 
 func (c *DatabaseChanges) getOrAddSubscribers(name string, watchCommand string, unwatchCommand string, value string) (*changeSubscribers, error) {
+	return c.getOrAddSubscribersWithValues(name, watchCommand, unwatchCommand, value, nil)
+}
+
+func (c *DatabaseChanges) getOrAddSubscribersWithValues(name string, watchCommand string, unwatchCommand string, value string, values []string) (*changeSubscribers, error) {
 	subscribersI, ok := c.subscribers.Load(name)
 
 	if ok {
@@ -542,6 +725,7 @@ func (c *DatabaseChanges) getOrAddSubscribers(name string, watchCommand string,
 		watchCommand:   watchCommand,
 		unwatchCommand: unwatchCommand,
 		commandValue:   value,
+		commandValues:  values,
 	}
 	c.subscribers.Store(name, subscribers)
 	if err := c.connectSubscribers(subscribers); err != nil {
@@ -557,23 +741,29 @@ func (c *DatabaseChanges) maybeDisconnectSubscribers(subscribers *changeSubscrib
 }
 
 func (c *DatabaseChanges) disconnectSubscribers(subscribers *changeSubscribers) {
-	_ = c.send(subscribers.unwatchCommand, subscribers.commandValue, false)
+	_ = c.send(subscribers.unwatchCommand, subscribers.commandValue, subscribers.commandValues, false)
 	// ignoring error: if we are not connected then we unsubscribed
 	// already because connections drops with all subscriptions
 	c.subscribers.Delete(subscribers.name)
 }
 
 func (c *DatabaseChanges) connectSubscribers(subscribers *changeSubscribers) error {
-	return c.send(subscribers.watchCommand, subscribers.commandValue, true)
+	return c.send(subscribers.watchCommand, subscribers.commandValue, subscribers.commandValues, true)
+}
+
+func (c *DatabaseChanges) send(command, value string, values []string, waitForConfirmation bool) error {
+	_, err := c.sendCommand(command, value, values, waitForConfirmation)
+	return err
 }
 
-func (c *DatabaseChanges) send(command, value string, waitForConfirmation bool) error {
+// sendCommand sends a command and returns true if the server confirmed it
+func (c *DatabaseChanges) sendCommand(command, value string, values []string, waitForConfirmation bool) (bool, error) {
 	if c.isClosed() {
-		return errors.New("send() called after Close()")
+		return false, errors.New("send() called after Close()")
 	}
 
 	id := c.nextCommandID()
-	cmd := newDatabaseChangesCommand(id, command, value)
+	cmd := newDatabaseChangesCommand(id, command, value, values)
 	dcdbg("DatabaseChanges: send(): command id: %d, command: '%s', wait: %v\n", id, fmtDCCommand(command, value), waitForConfirmation)
 	if waitForConfirmation {
 		c.outstandingCommands.Store(id, cmd)
@@ -585,9 +775,10 @@ func (c *DatabaseChanges) send(command, value string, waitForConfirmation bool)
 	chCommands <- cmd
 
 	if waitForConfirmation {
-		cmd.waitForConfirmation(time.Second * 15)
+		confirmed := cmd.waitForConfirmation(time.Second * 15)
+		return confirmed && !cmd.wasCancelled, nil
 	}
-	return nil
+	return false, nil
 }
 
 func startSendWorker(conn *websocket.Conn, chCommands chan *databaseChangesCommand) chan error {
@@ -597,13 +788,15 @@ func startSendWorker(conn *websocket.Conn, chCommands chan *databaseChangesComma
 		for cmd := range chCommands {
 			dcdbg("got command with id %d to send. Command: %s, param: %s\n", cmd.id, cmd.command, cmd.value)
 			o := struct {
-				CommandID int    `json:"CommandId"`
-				Command   string `json:"Command"`
-				Param     string `json:"Param"`
+				CommandID int      `json:"CommandId"`
+				Command   string   `json:"Command"`
+				Param     string   `json:"Param"`
+				Params    []string `json:"Params,omitempty"`
 			}{
 				CommandID: cmd.id,
 				Command:   cmd.command,
 				Param:     cmd.value,
+				Params:    cmd.values,
 			}
 			err := conn.SetWriteDeadline(time.Now().Add(time.Second * 3))
 			if err != nil {
@@ -650,6 +843,7 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 	urlString += "/databases/" + c.database + "/changes"
 	urlString = toWebSocketPath(urlString)
 
+	c.setConnectionState(ChangesConnectionStateConnecting, nil)
 	ctxDial, cancel := context.WithTimeout(ctx, time.Second*2)
 	var client *websocket.Conn
 	client, _, err = dialer.DialContext(ctxDial, urlString, nil)
@@ -657,7 +851,10 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 
 	if err != nil {
 		dcdbg("DatabaseChanges: dialer.DialContext failed with '%s'\n", err)
-		return err, false
+		c.conventions.GetLogger().Log(LogLevelWarn, "changes connection failed", "database", c.database, "url", urlString, "error", err)
+		// keep trying if we were connected before, otherwise the error
+		// is reported by EnsureConnectedNow()
+		return err, c.wasConnected && ctx.Err() == nil
 	}
 
 	var chWriterFailed chan error
@@ -665,18 +862,30 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 	var chReaderFailed chan error
 	chReaderFailed = c.startProcessMessagesWorker(ctx, client)
 
-	connectFn := func(key, value interface{}) bool {
-		subscribers := value.(*changeSubscribers)
-		_ = c.connectSubscribers(subscribers)
-		return true
+	resubscribeErr := c.resubscribe()
+	if resubscribeErr != nil {
+		c.conventions.GetLogger().Log(LogLevelWarn, "changes re-subscribing failed", "database", c.database, "error", resubscribeErr)
 	}
-	c.subscribers.Range(connectFn)
 
+	c.conventions.GetLogger().Log(LogLevelInfo, "changes connected", "database", c.database, "url", urlString)
+	c.setConnectionState(ChangesConnectionStateConnected, nil)
 	c.invokeConnectionStatusChanged()
 
-	c.chIsConnected <- nil
-	// close so that subsequent channel reads also return immediately
-	close(c.chIsConnected)
+	if c.wasConnected {
+		c.invokePossibleMissedChanges(&ChangesGap{
+			DisconnectedAt: c.disconnectedAt,
+			ReconnectedAt:  time.Now(),
+			Err:            c.disconnectErr,
+			ResubscribeErr: resubscribeErr,
+		})
+	}
+	c.wasConnected = true
+
+	c.isConnectedOnce.Do(func() {
+		c.chIsConnected <- nil
+		// close so that subsequent channel reads also return immediately
+		close(c.chIsConnected)
+	})
 
 	shouldReconnect := true
 	err = nil
@@ -701,6 +910,16 @@ func (c *DatabaseChanges) doWorkInner(ctx context.Context) (error, bool) {
 	close(chCommands)
 	_ = client.Close()
 
+	if err != nil {
+		c.conventions.GetLogger().Log(LogLevelWarn, "changes connection lost", "database", c.database, "error", err, "reconnect", shouldReconnect)
+	}
+	if shouldReconnect {
+		if err == nil {
+			err = newRuntimeError("Connection closed by the server")
+		}
+		c.disconnectedAt = time.Now()
+		c.disconnectErr = err
+	}
 	c.invokeConnectionStatusChanged()
 	return err, shouldReconnect
 }
@@ -713,10 +932,19 @@ func (c *DatabaseChanges) doWork(ctx context.Context) error {
 		}
 		c.cancelOutstandingCommands()
 		if !shouldReconnect {
+			if ctx.Err() != nil {
+				c.setConnectionState(ChangesConnectionStateClosed, nil)
+			} else {
+				c.setConnectionState(ChangesConnectionStateClosed, err)
+			}
 			return err
 		}
+		c.setConnectionState(ChangesConnectionStateDisconnected, err)
 		// wait before next retry
-		time.Sleep(time.Second)
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
 	}
 }
 
@@ -762,6 +990,19 @@ func (c *DatabaseChanges) notifySubscribers(typ string, value interface{}) error
 			return true
 		}
 		c.subscribers.Range(fn)
+	case "CounterChange":
+		var counterChange *CounterChange
+		err := decodeJSONAsStruct(value, &counterChange)
+		if err != nil {
+			dcdbg("notifySubscribers: '%s' decodeJSONAsStruct failed with %s\n", typ, err)
+			return err
+		}
+		fn := func(key, value interface{}) bool {
+			s := value.(*changeSubscribers)
+			s.sendCounterChange(counterChange)
+			return true
+		}
+		c.subscribers.Range(fn)
 	default:
 		dcdbg("DatabnaseChanges: notifySubscribers(): unsupported type '%s'\n", typ)
 		return fmt.Errorf("notifySubscribers: unsupported type '%s'", typ)
@@ -775,7 +1016,10 @@ func (c *DatabaseChanges) notifyAboutError(err error) {
 	}
 	panicIf(err == nil, "err is nil")
 	c.lastError.Store(err)
+	c.invokeOnError(err)
+}
 
+func (c *DatabaseChanges) invokeOnError(err error) {
 	// make a copy so that we can call outside of a lock
 	c.mu.Lock()
 	handlers := append([]func(error){}, c.onError...)
@@ -0,0 +1,271 @@
+package ravendb
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Channel-based variants of DatabaseChanges.For* methods. Changes are
+// buffered according to ChangesChannelOptions (nil means default options).
+// The channel is closed when subscription is cancelled with returned
+// CancelFunc or when the buffer overflows with ChangesOverflowError policy.
+
+func (c *DatabaseChanges) newCancelFunc(subscribers *changeSubscribers, q *changesQueue, unregister func()) CancelFunc {
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			unregister()
+			c.maybeDisconnectSubscribers(subscribers)
+		})
+	}
+	q.onOverflow = func(err error) {
+		unsubscribe()
+		c.invokeOnError(err)
+	}
+	return func() {
+		unsubscribe()
+		q.close()
+	}
+}
+
+func (c *DatabaseChanges) queueDocumentChanges(subscribers *changeSubscribers, filter func(*DocumentChange) bool, opts *ChangesChannelOptions) (*changesQueue, CancelFunc) {
+	q := newChangesQueue(opts)
+	id := subscribers.getNextID()
+	cancel := c.newCancelFunc(subscribers, q, func() {
+		subscribers.unregisterOnDocumentChange(id)
+	})
+	subscribers.onDocumentChange.Store(id, func(change *DocumentChange) {
+		if filter == nil || filter(change) {
+			q.push(change)
+		}
+	})
+	return q, cancel
+}
+
+func (c *DatabaseChanges) queueIndexChanges(subscribers *changeSubscribers, filter func(*IndexChange) bool, opts *ChangesChannelOptions) (*changesQueue, CancelFunc) {
+	q := newChangesQueue(opts)
+	id := subscribers.getNextID()
+	cancel := c.newCancelFunc(subscribers, q, func() {
+		subscribers.unregisterOnIndexChange(id)
+	})
+	subscribers.onIndexChange.Store(id, func(change *IndexChange) {
+		if filter == nil || filter(change) {
+			q.push(change)
+		}
+	})
+	return q, cancel
+}
+
+func (c *DatabaseChanges) queueOperationStatusChanges(subscribers *changeSubscribers, filter func(*OperationStatusChange) bool, opts *ChangesChannelOptions) (*changesQueue, CancelFunc) {
+	q := newChangesQueue(opts)
+	id := subscribers.getNextID()
+	cancel := c.newCancelFunc(subscribers, q, func() {
+		subscribers.unregisterOnOperationStatusChange(id)
+	})
+	subscribers.onOperationStatusChange.Store(id, func(change *OperationStatusChange) {
+		if filter == nil || filter(change) {
+			q.push(change)
+		}
+	})
+	return q, cancel
+}
+
+func (c *DatabaseChanges) queueCounterChanges(subscribers *changeSubscribers, filter func(*CounterChange) bool, opts *ChangesChannelOptions) (*changesQueue, CancelFunc) {
+	q := newChangesQueue(opts)
+	id := subscribers.getNextID()
+	cancel := c.newCancelFunc(subscribers, q, func() {
+		subscribers.unregisterOnCounterChange(id)
+	})
+	subscribers.onCounterChange.Store(id, func(change *CounterChange) {
+		if filter == nil || filter(change) {
+			q.push(change)
+		}
+	})
+	return q, cancel
+}
+
+func (q *changesQueue) documentChanges() <-chan *DocumentChange {
+	res := make(chan *DocumentChange)
+	go func() {
+		defer close(res)
+		for v := range q.ch {
+			select {
+			case res <- v.(*DocumentChange):
+			case <-q.done:
+				return
+			}
+		}
+	}()
+	return res
+}
+
+func (q *changesQueue) indexChanges() <-chan *IndexChange {
+	res := make(chan *IndexChange)
+	go func() {
+		defer close(res)
+		for v := range q.ch {
+			select {
+			case res <- v.(*IndexChange):
+			case <-q.done:
+				return
+			}
+		}
+	}()
+	return res
+}
+
+func (q *changesQueue) operationStatusChanges() <-chan *OperationStatusChange {
+	res := make(chan *OperationStatusChange)
+	go func() {
+		defer close(res)
+		for v := range q.ch {
+			select {
+			case res <- v.(*OperationStatusChange):
+			case <-q.done:
+				return
+			}
+		}
+	}()
+	return res
+}
+
+func (q *changesQueue) counterChanges() <-chan *CounterChange {
+	res := make(chan *CounterChange)
+	go func() {
+		defer close(res)
+		for v := range q.ch {
+			select {
+			case res <- v.(*CounterChange):
+			case <-q.done:
+				return
+			}
+		}
+	}()
+	return res
+}
+
+// dispatch calls cb for every change in its own goroutine
+func (q *changesQueue) dispatch(cb func(change interface{})) {
+	go func() {
+		for v := range q.ch {
+			if q.isCancelled() {
+				return
+			}
+			cb(v)
+		}
+	}()
+}
+
+// ForIndexChan is like ForIndex but returns a channel of changes
+func (c *DatabaseChanges) ForIndexChan(indexName string, opts *ChangesChannelOptions) (<-chan *IndexChange, CancelFunc, error) {
+	q, cancel, err := c.forIndex(indexName, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.indexChanges(), cancel, nil
+}
+
+// ForDocumentChan is like ForDocument but returns a channel of changes
+func (c *DatabaseChanges) ForDocumentChan(docID string, opts *ChangesChannelOptions) (<-chan *DocumentChange, CancelFunc, error) {
+	q, cancel, err := c.forDocument(docID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.documentChanges(), cancel, nil
+}
+
+// ForAllDocumentsChan is like ForAllDocuments but returns a channel of changes
+func (c *DatabaseChanges) ForAllDocumentsChan(opts *ChangesChannelOptions) (<-chan *DocumentChange, CancelFunc, error) {
+	q, cancel, err := c.forAllDocuments(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.documentChanges(), cancel, nil
+}
+
+// ForOperationIDChan is like ForOperationID but returns a channel of changes
+func (c *DatabaseChanges) ForOperationIDChan(operationID int64, opts *ChangesChannelOptions) (<-chan *OperationStatusChange, CancelFunc, error) {
+	q, cancel, err := c.forOperationID(operationID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.operationStatusChanges(), cancel, nil
+}
+
+// ForAllOperationsChan is like ForAllOperations but returns a channel of changes
+func (c *DatabaseChanges) ForAllOperationsChan(opts *ChangesChannelOptions) (<-chan *OperationStatusChange, CancelFunc, error) {
+	q, cancel, err := c.forAllOperations(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.operationStatusChanges(), cancel, nil
+}
+
+// ForAllIndexesChan is like ForAllIndexes but returns a channel of changes
+func (c *DatabaseChanges) ForAllIndexesChan(opts *ChangesChannelOptions) (<-chan *IndexChange, CancelFunc, error) {
+	q, cancel, err := c.forAllIndexes(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.indexChanges(), cancel, nil
+}
+
+// ForDocumentsStartingWithChan is like ForDocumentsStartingWith but returns a channel of changes
+func (c *DatabaseChanges) ForDocumentsStartingWithChan(docIDPrefix string, opts *ChangesChannelOptions) (<-chan *DocumentChange, CancelFunc, error) {
+	q, cancel, err := c.forDocumentsStartingWith(docIDPrefix, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.documentChanges(), cancel, nil
+}
+
+// ForDocumentsInCollectionChan is like ForDocumentsInCollection but returns a channel of changes
+func (c *DatabaseChanges) ForDocumentsInCollectionChan(collectionName string, opts *ChangesChannelOptions) (<-chan *DocumentChange, CancelFunc, error) {
+	q, cancel, err := c.forDocumentsInCollection(collectionName, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.documentChanges(), cancel, nil
+}
+
+// ForDocumentsInCollectionOfTypeChan is like ForDocumentsInCollectionOfType but returns a channel of changes
+func (c *DatabaseChanges) ForDocumentsInCollectionOfTypeChan(clazz reflect.Type, opts *ChangesChannelOptions) (<-chan *DocumentChange, CancelFunc, error) {
+	collectionName := c.conventions.getCollectionName(clazz)
+	return c.ForDocumentsInCollectionChan(collectionName, opts)
+}
+
+// ForAllCountersChan is like ForAllCounters but returns a channel of changes
+func (c *DatabaseChanges) ForAllCountersChan(opts *ChangesChannelOptions) (<-chan *CounterChange, CancelFunc, error) {
+	q, cancel, err := c.forAllCounters(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.counterChanges(), cancel, nil
+}
+
+// ForCounterChan is like ForCounter but returns a channel of changes
+func (c *DatabaseChanges) ForCounterChan(counterName string, opts *ChangesChannelOptions) (<-chan *CounterChange, CancelFunc, error) {
+	q, cancel, err := c.forCounter(counterName, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.counterChanges(), cancel, nil
+}
+
+// ForCounterOfDocumentChan is like ForCounterOfDocument but returns a channel of changes
+func (c *DatabaseChanges) ForCounterOfDocumentChan(documentID string, counterName string, opts *ChangesChannelOptions) (<-chan *CounterChange, CancelFunc, error) {
+	q, cancel, err := c.forCounterOfDocument(documentID, counterName, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.counterChanges(), cancel, nil
+}
+
+// ForCountersOfDocumentChan is like ForCountersOfDocument but returns a channel of changes
+func (c *DatabaseChanges) ForCountersOfDocumentChan(documentID string, opts *ChangesChannelOptions) (<-chan *CounterChange, CancelFunc, error) {
+	q, cancel, err := c.forCountersOfDocument(documentID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.counterChanges(), cancel, nil
+}
@@ -0,0 +1,160 @@
+package ravendb
+
+import (
+	"time"
+)
+
+// ChangesConnectionState describes state of connection of DatabaseChanges
+// to the server
+type ChangesConnectionState int
+
+const (
+	ChangesConnectionStateConnecting ChangesConnectionState = iota
+	ChangesConnectionStateConnected
+	// ChangesConnectionStateDisconnected means the connection was lost
+	// (or couldn't be established) and DatabaseChanges will try to reconnect
+	ChangesConnectionStateDisconnected
+	// ChangesConnectionStateClosed means DatabaseChanges was closed or
+	// gave up on connecting
+	ChangesConnectionStateClosed
+)
+
+func (s ChangesConnectionState) String() string {
+	switch s {
+	case ChangesConnectionStateConnecting:
+		return "Connecting"
+	case ChangesConnectionStateConnected:
+		return "Connected"
+	case ChangesConnectionStateDisconnected:
+		return "Disconnected"
+	case ChangesConnectionStateClosed:
+		return "Closed"
+	}
+	return "Unknown"
+}
+
+// ChangesConnectionStateChange describes a transition of connection state
+type ChangesConnectionStateChange struct {
+	PreviousState ChangesConnectionState
+	State         ChangesConnectionState
+	// Err is why the connection was lost or couldn't be established
+	Err error
+}
+
+// ChangesGap describes a period during which DatabaseChanges wasn't
+// connected to the server and changes might have been missed
+type ChangesGap struct {
+	DisconnectedAt time.Time
+	ReconnectedAt  time.Time
+	// Err is why the connection was lost
+	Err error
+	// ResubscribeErr is set if not all watch commands were confirmed by
+	// the server after reconnecting, so changes might still be missed
+	ResubscribeErr error
+}
+
+// GetConnectionState returns current state of connection to the server
+func (c *DatabaseChanges) GetConnectionState() ChangesConnectionState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.connectionState
+}
+
+// AddConnectionStateChanged registers a handler called when state of
+// connection to the server changes. Returns id to be used in
+// RemoveConnectionStateChanged
+func (c *DatabaseChanges) AddConnectionStateChanged(handler func(*ChangesConnectionStateChange)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx := len(c.onConnectionStateChanged)
+	c.onConnectionStateChanged = append(c.onConnectionStateChanged, handler)
+	return idx
+}
+
+func (c *DatabaseChanges) RemoveConnectionStateChanged(handlerID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onConnectionStateChanged[handlerID] = nil
+}
+
+// AddOnPossibleMissedChanges registers a handler called after re-connecting
+// to the server. Changes that happened while disconnected were not delivered
+// so the handler should re-synchronize whatever depends on them (e.g. flush
+// a cache). Returns id to be used in RemoveOnPossibleMissedChanges
+func (c *DatabaseChanges) AddOnPossibleMissedChanges(handler func(*ChangesGap)) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx := len(c.onPossibleMissedChanges)
+	c.onPossibleMissedChanges = append(c.onPossibleMissedChanges, handler)
+	return idx
+}
+
+func (c *DatabaseChanges) RemoveOnPossibleMissedChanges(handlerID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onPossibleMissedChanges[handlerID] = nil
+}
+
+func (c *DatabaseChanges) setConnectionState(state ChangesConnectionState, err error) {
+	c.mu.Lock()
+	previousState := c.connectionState
+	if previousState == state {
+		c.mu.Unlock()
+		return
+	}
+	c.connectionState = state
+	// make a copy so that we can call outside of a lock
+	handlers := append([]func(*ChangesConnectionStateChange){}, c.onConnectionStateChanged...)
+	c.mu.Unlock()
+
+	change := &ChangesConnectionStateChange{
+		PreviousState: previousState,
+		State:         state,
+		Err:           err,
+	}
+	for _, fn := range handlers {
+		if fn != nil {
+			fn(change)
+		}
+	}
+}
+
+func (c *DatabaseChanges) invokePossibleMissedChanges(gap *ChangesGap) {
+	c.conventions.GetLogger().Log(LogLevelWarn, "changes reconnected, changes might have been missed", "database", c.database, "disconnectedAt", gap.DisconnectedAt, "error", gap.Err)
+
+	// make a copy so that we can call outside of a lock
+	c.mu.Lock()
+	handlers := append([]func(*ChangesGap){}, c.onPossibleMissedChanges...)
+	c.mu.Unlock()
+
+	for _, fn := range handlers {
+		if fn != nil {
+			fn(gap)
+		}
+	}
+}
+
+// resubscribe re-issues watch commands of all active subscriptions and
+// waits for the server to confirm them
+func (c *DatabaseChanges) resubscribe() error {
+	var failed []string
+	var firstErr error
+	c.subscribers.Range(func(key, value interface{}) bool {
+		subscribers := value.(*changeSubscribers)
+		confirmed, err := c.sendCommand(subscribers.watchCommand, subscribers.commandValue, subscribers.commandValues, true)
+		if err == nil && !confirmed {
+			err = NewTimeoutError("Server didn't confirm '%s'", fmtDCCommand(subscribers.watchCommand, subscribers.commandValue))
+		}
+		if err != nil {
+			failed = append(failed, subscribers.name)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return true
+	})
+	if firstErr != nil {
+		return newRuntimeError("Failed to re-subscribe to %d watch command(s) %v: %s", len(failed), failed, firstErr.Error(), firstErr)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeChangesServer confirms all commands and lets the test drop
+// the connection and send changes
+type fakeChangesServer struct {
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	commands chan string
+}
+
+func (s *fakeChangesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+	for {
+		var cmd struct {
+			CommandID int    `json:"CommandId"`
+			Command   string `json:"Command"`
+			Param     string `json:"Param"`
+		}
+		if err := conn.ReadJSON(&cmd); err != nil {
+			return
+		}
+		s.commands <- fmtDCCommand(cmd.Command, cmd.Param)
+		msg := []map[string]interface{}{
+			{"Type": "Confirm", "CommandId": cmd.CommandID},
+		}
+		s.mu.Lock()
+		err = conn.WriteJSON(msg)
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeChangesServer) send(msg interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WriteJSON(msg)
+}
+
+func (s *fakeChangesServer) dropConnection() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.conn.Close()
+}
+
+func waitForString(t *testing.T, ch chan string, exp string) {
+	select {
+	case got := <-ch:
+		assert.Equal(t, exp, got)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "timed out waiting for "+exp)
+	}
+}
+
+func TestDatabaseChangesResubscribesAfterReconnect(t *testing.T) {
+	server := &fakeChangesServer{
+		commands: make(chan string, 16),
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	conventions := NewDocumentConventions()
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(httpServer.URL, "db", nil, nil, conventions)
+	changes := newDatabaseChanges(re, "db", nil)
+	defer changes.Close()
+
+	states := make(chan string, 16)
+	changes.AddConnectionStateChanged(func(change *ChangesConnectionStateChange) {
+		states <- change.State.String()
+	})
+	gaps := make(chan *ChangesGap, 1)
+	changes.AddOnPossibleMissedChanges(func(gap *ChangesGap) {
+		gaps <- gap
+	})
+
+	err := changes.EnsureConnectedNow()
+	assert.NoError(t, err)
+	assert.Equal(t, ChangesConnectionStateConnected, changes.GetConnectionState())
+
+	chChanges, cancel, err := changes.ForDocumentChan("users/1", nil)
+	assert.NoError(t, err)
+	defer cancel()
+	waitForString(t, server.commands, "watch-doc users/1")
+
+	server.dropConnection()
+	select {
+	case gap := <-gaps:
+		assert.False(t, gap.DisconnectedAt.IsZero())
+		assert.True(t, gap.ReconnectedAt.After(gap.DisconnectedAt))
+		assert.Error(t, gap.Err)
+		assert.NoError(t, gap.ResubscribeErr)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "timed out waiting for reconnect")
+	}
+	// re-subscribed after reconnecting
+	waitForString(t, server.commands, "watch-doc users/1")
+
+	waitForString(t, states, "Connected")
+	waitForString(t, states, "Disconnected")
+	waitForString(t, states, "Connecting")
+	waitForString(t, states, "Connected")
+
+	msg := []map[string]interface{}{
+		{
+			"Type": "DocumentChange",
+			"Value": map[string]interface{}{
+				"Type": "Put",
+				"Id":   "users/1",
+			},
+		},
+	}
+	err = server.send(msg)
+	assert.NoError(t, err)
+	select {
+	case change := <-chChanges:
+		assert.Equal(t, "users/1", change.ID)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "timed out waiting for change")
+	}
+}
@@ -0,0 +1,136 @@
+package ravendb
+
+import (
+	"sync"
+)
+
+// ChangesOverflowPolicy decides what happens when a consumer of changes
+// doesn't keep up and the buffer of changes is full
+type ChangesOverflowPolicy int
+
+const (
+	// ChangesOverflowBlock waits until there's space in the buffer. It
+	// delays delivery of all changes of DatabaseChanges until then
+	ChangesOverflowBlock ChangesOverflowPolicy = iota
+	// ChangesOverflowDropOldest discards the oldest buffered change
+	ChangesOverflowDropOldest
+	// ChangesOverflowError stops the subscription: the channel is closed
+	// after already buffered changes and ChangesBufferOverflowError is sent to handlers registered with
+	// DatabaseChanges.AddOnError
+	ChangesOverflowError
+)
+
+const defaultChangesBufferSize = 64
+
+// ChangesChannelOptions describes how changes are buffered
+type ChangesChannelOptions struct {
+	// BufferSize is the number of changes buffered for a consumer.
+	// Values < 1 mean default of 64
+	BufferSize     int
+	OverflowPolicy ChangesOverflowPolicy
+}
+
+// changesQueue buffers changes for a single subscriber so that they're
+// delivered outside of the goroutine reading from the websocket
+type changesQueue struct {
+	policy ChangesOverflowPolicy
+	ch     chan interface{}
+	// closed when subscription is cancelled
+	done     chan struct{}
+	doneOnce sync.Once
+
+	// called (once) when changes overflow with ChangesOverflowError
+	onOverflow func(err error)
+
+	mu     sync.Mutex // protects closed and sending to ch
+	closed bool
+}
+
+func newChangesQueue(opts *ChangesChannelOptions) *changesQueue {
+	bufferSize := defaultChangesBufferSize
+	policy := ChangesOverflowBlock
+	if opts != nil {
+		if opts.BufferSize > 0 {
+			bufferSize = opts.BufferSize
+		}
+		policy = opts.OverflowPolicy
+	}
+	return &changesQueue{
+		policy: policy,
+		ch:     make(chan interface{}, bufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+func (q *changesQueue) push(change interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+
+	switch q.policy {
+	case ChangesOverflowDropOldest:
+		for {
+			select {
+			case q.ch <- change:
+				return
+			default:
+			}
+			// buffer is full, make space
+			select {
+			case <-q.ch:
+			default:
+			}
+		}
+	case ChangesOverflowError:
+		select {
+		case q.ch <- change:
+		default:
+			q.closeLocked()
+			if q.onOverflow != nil {
+				err := newChangesBufferOverflowError("Buffer of %d changes is full", cap(q.ch))
+				// unsubscribing sends a command to the server so
+				// don't block the caller
+				go q.onOverflow(err)
+			}
+		}
+	default:
+		select {
+		case q.ch <- change:
+		case <-q.done:
+		}
+	}
+}
+
+func (q *changesQueue) isCancelled() bool {
+	select {
+	case <-q.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *changesQueue) close() {
+	// unblocks push() waiting for space in the buffer
+	q.cancel()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closeLocked()
+}
+
+func (q *changesQueue) cancel() {
+	q.doneOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *changesQueue) closeLocked() {
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.ch)
+}
@@ -0,0 +1,106 @@
+package ravendb
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangesQueueDropOldest(t *testing.T) {
+	q := newChangesQueue(&ChangesChannelOptions{
+		BufferSize:     2,
+		OverflowPolicy: ChangesOverflowDropOldest,
+	})
+	for i := 1; i <= 4; i++ {
+		q.push(&DocumentChange{ID: "users/" + strconv.Itoa(i)})
+	}
+	ch := q.documentChanges()
+	assert.Equal(t, "users/3", (<-ch).ID)
+	assert.Equal(t, "users/4", (<-ch).ID)
+
+	q.close()
+	_, ok := <-ch
+	assert.False(t, ok)
+	// no-op after close
+	q.push(&DocumentChange{ID: "users/5"})
+}
+
+func TestChangesQueueError(t *testing.T) {
+	q := newChangesQueue(&ChangesChannelOptions{
+		BufferSize:     1,
+		OverflowPolicy: ChangesOverflowError,
+	})
+	chErr := make(chan error, 1)
+	q.onOverflow = func(err error) {
+		chErr <- err
+	}
+	q.push(&IndexChange{Name: "Users"})
+	q.push(&IndexChange{Name: "Orders"})
+
+	select {
+	case err := <-chErr:
+		_, ok := err.(*ChangesBufferOverflowError)
+		assert.True(t, ok, "err is %T", err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for overflow")
+	}
+	// buffered changes are delivered before closing the channel
+	ch := q.indexChanges()
+	assert.Equal(t, "Users", (<-ch).Name)
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestChangesQueueBlock(t *testing.T) {
+	q := newChangesQueue(&ChangesChannelOptions{
+		BufferSize: 1,
+	})
+	chDispatched := make(chan string, 4)
+	chRelease := make(chan bool)
+	q.dispatch(func(change interface{}) {
+		<-chRelease
+		chDispatched <- change.(*CounterChange).Name
+	})
+
+	chPushed := make(chan bool)
+	go func() {
+		for _, name := range []string{"likes", "dislikes", "shares"} {
+			q.push(&CounterChange{Name: name})
+		}
+		close(chPushed)
+	}()
+
+	// dispatcher holds one change and the buffer another one
+	select {
+	case <-chPushed:
+		assert.Fail(t, "push should block when buffer is full")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	close(chRelease)
+	<-chPushed
+	for _, exp := range []string{"likes", "dislikes", "shares"} {
+		assert.Equal(t, exp, <-chDispatched)
+	}
+	q.close()
+}
+
+func TestChangesQueueCloseUnblocksPush(t *testing.T) {
+	q := newChangesQueue(&ChangesChannelOptions{
+		BufferSize: 1,
+	})
+	q.push(&OperationStatusChange{OperationID: 1})
+	chPushed := make(chan bool)
+	go func() {
+		q.push(&OperationStatusChange{OperationID: 2})
+		close(chPushed)
+	}()
+	q.close()
+	select {
+	case <-chPushed:
+	case <-time.After(time.Second):
+		assert.Fail(t, "timed out waiting for push")
+	}
+}
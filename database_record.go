@@ -1,12 +1,55 @@
 package ravendb
 
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
 // DatabaseRecord represents database record
 type DatabaseRecord struct {
 	DatabaseName         string            `json:"DatabaseName"`
 	Disabled             bool              `json:"Disabled"`
+	Encrypted            bool              `json:"Encrypted"`
+	EtagForBackup        int64             `json:"EtagForBackup"`
 	DataDirectory        string            `json:"DataDirectory,omitempty"`
-	Settings             map[string]string `json:"Settings"`
-	ConflictSolverConfig *ConflictSolver   `json:"ConflictSolverConfig"`
+	Settings             map[string]string `json:"Settings,omitempty"`
+	ConflictSolverConfig *ConflictSolver   `json:"ConflictSolverConfig,omitempty"`
+
+	// DeletionInProgress maps node tag to status of deletion of the database
+	DeletionInProgress map[string]string `json:"DeletionInProgress,omitempty"`
+	Topology           *DatabaseTopology `json:"Topology,omitempty"`
+
+	Indexes     map[string]*IndexDefinition     `json:"Indexes,omitempty"`
+	AutoIndexes map[string]*AutoIndexDefinition `json:"AutoIndexes,omitempty"`
+	Sorters     map[string]*SorterDefinition    `json:"Sorters,omitempty"`
+
+	Revisions  *RevisionsConfiguration  `json:"Revisions,omitempty"`
+	Expiration *ExpirationConfiguration `json:"Expiration,omitempty"`
+	Refresh    *RefreshConfiguration    `json:"Refresh,omitempty"`
+	Client     *ClientConfiguration     `json:"Client,omitempty"`
+	Studio     *StudioConfiguration     `json:"Studio,omitempty"`
+
+	PeriodicBackups        []*PeriodicBackupConfiguration    `json:"PeriodicBackups,omitempty"`
+	ExternalReplications   []*ExternalReplication            `json:"ExternalReplications,omitempty"`
+	RavenConnectionStrings map[string]*RavenConnectionString `json:"RavenConnectionStrings,omitempty"`
+	SqlConnectionStrings   map[string]*SqlConnectionString   `json:"SqlConnectionStrings,omitempty"`
+	RavenEtls              []*RavenEtlConfiguration          `json:"RavenEtls,omitempty"`
+	SqlEtls                []*SqlEtlConfiguration            `json:"SqlEtls,omitempty"`
+
+	TruncatedClusterTransactionCommandsCount int64 `json:"TruncatedClusterTransactionCommandsCount"`
+
+	// UnknownFields has fields of the record returned by the server that
+	// are not modeled by DatabaseRecord (e.g. those added in newer versions
+	// of the server). They are sent back to the server when the record is
+	// serialized so that updating a record doesn't lose them
+	UnknownFields map[string]json.RawMessage `json:"-"`
+
+	// raw is the JSON the record was deserialized from. Fields not modeled
+	// by nested types (e.g. PeriodicBackups[].S3Settings) are taken from it
+	// when the record is serialized
+	raw json.RawMessage
 }
 
 // NewDatabaseRecord returns new database record
@@ -15,3 +58,236 @@ func NewDatabaseRecord() *DatabaseRecord {
 		Settings: map[string]string{},
 	}
 }
+
+// StudioConfiguration describes configuration of the studio for a database
+type StudioConfiguration struct {
+	Disabled bool `json:"Disabled"`
+	// Environment is "None", "Development", "Testing" or "Production"
+	Environment string `json:"Environment"`
+}
+
+// AutoIndexFieldOptions describes a field of an auto index
+type AutoIndexFieldOptions struct {
+	Storage              FieldStorage  `json:"Storage,omitempty"`
+	Indexing             FieldIndexing `json:"Indexing,omitempty"`
+	Aggregation          string        `json:"Aggregation,omitempty"`
+	GroupByArrayBehavior string        `json:"GroupByArrayBehavior,omitempty"`
+	Suggestions          *bool         `json:"Suggestions"`
+	IsNameQuoted         bool          `json:"IsNameQuoted"`
+}
+
+// AutoIndexDefinition describes an index created by the server for
+// a dynamic query
+type AutoIndexDefinition struct {
+	Type          IndexType                         `json:"Type,omitempty"`
+	Name          string                            `json:"Name"`
+	Collection    string                            `json:"Collection"`
+	Priority      IndexPriority                     `json:"Priority,omitempty"`
+	MapFields     map[string]*AutoIndexFieldOptions `json:"MapFields"`
+	GroupByFields map[string]*AutoIndexFieldOptions `json:"GroupByFields"`
+}
+
+// databaseRecordJSON has the same fields as DatabaseRecord but not
+// its MarshalJSON / UnmarshalJSON methods
+type databaseRecordJSON DatabaseRecord
+
+var (
+	databaseRecordFieldsOnce sync.Once
+	databaseRecordFields     map[string]reflect.Type
+)
+
+// getDatabaseRecordFields returns names of JSON fields modeled by DatabaseRecord
+func getDatabaseRecordFields() map[string]reflect.Type {
+	databaseRecordFieldsOnce.Do(func() {
+		databaseRecordFields = getJSONFields(reflect.TypeOf(DatabaseRecord{}))
+	})
+	return databaseRecordFields
+}
+
+// getJSONFields returns JSON names and types of fields of struct typ,
+// including fields of embedded structs
+func getJSONFields(typ reflect.Type) map[string]reflect.Type {
+	res := map[string]reflect.Type{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for name, typ := range getJSONFields(ft) {
+					res[name] = typ
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		res[name] = field.Type
+	}
+	return res
+}
+
+// mergeUnknownJSONFields returns d (JSON of a value of type typ) with fields
+// of objects in orig (JSON the value was deserialized from) that are not
+// modeled by typ or its nested types. Elements of arrays are matched by
+// TaskId or Name, or by position if the arrays have the same length
+func mergeUnknownJSONFields(d json.RawMessage, orig json.RawMessage, typ reflect.Type) (json.RawMessage, error) {
+	if typ == nil {
+		return d, nil
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if len(orig) == 0 || typ.Implements(jsonMarshalerType) || reflect.PtrTo(typ).Implements(jsonMarshalerType) {
+		return d, nil
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		var m, origM map[string]json.RawMessage
+		if json.Unmarshal(d, &m) != nil || json.Unmarshal(orig, &origM) != nil || m == nil || origM == nil {
+			return d, nil
+		}
+		fields := getJSONFields(typ)
+		for name, v := range origM {
+			if _, known := fields[name]; !known {
+				m[name] = v
+			}
+		}
+		for name, v := range m {
+			fieldType, known := fields[name]
+			if !known {
+				continue
+			}
+			merged, err := mergeUnknownJSONFields(v, origM[name], fieldType)
+			if err != nil {
+				return nil, err
+			}
+			m[name] = merged
+		}
+		return json.Marshal(m)
+	case reflect.Map:
+		var m, origM map[string]json.RawMessage
+		if json.Unmarshal(d, &m) != nil || json.Unmarshal(orig, &origM) != nil || m == nil || origM == nil {
+			return d, nil
+		}
+		for key, v := range m {
+			merged, err := mergeUnknownJSONFields(v, origM[key], typ.Elem())
+			if err != nil {
+				return nil, err
+			}
+			m[key] = merged
+		}
+		return json.Marshal(m)
+	case reflect.Slice, reflect.Array:
+		var a, origA []json.RawMessage
+		if json.Unmarshal(d, &a) != nil || json.Unmarshal(orig, &origA) != nil || a == nil || origA == nil {
+			return d, nil
+		}
+		for i, v := range a {
+			origV := findJSONArrayElement(origA, v)
+			if origV == nil && len(a) == len(origA) {
+				origV = origA[i]
+			}
+			merged, err := mergeUnknownJSONFields(v, origV, typ.Elem())
+			if err != nil {
+				return nil, err
+			}
+			a[i] = merged
+		}
+		return json.Marshal(a)
+	}
+	return d, nil
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// findJSONArrayElement returns an object in a with the same TaskId or Name
+// as object v
+func findJSONArrayElement(a []json.RawMessage, v json.RawMessage) json.RawMessage {
+	var m map[string]json.RawMessage
+	if json.Unmarshal(v, &m) != nil {
+		return nil
+	}
+	for _, key := range []string{"TaskId", "Name"} {
+		id := string(m[key])
+		if id == "" || id == "0" || id == `""` || id == "null" {
+			continue
+		}
+		for _, el := range a {
+			var elM map[string]json.RawMessage
+			if json.Unmarshal(el, &elM) == nil && string(elM[key]) == id {
+				return el
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// MarshalJSON serializes the record together with UnknownFields and fields
+// not modeled by nested types of the JSON the record was deserialized from
+func (r DatabaseRecord) MarshalJSON() ([]byte, error) {
+	d, err := json.Marshal(databaseRecordJSON(r))
+	if err != nil || (len(r.UnknownFields) == 0 && len(r.raw) == 0) {
+		return d, err
+	}
+	var m map[string]json.RawMessage
+	if err = json.Unmarshal(d, &m); err != nil {
+		return nil, err
+	}
+	var orig map[string]json.RawMessage
+	if len(r.raw) > 0 {
+		if err = json.Unmarshal(r.raw, &orig); err != nil {
+			return nil, err
+		}
+	}
+	known := getDatabaseRecordFields()
+	for name, v := range m {
+		if m[name], err = mergeUnknownJSONFields(v, orig[name], known[name]); err != nil {
+			return nil, err
+		}
+	}
+	for name, v := range r.UnknownFields {
+		if _, ok := m[name]; !ok {
+			m[name] = v
+		}
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON deserializes the record and remembers fields that are not
+// modeled in UnknownFields
+func (r *DatabaseRecord) UnmarshalJSON(d []byte) error {
+	var res databaseRecordJSON
+	if err := json.Unmarshal(d, &res); err != nil {
+		return err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(d, &m); err != nil {
+		return err
+	}
+	known := getDatabaseRecordFields()
+	for name := range m {
+		if _, ok := known[name]; ok {
+			delete(m, name)
+		}
+	}
+	res.UnknownFields = nil
+	if len(m) > 0 {
+		res.UnknownFields = m
+	}
+	res.raw = append(json.RawMessage(nil), d...)
+	*r = DatabaseRecord(res)
+	return nil
+}
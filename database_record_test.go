@@ -0,0 +1,131 @@
+package ravendb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseRecordKeepsUnknownFields(t *testing.T) {
+	js := `{"DatabaseName":"db","Disabled":false,"Etag":12,"Topology":{"Members":["A"],"ReplicationFactor":1},"Sorters":{"MySorter":{"Name":"MySorter","Code":"class"}},"Expiration":{"Disabled":false,"DeleteFrequencyInSec":60},"HubPullReplications":[{"Name":"hub"}],"LockMode":"Unlock"}`
+	var record *DatabaseRecordWithEtag
+	err := jsonUnmarshal([]byte(js), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, "db", record.DatabaseName)
+	assert.Equal(t, int64(12), record.Etag)
+	assert.Equal(t, []string{"A"}, record.Topology.Members)
+	assert.Equal(t, "class", record.Sorters["MySorter"].Code)
+	assert.Equal(t, int64(60), *record.Expiration.DeleteFrequencyInSec)
+	assert.Equal(t, 2, len(record.UnknownFields))
+	assert.Equal(t, `"Unlock"`, string(record.UnknownFields["LockMode"]))
+
+	d, err := jsonMarshal(&record.DatabaseRecord)
+	assert.NoError(t, err)
+	var m map[string]interface{}
+	err = json.Unmarshal(d, &m)
+	assert.NoError(t, err)
+	assert.Equal(t, "Unlock", m["LockMode"])
+	assert.Equal(t, "hub", m["HubPullReplications"].([]interface{})[0].(map[string]interface{})["Name"])
+	assert.Equal(t, "db", m["DatabaseName"])
+	_, hasEtag := m["Etag"]
+	assert.False(t, hasEtag)
+
+	d, err = jsonMarshal(record)
+	assert.NoError(t, err)
+	m = nil
+	err = json.Unmarshal(d, &m)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(12), m["Etag"])
+	assert.Equal(t, "Unlock", m["LockMode"])
+	// marshaling doesn't modify the record
+	assert.Equal(t, 2, len(record.UnknownFields))
+}
+
+func TestUpdateDatabaseCommandRequest(t *testing.T) {
+	node := &ServerNode{
+		URL: "http://localhost:8080",
+	}
+	record := &DatabaseRecordWithEtag{
+		DatabaseRecord: DatabaseRecord{
+			DatabaseName: "db",
+			Topology: &DatabaseTopology{
+				ReplicationFactor: 3,
+			},
+		},
+		Etag: 7,
+	}
+	cmd, err := NewUpdateDatabaseRecordOperation(record).GetCommand(nil)
+	assert.NoError(t, err)
+	req, err := cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/admin/databases?name=db&replicationFactor=3", req.URL.String())
+	assert.Equal(t, `"7"`, req.Header.Get(headersEtag))
+
+	cmd, err = NewCreateDatabaseOperation(NewDatabaseRecord(), 1).GetCommand(nil)
+	assert.Error(t, err)
+}
+
+func TestDatabaseRecordKeepsNestedUnknownFields(t *testing.T) {
+	js := `{
+		"DatabaseName": "db",
+		"Settings": {"Indexing.MapTimeoutInSec": "30"},
+		"PeriodicBackups": [
+			{"TaskId": 1, "Name": "local", "BackupType": "Backup", "LocalSettings": {"Disabled": false, "FolderPath": "/backups"}, "RetentionPolicy": {"Disabled": false, "MinimumBackupAgeToKeep": "1.00:00:00"}},
+			{"TaskId": 2, "Name": "s3", "BackupType": "Backup", "S3Settings": {"BucketName": "bucket", "AwsRegionName": "us-east-1"}}
+		],
+		"Indexes": {
+			"Orders/Totals": {"Name": "Orders/Totals", "Maps": ["from o in docs.Orders select new { o.Company }"], "Type": "MapReduce", "OutputReduceToCollection": "Totals", "PatternForOutputReduceToCollection": "totals/{Company}"}
+		}
+	}`
+	var record *DatabaseRecordWithEtag
+	err := jsonUnmarshal([]byte(js), &record)
+	assert.NoError(t, err)
+	assert.Nil(t, record.UnknownFields)
+
+	// modify the record, remove the first backup
+	record.Settings["Indexing.MapTimeoutInSec"] = "60"
+	record.PeriodicBackups = record.PeriodicBackups[1:]
+	record.PeriodicBackups[0].Name = "s3-renamed"
+
+	d, err := jsonMarshal(record)
+	assert.NoError(t, err)
+	var m map[string]interface{}
+	err = json.Unmarshal(d, &m)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "60", m["Settings"].(map[string]interface{})["Indexing.MapTimeoutInSec"])
+	backups := m["PeriodicBackups"].([]interface{})
+	assert.Equal(t, 1, len(backups))
+	backup := backups[0].(map[string]interface{})
+	assert.Equal(t, "s3-renamed", backup["Name"])
+	assert.Equal(t, "bucket", backup["S3Settings"].(map[string]interface{})["BucketName"])
+	// unknown fields of the removed backup are not applied to another one
+	_, hasRetentionPolicy := backup["RetentionPolicy"]
+	assert.False(t, hasRetentionPolicy)
+	_, hasLocalSettings := backup["LocalSettings"]
+	assert.False(t, hasLocalSettings)
+
+	index := m["Indexes"].(map[string]interface{})["Orders/Totals"].(map[string]interface{})
+	assert.Equal(t, "totals/{Company}", index["PatternForOutputReduceToCollection"])
+	assert.Equal(t, "MapReduce", index["Type"])
+
+	// optional fields that are not set are not serialized as null
+	d, err = jsonMarshal(&DatabaseRecord{
+		DatabaseName:    "db",
+		PeriodicBackups: []*PeriodicBackupConfiguration{{Name: "backup"}},
+		Indexes:         map[string]*IndexDefinition{"Users": {Name: "Users"}},
+	})
+	assert.NoError(t, err)
+	m = nil
+	err = json.Unmarshal(d, &m)
+	assert.NoError(t, err)
+	for _, name := range []string{"Settings", "ConflictSolverConfig"} {
+		_, ok := m[name]
+		assert.False(t, ok, "%s is serialized", name)
+	}
+	_, ok := m["PeriodicBackups"].([]interface{})[0].(map[string]interface{})["LocalSettings"]
+	assert.False(t, ok)
+	_, ok = m["Indexes"].(map[string]interface{})["Users"].(map[string]interface{})["Type"]
+	assert.False(t, ok)
+}
@@ -1,7 +1,45 @@
 package ravendb
 
+import (
+	"encoding/json"
+)
+
 // DatabaseRecordWithEtag represents database record with etag
 type DatabaseRecordWithEtag struct {
 	DatabaseRecord
 	Etag int64 `json:"Etag"`
 }
+
+// MarshalJSON is needed because DatabaseRecord.MarshalJSON would be
+// used otherwise and Etag would not be serialized
+func (r DatabaseRecordWithEtag) MarshalJSON() ([]byte, error) {
+	record := r.DatabaseRecord
+	record.UnknownFields = map[string]json.RawMessage{}
+	for name, v := range r.DatabaseRecord.UnknownFields {
+		record.UnknownFields[name] = v
+	}
+	etag, err := json.Marshal(r.Etag)
+	if err != nil {
+		return nil, err
+	}
+	record.UnknownFields["Etag"] = etag
+	return record.MarshalJSON()
+}
+
+// UnmarshalJSON is needed because DatabaseRecord.UnmarshalJSON would be
+// used otherwise and Etag would not be deserialized
+func (r *DatabaseRecordWithEtag) UnmarshalJSON(d []byte) error {
+	if err := r.DatabaseRecord.UnmarshalJSON(d); err != nil {
+		return err
+	}
+	r.Etag = 0
+	etag, ok := r.UnknownFields["Etag"]
+	if !ok {
+		return nil
+	}
+	delete(r.UnknownFields, "Etag")
+	if len(r.UnknownFields) == 0 {
+		r.UnknownFields = nil
+	}
+	return json.Unmarshal(etag, &r.Etag)
+}
@@ -0,0 +1,59 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &DeleteOngoingTaskOperation{}
+)
+
+// DeleteOngoingTaskOperation deletes an ongoing task e.g. an ETL task
+type DeleteOngoingTaskOperation struct {
+	taskID   int64
+	taskType OngoingTaskType
+
+	Command *DeleteOngoingTaskCommand
+}
+
+// NewDeleteOngoingTaskOperation returns new DeleteOngoingTaskOperation
+func NewDeleteOngoingTaskOperation(taskID int64, taskType OngoingTaskType) *DeleteOngoingTaskOperation {
+	return &DeleteOngoingTaskOperation{
+		taskID:   taskID,
+		taskType: taskType,
+	}
+}
+
+func (o *DeleteOngoingTaskOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = &DeleteOngoingTaskCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		taskID:   o.taskID,
+		taskType: o.taskType,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &DeleteOngoingTaskCommand{}
+
+// DeleteOngoingTaskCommand is a command for DeleteOngoingTaskOperation
+type DeleteOngoingTaskCommand struct {
+	RavenCommandBase
+
+	taskID   int64
+	taskType OngoingTaskType
+
+	Result *ModifyOngoingTaskResult
+}
+
+func (c *DeleteOngoingTaskCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/tasks?id=" + i64toa(c.taskID) + "&type=" + c.taskType
+	return newHttpDelete(url, nil)
+}
+
+func (c *DeleteOngoingTaskCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
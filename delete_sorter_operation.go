@@ -0,0 +1,50 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &DeleteSorterOperation{}
+)
+
+// DeleteSorterOperation deletes a custom sorter of a database
+type DeleteSorterOperation struct {
+	sorterName string
+
+	Command *DeleteSorterCommand
+}
+
+// NewDeleteSorterOperation returns new DeleteSorterOperation
+func NewDeleteSorterOperation(sorterName string) *DeleteSorterOperation {
+	return &DeleteSorterOperation{
+		sorterName: sorterName,
+	}
+}
+
+func (o *DeleteSorterOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if stringIsBlank(o.sorterName) {
+		return nil, newIllegalArgumentError("sorterName cannot be empty")
+	}
+	o.Command = &DeleteSorterCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		sorterName: o.sorterName,
+	}
+	o.Command.ResponseType = RavenCommandResponseTypeEmpty
+	return o.Command, nil
+}
+
+var _ RavenCommand = &DeleteSorterCommand{}
+
+// DeleteSorterCommand is a command for DeleteSorterOperation
+type DeleteSorterCommand struct {
+	RavenCommandBase
+
+	sorterName string
+}
+
+func (c *DeleteSorterCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/sorters?name=" + urlUtilsEscapeDataString(c.sorterName)
+	return newHttpDelete(url, nil)
+}
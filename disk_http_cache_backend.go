@@ -0,0 +1,317 @@
+package ravendb
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	diskHTTPCacheFileExt        = ".cache"
+	diskHTTPCacheTempFilePrefix = "http-cache-"
+	diskHTTPCacheTempFileExt    = ".tmp"
+
+	// temporary files older than that are assumed to be left by a process
+	// that crashed while writing. Younger files might be written right now
+	// by another process sharing the directory
+	diskHTTPCacheStaleTempFileAge = time.Hour
+)
+
+// DiskHTTPCacheBackend is HTTPCacheBackend that keeps cached responses in
+// files in a directory. The directory can be shared by multiple processes
+// (e.g. replicas of a service or a restarted process) so that they can
+// re-validate responses with the server (which responds with 304 Not Modified
+// if they didn't change) instead of fetching them again.
+//
+// Files are written to a temporary file and atomically renamed, and have
+// a checksum, so that responses written partially (e.g. when a process
+// crashes) are ignored. Total size of files is kept below maxSize by
+// deleting least recently used files. With multiple processes it's
+// approximate because each process only knows about files it has seen.
+type DiskHTTPCacheBackend struct {
+	dir     string
+	maxSize int64
+
+	mu        sync.Mutex
+	files     map[string]*list.Element
+	lru       *list.List // of *diskHTTPCacheFile, most recently used first
+	size      int64
+	evictions int64
+}
+
+type diskHTTPCacheFile struct {
+	name string
+	size int64
+}
+
+// diskHTTPCacheHeader is the first line of a cache file, followed by payload
+type diskHTTPCacheHeader struct {
+	URL          string `json:"URL"`
+	ChangeVector string `json:"ChangeVector"`
+	Generation   int64  `json:"Generation"`
+	Size         int    `json:"Size"`
+	Checksum     uint32 `json:"Checksum"`
+}
+
+var _ HTTPCacheBackend = &DiskHTTPCacheBackend{}
+
+// NewDiskHTTPCacheBackend returns DiskHTTPCacheBackend that keeps up to
+// maxSize bytes of responses in files in dir. The directory is created if it
+// doesn't exist and files cached by previous processes are re-used
+func NewDiskHTTPCacheBackend(dir string, maxSize int64) (*DiskHTTPCacheBackend, error) {
+	if dir == "" {
+		return nil, newIllegalArgumentError("dir cannot be empty")
+	}
+	if maxSize <= 0 {
+		return nil, newIllegalArgumentError("maxSize must be positive")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &DiskHTTPCacheBackend{
+		dir:     dir,
+		maxSize: maxSize,
+		files:   map[string]*list.Element{},
+		lru:     list.New(),
+	}
+
+	// the most recently used (i.e. modified, see NotModified) files first
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().After(infos[j].ModTime())
+	})
+	for _, info := range infos {
+		name := info.Name()
+		switch {
+		case info.IsDir():
+		case strings.HasPrefix(name, diskHTTPCacheTempFilePrefix) && strings.HasSuffix(name, diskHTTPCacheTempFileExt):
+			if time.Since(info.ModTime()) > diskHTTPCacheStaleTempFileAge {
+				_ = os.Remove(filepath.Join(dir, name))
+			}
+		case strings.HasSuffix(name, diskHTTPCacheFileExt):
+			res.files[name] = res.lru.PushBack(&diskHTTPCacheFile{
+				name: name,
+				size: info.Size(),
+			})
+			res.size += info.Size()
+		}
+	}
+	res.mu.Lock()
+	res.evictLocked()
+	res.mu.Unlock()
+	return res, nil
+}
+
+func diskHTTPCacheFileName(url string) string {
+	h := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(h[:]) + diskHTTPCacheFileExt
+}
+
+// Get returns the response cached for url or nil
+func (c *DiskHTTPCacheBackend) Get(url string) *HTTPCacheEntry {
+	name := diskHTTPCacheFileName(url)
+	path := filepath.Join(c.dir, name)
+	entry, size, ok := readDiskHTTPCacheFile(path, url)
+	if !ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if el := c.files[name]; el != nil {
+			// deleted by another process or corrupted
+			c.removeLocked(el)
+		}
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(name, size)
+	return entry
+}
+
+// readDiskHTTPCacheFile returns entry, size of the file and false if the file
+// doesn't exist or is not valid (in which case it's deleted)
+func readDiskHTTPCacheFile(path string, url string) (*HTTPCacheEntry, int64, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, false
+	}
+	d, err := ioutil.ReadAll(f)
+	_ = f.Close()
+	if err != nil {
+		return nil, 0, false
+	}
+
+	var hdr diskHTTPCacheHeader
+	valid := false
+	if idx := bytes.IndexByte(d, '\n'); idx >= 0 {
+		payload := d[idx+1:]
+		valid = jsonUnmarshal(d[:idx], &hdr) == nil && hdr.Size == len(payload) && hdr.Checksum == crc32.ChecksumIEEE(payload)
+		d = payload
+	}
+	if !valid {
+		_ = os.Remove(path)
+		return nil, 0, false
+	}
+	if hdr.URL != url {
+		// sha256 collision, very unlikely
+		return nil, 0, false
+	}
+	res := &HTTPCacheEntry{
+		ChangeVector:     hdr.ChangeVector,
+		Payload:          d,
+		LastServerUpdate: info.ModTime(),
+		Generation:       hdr.Generation,
+	}
+	if len(d) == 0 {
+		res.Payload = nil
+	}
+	return res, info.Size(), true
+}
+
+// Set caches the response for url, possibly deleting least recently used
+// files. Errors are ignored because the cache is an optimization
+func (c *DiskHTTPCacheBackend) Set(url string, entry *HTTPCacheEntry) {
+	hdr := &diskHTTPCacheHeader{
+		URL:          url,
+		ChangeVector: entry.ChangeVector,
+		Generation:   entry.Generation,
+		Size:         len(entry.Payload),
+		Checksum:     crc32.ChecksumIEEE(entry.Payload),
+	}
+	hdrJSON, err := jsonMarshal(hdr)
+	if err != nil {
+		return
+	}
+	size := int64(len(hdrJSON) + 1 + len(entry.Payload))
+	name := diskHTTPCacheFileName(url)
+	if size > c.maxSize {
+		// the previous response must not be read back by Get
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		_ = os.Remove(filepath.Join(c.dir, name))
+		if el := c.files[name]; el != nil {
+			c.removeLocked(el)
+		}
+		return
+	}
+
+	tmp, err := ioutil.TempFile(c.dir, diskHTTPCacheTempFilePrefix+"*"+diskHTTPCacheTempFileExt)
+	if err != nil {
+		return
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(append(hdrJSON, '\n'))
+	if err == nil {
+		_, err = tmp.Write(entry.Payload)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chtimes(tmpPath, entry.LastServerUpdate, entry.LastServerUpdate)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(name, size)
+}
+
+// NotModified records the time the response was confirmed by the server as
+// modification time of its file
+func (c *DiskHTTPCacheBackend) NotModified(url string, at time.Time) {
+	name := diskHTTPCacheFileName(url)
+	if err := os.Chtimes(filepath.Join(c.dir, name), at, at); err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el := c.files[name]; el != nil {
+		c.lru.MoveToFront(el)
+	}
+}
+
+// Clear deletes all cached responses, including those cached by other
+// processes
+func (c *DiskHTTPCacheBackend) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	infos, _ := ioutil.ReadDir(c.dir)
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), diskHTTPCacheFileExt) {
+			_ = os.Remove(filepath.Join(c.dir, info.Name()))
+		}
+	}
+	c.files = map[string]*list.Element{}
+	c.lru.Init()
+	c.size = 0
+}
+
+// Stats returns statistics of the cache
+func (c *DiskHTTPCacheBackend) Stats() *HTTPCacheBackendStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &HTTPCacheBackendStats{
+		Items:     len(c.files),
+		Bytes:     c.size,
+		MaxBytes:  c.maxSize,
+		Evictions: c.evictions,
+	}
+}
+
+// addLocked adds (or updates) the file as the most recently used one
+func (c *DiskHTTPCacheBackend) addLocked(name string, size int64) {
+	if el := c.files[name]; el != nil {
+		file := el.Value.(*diskHTTPCacheFile)
+		c.size += size - file.size
+		file.size = size
+		c.lru.MoveToFront(el)
+	} else {
+		c.files[name] = c.lru.PushFront(&diskHTTPCacheFile{
+			name: name,
+			size: size,
+		})
+		c.size += size
+	}
+	c.evictLocked()
+}
+
+func (c *DiskHTTPCacheBackend) evictLocked() {
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		el := c.lru.Back()
+		file := el.Value.(*diskHTTPCacheFile)
+		_ = os.Remove(filepath.Join(c.dir, file.name))
+		c.removeLocked(el)
+		c.evictions++
+	}
+}
+
+func (c *DiskHTTPCacheBackend) removeLocked(el *list.Element) {
+	file := c.lru.Remove(el).(*diskHTTPCacheFile)
+	delete(c.files, file.name)
+	c.size -= file.size
+}
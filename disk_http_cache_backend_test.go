@@ -0,0 +1,183 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskHTTPCacheBackendPersistsEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http_cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend, err := NewDiskHTTPCacheBackend(dir, 1024)
+	require.NoError(t, err)
+	lastServerUpdate := time.Now().Add(-time.Hour).Truncate(time.Second)
+	backend.Set("/databases/db/docs?id=users/1", &HTTPCacheEntry{
+		ChangeVector:     "A:1",
+		Payload:          []byte(`{"Results":[]}`),
+		LastServerUpdate: lastServerUpdate,
+		Generation:       5,
+	})
+	assert.Nil(t, backend.Get("/databases/db/docs?id=users/2"))
+
+	// a different process
+	backend, err = NewDiskHTTPCacheBackend(dir, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backend.Stats().Items)
+	entry := backend.Get("/databases/db/docs?id=users/1")
+	require.NotNil(t, entry)
+	assert.Equal(t, "A:1", entry.ChangeVector)
+	assert.Equal(t, `{"Results":[]}`, string(entry.Payload))
+	assert.Equal(t, int64(5), entry.Generation)
+	assert.True(t, entry.LastServerUpdate.Equal(lastServerUpdate))
+
+	now := time.Now().Truncate(time.Second)
+	backend.NotModified("/databases/db/docs?id=users/1", now)
+	entry = backend.Get("/databases/db/docs?id=users/1")
+	assert.True(t, entry.LastServerUpdate.Equal(now))
+
+	backend.Clear()
+	assert.Nil(t, backend.Get("/databases/db/docs?id=users/1"))
+	assert.Equal(t, 0, backend.Stats().Items)
+}
+
+func TestDiskHTTPCacheBackendIgnoresCorruptedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http_cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend, err := NewDiskHTTPCacheBackend(dir, 1024)
+	require.NoError(t, err)
+	url := "/databases/db/stats"
+	backend.Set(url, &HTTPCacheEntry{ChangeVector: "A:1", Payload: []byte(`{"CountOfDocuments":1}`)})
+
+	// simulate a crash while writing
+	path := filepath.Join(dir, diskHTTPCacheFileName(url))
+	d, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(path, d[:len(d)-3], 0644)
+	require.NoError(t, err)
+	tmpPath := filepath.Join(dir, diskHTTPCacheTempFilePrefix+"123"+diskHTTPCacheTempFileExt)
+	err = ioutil.WriteFile(tmpPath, d[:10], 0644)
+	require.NoError(t, err)
+	stale := time.Now().Add(-2 * diskHTTPCacheStaleTempFileAge)
+	err = os.Chtimes(tmpPath, stale, stale)
+	require.NoError(t, err)
+	// might be written right now by another process sharing the directory
+	freshTmpPath := filepath.Join(dir, diskHTTPCacheTempFilePrefix+"456"+diskHTTPCacheTempFileExt)
+	err = ioutil.WriteFile(freshTmpPath, d[:10], 0644)
+	require.NoError(t, err)
+	// not created by the cache
+	otherTmpPath := filepath.Join(dir, "other"+diskHTTPCacheTempFileExt)
+	err = ioutil.WriteFile(otherTmpPath, d[:10], 0644)
+	require.NoError(t, err)
+	err = os.Chtimes(otherTmpPath, stale, stale)
+	require.NoError(t, err)
+
+	backend, err = NewDiskHTTPCacheBackend(dir, 1024)
+	require.NoError(t, err)
+	_, err = os.Stat(tmpPath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(freshTmpPath)
+	assert.NoError(t, err)
+	_, err = os.Stat(otherTmpPath)
+	assert.NoError(t, err)
+	assert.Nil(t, backend.Get(url))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, 0, backend.Stats().Items)
+}
+
+func TestDiskHTTPCacheBackendEvictsLeastRecentlyUsed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http_cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend, err := NewDiskHTTPCacheBackend(dir, 500)
+	require.NoError(t, err)
+	payload := []byte(strings.Repeat("x", 150))
+	backend.Set("/1", &HTTPCacheEntry{ChangeVector: "A:1", Payload: payload})
+	backend.Set("/2", &HTTPCacheEntry{ChangeVector: "A:1", Payload: payload})
+	assert.NotNil(t, backend.Get("/1"))
+	backend.Set("/3", &HTTPCacheEntry{ChangeVector: "A:1", Payload: payload})
+
+	assert.Nil(t, backend.Get("/2"))
+	assert.NotNil(t, backend.Get("/1"))
+	assert.NotNil(t, backend.Get("/3"))
+	stats := backend.Stats()
+	assert.Equal(t, 2, stats.Items)
+	assert.Equal(t, int64(1), stats.Evictions)
+	assert.True(t, stats.Bytes <= stats.MaxBytes)
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(infos))
+
+	// too big to be cached
+	backend.Set("/4", &HTTPCacheEntry{ChangeVector: "A:1", Payload: []byte(strings.Repeat("x", 600))})
+	assert.Nil(t, backend.Get("/4"))
+
+	// a new response that is too big replaces the previous one
+	bytes := backend.Stats().Bytes
+	backend.Set("/3", &HTTPCacheEntry{ChangeVector: "A:2", Payload: []byte(strings.Repeat("x", 600))})
+	assert.Nil(t, backend.Get("/3"))
+	_, err = os.Stat(filepath.Join(dir, diskHTTPCacheFileName("/3")))
+	assert.True(t, os.IsNotExist(err))
+	stats = backend.Stats()
+	assert.Equal(t, 1, stats.Items)
+	assert.True(t, stats.Bytes < bytes)
+}
+
+func TestDiskHTTPCacheBackendSharedByRequestExecutors(t *testing.T) {
+	var fullResponses int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headersIfNoneMatch) == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&fullResponses, 1)
+		w.Header().Set(headersEtag, `"1"`)
+		_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "http_cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// request executors of 2 processes sharing the directory
+	for i := 0; i < 2; i++ {
+		backend, err := NewDiskHTTPCacheBackend(dir, 1024*1024)
+		require.NoError(t, err)
+		conventions := NewDocumentConventions()
+		conventions.HTTPCacheBackend = backend
+		re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, conventions)
+
+		cmd := NewGetStatisticsCommand("")
+		err = re.ExecuteCommand(cmd, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), cmd.Result.CountOfDocuments)
+
+		stats := re.GetHTTPCacheStats()
+		if i == 0 {
+			assert.Equal(t, int64(1), stats.Misses)
+		} else {
+			assert.Equal(t, int64(1), stats.Hits)
+			assert.Equal(t, int64(1), stats.NotModified)
+		}
+		re.Close()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fullResponses))
+}
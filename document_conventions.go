@@ -46,6 +46,23 @@ type DocumentConventions struct {
 
 	maxHttpCacheSize int
 
+	// Logger receives logs from RequestExecutor, DatabaseChanges,
+	// SubscriptionWorker and BulkInsertOperation. No logging if nil
+	Logger Logger
+
+	// RequestObserver is set on RequestExecutors created with these
+	// conventions. It's notified about every request they send
+	RequestObserver RequestObserver
+
+	// HTTPMiddlewares wrap transport of http clients of RequestExecutors
+	// created with these conventions. The first one is the outermost
+	HTTPMiddlewares []HTTPMiddleware
+
+	// HTTPCacheBackend stores responses cached by RequestExecutors created
+	// with these conventions. If nil, each RequestExecutor caches up to
+	// GetMaxHttpCacheSize bytes in memory
+	HTTPCacheBackend HTTPCacheBackend
+
 	// a pointer to silence go vet when copying DocumentConventions wholesale
 	mu *sync.Mutex
 }
@@ -73,10 +90,27 @@ func NewDocumentConventions() *DocumentConventions {
 	}
 }
 
-func (c *DocumentConventions) getMaxHttpCacheSize() int {
+// GetMaxHttpCacheSize returns maximum size (in bytes) of responses cached
+// by RequestExecutor
+func (c *DocumentConventions) GetMaxHttpCacheSize() int {
 	return c.maxHttpCacheSize
 }
 
+// SetMaxHttpCacheSize sets maximum size (in bytes) of responses cached by
+// RequestExecutor. When the cache is full, least recently used responses are
+// evicted. Default is 128 MB
+func (c *DocumentConventions) SetMaxHttpCacheSize(size int) {
+	c.maxHttpCacheSize = size
+}
+
+// GetLogger returns Logger or a no-op logger if Logger is not set
+func (c *DocumentConventions) GetLogger() Logger {
+	if c == nil || c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
 func (c *DocumentConventions) Freeze() {
 	c.frozen = true
 }
@@ -156,6 +190,7 @@ func (c *DocumentConventions) Clone() *DocumentConventions {
 	res := *c
 	// mutex carries its locking state so we need to re-initialize it
 	res.mu = &sync.Mutex{}
+	res.HTTPMiddlewares = append([]HTTPMiddleware(nil), c.HTTPMiddlewares...)
 	return &res
 }
 
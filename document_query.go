@@ -130,7 +130,16 @@ func (q *DocumentQuery) OrderByScoreDescending() *DocumentQuery {
 	return q
 }
 
-//TBD 4.1  IDocumentQuery<T> explainScores() {
+// IncludeExplanations requests Lucene explanations of scores of results.
+// explanations is set to Explanations that are filled when the query
+// is executed. options can be nil
+func (q *DocumentQuery) IncludeExplanations(options *ExplanationOptions, explanations **Explanations) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.includeExplanations(options, explanations)
+	return q
+}
 
 // WaitForNonStaleResults waits for non-stale results for a given waitTimeout.
 // Timeout of 0 means default timeout.
@@ -281,13 +290,88 @@ func (q *DocumentQuery) NoCaching() *DocumentQuery {
 	return q
 }
 
-//TBD 4.1  IDocumentQuery<T> showTimings()
+// Timings requests timings of query stages from the server. timings is set
+// to QueryTimings that are filled when the query is executed
+func (q *DocumentQuery) Timings(timings **QueryTimings) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.includeTimings(timings)
+	return q
+}
 
 func (q *DocumentQuery) Include(path string) *DocumentQuery {
 	q.include(path)
 	return q
 }
 
+// IncludeCounter includes the value of a given counter of returned documents
+func (q *DocumentQuery) IncludeCounter(name string) *DocumentQuery {
+	q.includeCounter(name)
+	return q
+}
+
+// IncludeCounters includes values of given counters of returned documents
+func (q *DocumentQuery) IncludeCounters(names []string) *DocumentQuery {
+	for _, name := range names {
+		q.includeCounter(name)
+	}
+	return q
+}
+
+// IncludeAllCounters includes values of all counters of returned documents
+func (q *DocumentQuery) IncludeAllCounters() *DocumentQuery {
+	q.includeAllCounters()
+	return q
+}
+
+// Highlight requests fragments of a field (at most fragmentCount fragments
+// of at most fragmentLength characters) with highlighted search terms.
+// highlightings is set to Highlightings that are filled when the query
+// is executed. The field must be searched with Search and be indexed
+// with Store (FieldStorageYes), Index (FieldIndexingSearch) and TermVector
+// (FieldTermVectorWithPositionsAndOffsets). options can be nil
+func (q *DocumentQuery) Highlight(fieldName string, fragmentLength int, fragmentCount int, options *HighlightingOptions, highlightings **Highlightings) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.highlight(fieldName, fragmentLength, fragmentCount, options, highlightings)
+	return q
+}
+
+// IncludeTimeSeries includes entries of a given time series of returned
+// documents within [from, to]. nil from or to means that the range is not bounded
+func (q *DocumentQuery) IncludeTimeSeries(name string, from *time.Time, to *time.Time) *DocumentQuery {
+	q.includeTimeSeries(name, from, to)
+	return q
+}
+
+// SelectTimeSeries projects results of the query to results of a time series
+// query e.g. "from HeartRate between $start and $end group by '1 hour' select max()".
+// Results should be *[]*TimeSeriesAggregationResult for queries with
+// group by and *[]*TimeSeriesRawResult otherwise. Use AddParameter to
+// set query parameters, with ravendb.Time for time values
+func (q *DocumentQuery) SelectTimeSeries(projectionType reflect.Type, timeSeriesQuery string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	if stringIsBlank(timeSeriesQuery) {
+		q.err = newIllegalArgumentError("timeSeriesQuery cannot be empty")
+		return q
+	}
+	queryData := &QueryData{
+		Fields:           []string{"timeseries(" + timeSeriesQuery + ")"},
+		Projections:      []string{},
+		isCustomFunction: true,
+	}
+	res, err := q.createDocumentQueryInternal(projectionType, queryData)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return res
+}
+
 //TBD expr IDocumentQuery<T> IDocumentQueryBase<T, IDocumentQuery<T>>.Include(Expression<Func<T, object>> path)
 
 func (q *DocumentQuery) Not() *DocumentQuery {
@@ -536,8 +620,6 @@ func (q *DocumentQuery) RandomOrderingWithSeed(seed string) *DocumentQuery {
 	return q
 }
 
-//TBD 4.1  IDocumentQuery<T> customSortUsing(string typeName, bool descending)
-
 // GroupBy makes a query grouped by fields
 func (q *DocumentQuery) GroupBy(fieldName string, fieldNames ...string) *GroupByDocumentQuery {
 	res := newGroupByDocumentQuery(q)
@@ -592,6 +674,26 @@ func (q *DocumentQuery) OrderByDescendingWithOrdering(field string, ordering Ord
 
 //TBD expr  IDocumentQuery<T> OrderByDescending<TValue>(params Expression<Func<T, TValue>>[] propertySelectors)
 
+// OrderByCustom orders query results by a field using a custom sorter
+// (see PutSortersOperation)
+func (q *DocumentQuery) OrderByCustom(field string, sorterName string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.orderByWithSorter(field, sorterName, false)
+	return q
+}
+
+// OrderByCustomDescending orders query results by a field using a custom
+// sorter in descending order
+func (q *DocumentQuery) OrderByCustomDescending(field string, sorterName string) *DocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.orderByWithSorter(field, sorterName, true)
+	return q
+}
+
 // AddBeforeQueryExecutedListener adds a listener that will be called before query
 // is executed
 func (q *DocumentQuery) AddBeforeQueryExecutedListener(action func(*IndexQuery)) int {
@@ -672,6 +774,11 @@ func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.
 	query.negate = q.negate
 	//noinspection unchecked
 	query.includes = stringArrayCopy(q.includes)
+	query.counterIncludes = stringArrayCopy(q.counterIncludes)
+	query.allCountersIncluded = q.allCountersIncluded
+	query.timeSeriesIncludes = append([]*TimeSeriesRange(nil), q.timeSeriesIncludes...)
+	query.highlightingTokens = append([]*highlightingToken(nil), q.highlightingTokens...)
+	query.queryHighlightings = append(queryHighlightings(nil), q.queryHighlightings...)
 	// TODO: should this be deep copy so that adding/removing in one
 	// doesn't affect the other?
 	query.beforeQueryExecutedCallback = q.beforeQueryExecutedCallback
@@ -679,8 +786,9 @@ func (q *abstractDocumentQuery) createDocumentQueryInternal(resultClass reflect.
 	query.afterStreamExecutedCallback = q.afterStreamExecutedCallback
 	query.disableEntitiesTracking = q.disableEntitiesTracking
 	query.disableCaching = q.disableCaching
-	//TBD 4.1 ShowQueryTimings = ShowQueryTimings,
-	//TBD 4.1 query.shouldExplainScores = shouldExplainScores;
+	query.queryTimings = q.queryTimings
+	query.explanationToken = q.explanationToken
+	query.explanations = q.explanations
 	query.isIntersect = q.isIntersect
 	query.defaultOperator = q.defaultOperator
 
@@ -94,7 +94,7 @@ func (s *DocumentSession) SaveChanges() error {
 	defer func() {
 		_ = command.Close()
 	}()
-	err = s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
+	err = s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 	if err != nil {
 		return err
 	}
@@ -117,7 +117,7 @@ func (s *DocumentSession) Exists(id string) (bool, error) {
 	}
 	command := NewHeadDocumentCommand(id, nil)
 
-	if err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo); err != nil {
+	if err := s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo); err != nil {
 		return false, err
 	}
 
@@ -142,7 +142,7 @@ func (s *DocumentSession) Refresh(entity interface{}) error {
 	if err != nil {
 		return err
 	}
-	if err = s.requestExecutor.ExecuteCommand(command, s.sessionInfo); err != nil {
+	if err = s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo); err != nil {
 		return err
 	}
 	return s.refreshInternal(entity, command, documentInfo)
@@ -210,7 +210,7 @@ func (s *DocumentSession) executeLazyOperationsSingleStep(responseTimeInformatio
 	}
 	multiGetCommand := multiGetOperation.createRequest(requests)
 
-	err := s.GetRequestExecutor().ExecuteCommand(multiGetCommand, s.sessionInfo)
+	err := s.GetRequestExecutor().ExecuteCommandWithContext(s.ctx, multiGetCommand, s.sessionInfo)
 	if err != nil {
 		return false, err
 	}
@@ -244,6 +244,22 @@ func (s *DocumentSession) Include(path string) *MultiLoaderWithInclude {
 	return NewMultiLoaderWithInclude(s).Include(path)
 }
 
+// IncludeCounter starts a load that includes the value of a given counter
+func (s *DocumentSession) IncludeCounter(name string) *MultiLoaderWithInclude {
+	return NewMultiLoaderWithInclude(s).IncludeCounter(name)
+}
+
+// IncludeTimeSeries starts a load that includes entries of a given time series
+// within [from, to]
+func (s *DocumentSession) IncludeTimeSeries(name string, from *time.Time, to *time.Time) *MultiLoaderWithInclude {
+	return NewMultiLoaderWithInclude(s).IncludeTimeSeries(name, from, to)
+}
+
+// IncludeAllCounters starts a load that includes values of all counters
+func (s *DocumentSession) IncludeAllCounters() *MultiLoaderWithInclude {
+	return NewMultiLoaderWithInclude(s).IncludeAllCounters()
+}
+
 func (s *DocumentSession) addLazyOperation(operation ILazyOperation, onEval func(), onEvalResult interface{}) *Lazy {
 	s.pendingLazyOperations = append(s.pendingLazyOperations, operation)
 
@@ -349,7 +365,7 @@ func (s *DocumentSession) Load(result interface{}, id string) error {
 	}
 
 	if command != nil {
-		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
+		err := s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 		if err != nil {
 			return err
 		}
@@ -414,7 +430,7 @@ func (s *DocumentSession) loadInternalWithOperation(ids []string, operation *Loa
 		return err
 	}
 	if command != nil {
-		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
+		err := s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 		if err != nil {
 			return err
 		}
@@ -432,7 +448,7 @@ func (s *DocumentSession) loadInternalWithOperation(ids []string, operation *Loa
 }
 
 // results should be map[string]*struct
-func (s *DocumentSession) loadInternalMulti(results interface{}, ids []string, includes []string) error {
+func (s *DocumentSession) loadInternalMulti(results interface{}, ids []string, includes []string, counterIncludes []string, includeAllCounters bool, timeSeriesIncludes []*TimeSeriesRange) error {
 	if len(ids) == 0 {
 		return newIllegalArgumentError("ids cannot be empty array")
 	}
@@ -440,13 +456,15 @@ func (s *DocumentSession) loadInternalMulti(results interface{}, ids []string, i
 	loadOperation := NewLoadOperation(s.InMemoryDocumentSessionOperations)
 	loadOperation.byIds(ids)
 	loadOperation.withIncludes(includes)
+	loadOperation.withCounters(counterIncludes, includeAllCounters)
+	loadOperation.withTimeSeries(timeSeriesIncludes)
 
 	command, err := loadOperation.createRequest()
 	if err != nil {
 		return err
 	}
 	if command != nil {
-		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
+		err := s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 		if err != nil {
 			return err
 		}
@@ -494,7 +512,7 @@ func (s *DocumentSession) loadStartingWithInternal(idPrefix string, operation *L
 		return nil, err
 	}
 	if command != nil {
-		err := s.requestExecutor.ExecuteCommand(command, s.sessionInfo)
+		err := s.requestExecutor.ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -870,7 +888,7 @@ func (s *DocumentSession) StreamQuery(query *DocumentQuery, streamQueryStats *St
 	if err != nil {
 		return nil, err
 	}
-	err = s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
+	err = s.GetRequestExecutor().ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -896,7 +914,7 @@ func (s *DocumentSession) StreamRawQuery(query *RawDocumentQuery, streamQuerySta
 	if err != nil {
 		return nil, err
 	}
-	err = s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
+	err = s.GetRequestExecutor().ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -922,7 +940,7 @@ func (s *DocumentSession) StreamRawQueryInto(query *RawDocumentQuery, output io.
 	if err != nil {
 		return err
 	}
-	err = s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
+	err = s.GetRequestExecutor().ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 	if err != nil {
 		return err
 	}
@@ -944,7 +962,7 @@ func (s *DocumentSession) StreamQueryInto(query *DocumentQuery, output io.Writer
 	if err != nil {
 		return err
 	}
-	err = s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
+	err = s.GetRequestExecutor().ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 	if err != nil {
 		return err
 	}
@@ -1001,7 +1019,7 @@ func (s *DocumentSession) Stream(args *StartsWithArgs) (*StreamIterator, error)
 	streamOperation := NewStreamOperation(s.InMemoryDocumentSessionOperations, nil)
 
 	command := streamOperation.createRequest(args.StartsWith, args.Matches, args.Start, args.PageSize, "", args.StartAfter)
-	err := s.GetRequestExecutor().ExecuteCommand(command, s.sessionInfo)
+	err := s.GetRequestExecutor().ExecuteCommandWithContext(s.ctx, command, s.sessionInfo)
 	if err != nil {
 		return nil, err
 	}
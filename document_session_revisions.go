@@ -34,7 +34,7 @@ func (r *DocumentSessionRevisions) GetForPaged(results interface{}, id string, s
 	if err != nil {
 		return err
 	}
-	err = r.requestExecutor.ExecuteCommand(command, r.sessionInfo)
+	err = r.requestExecutor.ExecuteCommandWithContext(r.session.ctx, command, r.sessionInfo)
 	if err != nil {
 		return err
 	}
@@ -59,7 +59,7 @@ func (r *DocumentSessionRevisions) GetMetadataForPaged(id string, start int, pag
 	if err != nil {
 		return nil, err
 	}
-	err = r.requestExecutor.ExecuteCommand(command, r.sessionInfo)
+	err = r.requestExecutor.ExecuteCommandWithContext(r.session.ctx, command, r.sessionInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +73,7 @@ func (r *DocumentSessionRevisions) Get(result interface{}, changeVector string)
 	if err != nil {
 		return err
 	}
-	err = r.requestExecutor.ExecuteCommand(command, r.sessionInfo)
+	err = r.requestExecutor.ExecuteCommandWithContext(r.session.ctx, command, r.sessionInfo)
 	if err != nil {
 		return err
 	}
@@ -88,7 +88,7 @@ func (r *DocumentSessionRevisions) GetRevisions(results interface{}, changeVecto
 	if err != nil {
 		return err
 	}
-	err = r.requestExecutor.ExecuteCommand(command, r.sessionInfo);
+	err = r.requestExecutor.ExecuteCommandWithContext(r.session.ctx, command, r.sessionInfo);
 	if err != nil {
 		return err
 	}
@@ -66,6 +66,37 @@ func (s *DocumentStore) GetConventions() *DocumentConventions {
 	return s.conventions
 }
 
+// SetLogger sets Logger that receives logs of the store's request executors,
+// changes, subscriptions and bulk inserts. Must be called before Initialize
+func (s *DocumentStore) SetLogger(logger Logger) {
+	s.assertNotInitialized("logger")
+	s.GetConventions().Logger = logger
+}
+
+// SetRequestObserver sets RequestObserver notified about every request sent
+// by the store's request executors. Must be called before Initialize
+func (s *DocumentStore) SetRequestObserver(observer RequestObserver) {
+	s.assertNotInitialized("request observer")
+	s.GetConventions().RequestObserver = observer
+}
+
+// AddHTTPMiddleware adds HTTPMiddleware to http clients of the store's
+// request executors. Middlewares are called in the order they were added.
+// Must be called before Initialize
+func (s *DocumentStore) AddHTTPMiddleware(middleware HTTPMiddleware) {
+	s.assertNotInitialized("http middleware")
+	conventions := s.GetConventions()
+	conventions.HTTPMiddlewares = append(conventions.HTTPMiddlewares, middleware)
+}
+
+// SetHTTPCacheBackend sets HTTPCacheBackend that stores responses cached by
+// the store's request executors e.g. DiskHTTPCacheBackend to share them
+// between processes. Must be called before Initialize
+func (s *DocumentStore) SetHTTPCacheBackend(backend HTTPCacheBackend) {
+	s.assertNotInitialized("http cache backend")
+	s.GetConventions().HTTPCacheBackend = backend
+}
+
 // SetConventions sets DocumentConventions
 func (s *DocumentStore) SetConventions(conventions *DocumentConventions) {
 	s.assertNotInitialized("conventions")
@@ -331,6 +362,12 @@ func (s *DocumentStore) OpenSessionWithOptions(options *SessionOptions) (*Docume
 		requestExecutor = s.GetRequestExecutor(databaseName)
 	}
 	session := NewDocumentSession(databaseName, s, sessionID, requestExecutor)
+	if options.Context != nil {
+		session.ctx = options.Context
+	}
+	if options.TransactionMode != "" {
+		session.transactionMode = options.TransactionMode
+	}
 	s.registerEvents(session.InMemoryDocumentSessionOperations)
 	s.afterSessionCreated(session.InMemoryDocumentSessionOperations)
 	return session, nil
@@ -449,29 +486,28 @@ func (s *DocumentStore) Changes(database string) *DatabaseChanges {
 		database = s.GetDatabase()
 	}
 
+	panicIf(database == "", "database can't be empty string")
+	// GetRequestExecutor takes s.mu
+	re := s.GetRequestExecutor(database)
+
+	// looked up and created under the lock so that concurrent callers
+	// don't create (and leak) multiple instances
 	s.mu.Lock()
+	defer s.mu.Unlock()
 	changes, ok := s.databaseChanges[database]
-	s.mu.Unlock()
-
 	if !ok {
-		changes = s.createDatabaseChanges(database)
-
-		s.mu.Lock()
+		changes = s.createDatabaseChanges(re, database)
 		s.databaseChanges[database] = changes
-		s.mu.Unlock()
-
 	}
 	return changes
 }
 
-func (s *DocumentStore) createDatabaseChanges(database string) *DatabaseChanges {
-	panicIf(database == "", "database can't be empty string")
+func (s *DocumentStore) createDatabaseChanges(re *RequestExecutor, database string) *DatabaseChanges {
 	onDispose := func() {
 		s.mu.Lock()
 		delete(s.databaseChanges, database)
 		s.mu.Unlock()
 	}
-	re := s.GetRequestExecutor(database)
 	return newDatabaseChanges(re, database, onDispose)
 }
 
@@ -35,6 +35,13 @@ func (s *DocumentSubscriptions) Create(options *SubscriptionCreationOptions, dat
 	}
 	requestExecutor := s.store.GetRequestExecutor(database)
 
+	if len(options.Includes) > 0 {
+		optionsCopy := *options
+		optionsCopy.Query = options.getQueryWithIncludes()
+		optionsCopy.Includes = nil
+		options = &optionsCopy
+	}
+
 	command := newCreateSubscriptionCommand(s.store.GetConventions(), options, "")
 	if err := requestExecutor.ExecuteCommand(command, nil); err != nil {
 		return "", err
@@ -54,6 +61,8 @@ func (s *DocumentSubscriptions) CreateForType(clazz reflect.Type, options *Subsc
 		Name:         options.Name,
 		ChangeVector: options.ChangeVector,
 		Query:        options.Query,
+		MentorNode:   options.MentorNode,
+		Includes:     options.Includes,
 	}
 
 	opts := s.ensureCriteria(creationOptions, clazz, false)
@@ -70,6 +79,8 @@ func (s *DocumentSubscriptions) CreateForRevisions(clazz reflect.Type, options *
 		Name:         options.Name,
 		ChangeVector: options.ChangeVector,
 		Query:        options.Query,
+		MentorNode:   options.MentorNode,
+		Includes:     options.Includes,
 	}
 
 	opts := s.ensureCriteria(creationOptions, clazz, true)
@@ -1,6 +1,7 @@
 package ravendb
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -27,6 +28,11 @@ func (e *errorBase) WrappedError() error {
 	return e.wrapped
 }
 
+// Unwrap returns a wrapped error, so that errors.Is() and errors.As() can see it
+func (e *errorBase) Unwrap() error {
+	return e.wrapped
+}
+
 type iWrappedError interface {
 	WrappedError() error
 }
@@ -67,6 +73,19 @@ func newRuntimeError(format string, args ...interface{}) *RuntimeError {
 	return res
 }
 
+// ChangesBufferOverflowError is reported when a subscription to changes
+// with ChangesOverflowError policy is stopped because its consumer
+// didn't keep up
+type ChangesBufferOverflowError struct {
+	RavenError
+}
+
+func newChangesBufferOverflowError(format string, args ...interface{}) *ChangesBufferOverflowError {
+	res := &ChangesBufferOverflowError{}
+	res.setErrorf(format, args...)
+	return res
+}
+
 // UnsupportedOperationError represents unsupported operation error
 type UnsupportedOperationError struct {
 	errorBase
@@ -310,6 +329,24 @@ func newSubscriptionDoesNotBelongToNodeError(format string, args ...interface{})
 	return res
 }
 
+// SubscriptionItemsError is returned by SubscriptionWorker.RunItems when
+// processing of some items of a batch failed. The batch is not
+// acknowledged so it will be received again
+type SubscriptionItemsError struct {
+	SubscriptionError
+
+	// Errors maps id of a failed item to the last error returned for it
+	Errors map[string]error
+}
+
+func newSubscriptionItemsError(errors map[string]error, firstID string) *SubscriptionItemsError {
+	res := &SubscriptionItemsError{
+		Errors: errors,
+	}
+	res.setErrorf("Failed to process %d item(s) of subscription batch, error processing %s: %s", len(errors), firstID, errors[firstID].Error(), errors[firstID])
+	return res
+}
+
 // SubscriptionDoesNotExistError is returned when subscription doesn't exist
 type SubscriptionDoesNotExistError struct {
 	SubscriptionError
@@ -357,10 +394,30 @@ func throwCancellationRequested() error {
 	return newOperationCancelledError("")
 }
 
+// newContextDoneError returns an error wrapping ctx.Err() or nil if ctx
+// is not done. Expired deadline is reported as TimeoutError, cancellation
+// as OperationCancelledError
+func newContextDoneError(ctx context.Context, what string) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	if err == context.DeadlineExceeded {
+		return NewTimeoutError("%s: %s", what, err.Error(), err)
+	}
+	return newOperationCancelledError("%s: %s", what, err.Error(), err)
+}
+
 type InvalidQueryError struct {
 	RavenError
 }
 
+func newInvalidQueryError(format string, args ...interface{}) *InvalidQueryError {
+	res := &InvalidQueryError{}
+	res.setErrorf(format, args...)
+	return res
+}
+
 type UnsuccessfulRequestError struct {
 	RavenError
 }
@@ -0,0 +1,94 @@
+package ravendb
+
+// EtlType describes a type of ETL task
+type EtlType = string
+
+const (
+	EtlTypeRaven EtlType = "Raven"
+	EtlTypeSQL   EtlType = "Sql"
+)
+
+// Transformation describes a transformation script of an ETL task
+type Transformation struct {
+	Name     string `json:"Name"`
+	Disabled bool   `json:"Disabled"`
+	// Collections are names of collections whose documents are transformed
+	Collections []string `json:"Collections"`
+	// ApplyToAllDocuments is used instead of Collections to transform
+	// documents of all collections
+	ApplyToAllDocuments bool `json:"ApplyToAllDocuments"`
+	// Script is JavaScript transformation e.g. "loadToOrders(this)"
+	Script string `json:"Script"`
+}
+
+// EtlConfiguration describes settings common to all ETL tasks
+type EtlConfiguration struct {
+	// TaskID is 0 for a new task
+	TaskID                        int64             `json:"TaskId"`
+	Name                          string            `json:"Name"`
+	MentorNode                    string            `json:"MentorNode,omitempty"`
+	ConnectionStringName          string            `json:"ConnectionStringName"`
+	Transforms                    []*Transformation `json:"Transforms"`
+	Disabled                      bool              `json:"Disabled"`
+	AllowEtlOnNonEncryptedChannel bool              `json:"AllowEtlOnNonEncryptedChannel"`
+	EtlType                       EtlType           `json:"EtlType"`
+}
+
+// RavenEtlConfiguration describes ETL task to another RavenDB database
+type RavenEtlConfiguration struct {
+	EtlConfiguration
+	LoadRequestTimeoutInSec *int `json:"LoadRequestTimeoutInSec,omitempty"`
+}
+
+// NewRavenEtlConfiguration returns new RavenEtlConfiguration
+func NewRavenEtlConfiguration() *RavenEtlConfiguration {
+	res := &RavenEtlConfiguration{}
+	res.EtlType = EtlTypeRaven
+	return res
+}
+
+// SqlEtlTable describes a table SQL ETL writes to
+type SqlEtlTable struct {
+	TableName        string `json:"TableName"`
+	DocumentIDColumn string `json:"DocumentIdColumn"`
+	// InsertOnlyMode skips deleting rows before inserting them
+	InsertOnlyMode bool `json:"InsertOnlyMode"`
+}
+
+// SqlEtlConfiguration describes ETL task to a relational database
+type SqlEtlConfiguration struct {
+	EtlConfiguration
+	ParameterizeDeletes bool           `json:"ParameterizeDeletes"`
+	ForceQueryRecompile bool           `json:"ForceQueryRecompile"`
+	QuoteTables         bool           `json:"QuoteTables"`
+	CommandTimeout      *int           `json:"CommandTimeout,omitempty"`
+	SqlTables           []*SqlEtlTable `json:"SqlTables"`
+}
+
+// NewSqlEtlConfiguration returns new SqlEtlConfiguration
+func NewSqlEtlConfiguration() *SqlEtlConfiguration {
+	res := &SqlEtlConfiguration{
+		ParameterizeDeletes: true,
+		QuoteTables:         true,
+	}
+	res.EtlType = EtlTypeSQL
+	return res
+}
+
+// checkEtlConfiguration returns an error if configuration is not
+// *RavenEtlConfiguration or *SqlEtlConfiguration
+func checkEtlConfiguration(configuration interface{}) error {
+	switch c := configuration.(type) {
+	case *RavenEtlConfiguration:
+		if c != nil {
+			return nil
+		}
+	case *SqlEtlConfiguration:
+		if c != nil {
+			return nil
+		}
+	default:
+		return newIllegalArgumentError("configuration must be *RavenEtlConfiguration or *SqlEtlConfiguration, got %T", configuration)
+	}
+	return newIllegalArgumentError("configuration cannot be nil")
+}
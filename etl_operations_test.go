@@ -0,0 +1,107 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEtlCommandsRequests(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+
+	config := NewRavenEtlConfiguration()
+	config.Name = "to-replica"
+	config.ConnectionStringName = "replica"
+	config.Transforms = []*Transformation{
+		{Name: "users", Collections: []string{"Users"}, Script: "loadToUsers(this)"},
+	}
+
+	{
+		cmd, err := NewAddEtlOperation(config).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/etl", req.URL.String())
+		d, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		exp := `{"TaskId":0,"Name":"to-replica","ConnectionStringName":"replica","Transforms":[{"Name":"users","Disabled":false,"Collections":["Users"],"ApplyToAllDocuments":false,"Script":"loadToUsers(this)"}],"Disabled":false,"AllowEtlOnNonEncryptedChannel":false,"EtlType":"Raven"}`
+		assert.Equal(t, exp, string(d))
+
+		_, err = NewAddEtlOperation(config.EtlConfiguration).GetCommand(nil)
+		assert.Error(t, err)
+		var nilConfig *SqlEtlConfiguration
+		_, err = NewAddEtlOperation(nilConfig).GetCommand(nil)
+		assert.Error(t, err)
+	}
+
+	{
+		cmd, err := NewUpdateEtlOperation(3, NewSqlEtlConfiguration()).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/etl?id=3", req.URL.String())
+	}
+
+	{
+		cmd, err := NewResetEtlOperation("to-replica", "users").GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "RESET", req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/etl?configurationName=to-replica&transformationName=users", req.URL.String())
+
+		_, err = NewResetEtlOperation("to-replica", "").GetCommand(nil)
+		assert.Error(t, err)
+	}
+
+	{
+		cmd, err := NewDeleteOngoingTaskOperation(3, OngoingTaskTypeRavenEtl).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/tasks?id=3&type=RavenEtl", req.URL.String())
+	}
+}
+
+func TestGetOngoingTaskInfoCommand(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+
+	op := NewGetOngoingTaskInfoOperation(3, OngoingTaskTypeSQLEtl)
+	cmd, err := op.GetCommand(nil)
+	assert.NoError(t, err)
+	req, err := cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/databases/db/task?key=3&type=SqlEtl", req.URL.String())
+
+	js := `{"TaskId":3,"TaskType":"SqlEtl","TaskState":"Enabled","TaskConnectionStatus":"Active","TaskName":"orders","ResponsibleNode":{"NodeTag":"A"},"DestinationDatabase":"Orders","Configuration":{"TaskId":3,"Name":"orders","EtlType":"Sql","SqlTables":[{"TableName":"Orders","DocumentIdColumn":"Id"}]}}`
+	err = cmd.setResponse([]byte(js), false)
+	assert.NoError(t, err)
+	details := op.Command.Result.(*OngoingTaskSqlEtlDetails)
+	assert.Equal(t, OngoingTaskStateEnabled, details.TaskState)
+	assert.Equal(t, OngoingTaskConnectionStatusActive, details.TaskConnectionStatus)
+	assert.Equal(t, "A", details.ResponsibleNode.NodeTag)
+	assert.Equal(t, "Orders", details.DestinationDatabase)
+	assert.Equal(t, "Id", details.Configuration.SqlTables[0].DocumentIDColumn)
+
+	err = cmd.setResponse(nil, false)
+	assert.NoError(t, err)
+	assert.Nil(t, op.Command.Result)
+
+	op = NewGetOngoingTaskInfoOperationWithName("to replica", OngoingTaskTypeRavenEtl)
+	cmd, err = op.GetCommand(nil)
+	assert.NoError(t, err)
+	req, err = cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/databases/db/task?taskName=to+replica&type=RavenEtl", req.URL.String())
+}
@@ -46,6 +46,14 @@ func newEvictItemsFromCacheBasedOnChanges(store *DocumentStore, databaseName str
 		return nil, err
 	}
 
+	// changes that happened while disconnected might have made
+	// cached items stale
+	cbMissedChanges := func(gap *ChangesGap) {
+		cache := res.requestExecutor.Cache
+		cache.incGeneration()
+	}
+	res.changes.AddOnPossibleMissedChanges(cbMissedChanges)
+
 	return res, nil
 }
 
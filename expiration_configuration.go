@@ -0,0 +1,19 @@
+package ravendb
+
+// ExpirationConfiguration describes configuration of deleting expired
+// documents i.e. documents with @expires metadata in the past
+type ExpirationConfiguration struct {
+	Disabled bool `json:"Disabled"`
+	// DeleteFrequencyInSec is how often the server looks for expired
+	// documents. Server's default is used if nil
+	DeleteFrequencyInSec *int64 `json:"DeleteFrequencyInSec"`
+}
+
+// RefreshConfiguration describes configuration of refreshing documents
+// i.e. updating documents with @refresh metadata in the past
+type RefreshConfiguration struct {
+	Disabled bool `json:"Disabled"`
+	// RefreshFrequencyInSec is how often the server looks for documents
+	// to refresh. Server's default is used if nil
+	RefreshFrequencyInSec *int64 `json:"RefreshFrequencyInSec"`
+}
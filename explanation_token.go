@@ -0,0 +1,19 @@
+package ravendb
+
+import "strings"
+
+var _ queryToken = &explanationToken{}
+
+type explanationToken struct {
+	optionsParameterName string
+}
+
+func (t *explanationToken) writeTo(writer *strings.Builder) error {
+	writer.WriteString("explanations(")
+	if t.optionsParameterName != "" {
+		writer.WriteString("$")
+		writer.WriteString(t.optionsParameterName)
+	}
+	writer.WriteString(")")
+	return nil
+}
@@ -0,0 +1,45 @@
+package ravendb
+
+import "sort"
+
+// ExplanationOptions describes options of score explanations
+type ExplanationOptions struct {
+	// GroupKey is a name of the field whose values are used as keys of
+	// Explanations instead of document ids
+	GroupKey string `json:"GroupKey,omitempty"`
+}
+
+// Explanations describes how scores of query results were calculated
+type Explanations struct {
+	explanations map[string][]string
+}
+
+func newExplanations() *Explanations {
+	return &Explanations{
+		explanations: map[string][]string{},
+	}
+}
+
+// GetKeys returns keys (document ids or values of a group key) of results
+// that have explanations
+func (e *Explanations) GetKeys() []string {
+	res := make([]string, 0, len(e.explanations))
+	for key := range e.explanations {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// GetExplanations returns Lucene score explanations of a result with
+// a given key (document id or value of a group key)
+func (e *Explanations) GetExplanations(key string) []string {
+	return e.explanations[key]
+}
+
+func (e *Explanations) update(queryResult *QueryResult) {
+	e.explanations = map[string][]string{}
+	for key, v := range queryResult.Explanations {
+		e.explanations[key] = v
+	}
+}
@@ -5,9 +5,16 @@ type GenericQueryResult struct {
 	queryResultBase
 	TotalResults   int `json:"TotalResults"`
 	SkippedResults int `json:"SkippedResults"`
-	//TBD 4.1  map[string]map[string]List<String>>> highlightings
-	DurationInMs      int64              `json:"DurationInMs"`
-	ScoreExplanations map[string]string  `json:"ScoreExplanation"`
-	TimingsInMs       map[string]float64 `json:"TimingsInMs"`
-	ResultSize        int64              `json:"ResultSize"`
+	// Highlightings maps highlighted field name and document id
+	// (or value of a group key) to highlighted fragments
+	Highlightings     map[string]map[string][]string `json:"Highlightings"`
+	DurationInMs      int64                          `json:"DurationInMs"`
+	ScoreExplanations map[string]string              `json:"ScoreExplanation"`
+	TimingsInMs       map[string]float64             `json:"TimingsInMs"`
+	ResultSize        int64                          `json:"ResultSize"`
+	// Timings is set for queries with "include timings()"
+	Timings *QueryTimings `json:"Timings"`
+	// Explanations maps document id (or value of a group key) to score
+	// explanations and is set for queries with "include explanations()"
+	Explanations map[string][]string `json:"Explanations"`
 }
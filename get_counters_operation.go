@@ -0,0 +1,122 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IOperation = &GetCountersOperation{}
+)
+
+// GetCountersOperation returns values of counters of a document
+type GetCountersOperation struct {
+	docID             string
+	counters          []string
+	returnFullResults bool
+
+	Command *GetCountersCommand
+}
+
+// NewGetCountersOperation returns GetCountersOperation for given counters
+// of a document. If no counters are given, all counters are returned
+func NewGetCountersOperation(docID string, counters ...string) *GetCountersOperation {
+	return &GetCountersOperation{
+		docID:    docID,
+		counters: counters,
+	}
+}
+
+// NewGetCountersOperationWithFullResults is like NewGetCountersOperation but
+// the result also contains values of counters on each node
+func NewGetCountersOperationWithFullResults(docID string, counters ...string) *GetCountersOperation {
+	res := NewGetCountersOperation(docID, counters...)
+	res.returnFullResults = true
+	return res
+}
+
+func (o *GetCountersOperation) GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error) {
+	var err error
+	o.Command, err = NewGetCountersCommand(o.docID, o.counters, o.returnFullResults)
+	return o.Command, err
+}
+
+var _ RavenCommand = &GetCountersCommand{}
+
+// GetCountersCommand is a command for GetCountersOperation
+type GetCountersCommand struct {
+	RavenCommandBase
+
+	docID             string
+	counters          []string
+	returnFullResults bool
+
+	Result *CountersDetail
+}
+
+// NewGetCountersCommand returns new GetCountersCommand
+func NewGetCountersCommand(docID string, counters []string, returnFullResults bool) (*GetCountersCommand, error) {
+	if docID == "" {
+		return nil, newIllegalArgumentError("docID cannot be empty")
+	}
+
+	cmd := &GetCountersCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		docID:             docID,
+		counters:          counters,
+		returnFullResults: returnFullResults,
+	}
+	cmd.IsReadRequest = true
+	return cmd, nil
+}
+
+func (c *GetCountersCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/counters?docId=" + urlUtilsEscapeDataString(c.docID)
+
+	if c.returnFullResults {
+		url += "&full=true"
+	}
+
+	uniqueNames := stringArrayRemoveDuplicatesNoCase(stringArrayCopy(c.counters))
+	totalLen := 0
+	for _, s := range uniqueNames {
+		totalLen += len(s)
+	}
+
+	// if it is too big, we drop to POST (note that means that we can't use the HTTP cache any longer)
+	if totalLen < 1024 {
+		for _, counter := range uniqueNames {
+			url += "&counter=" + urlUtilsEscapeDataString(counter)
+		}
+		return newHttpGet(url)
+	}
+
+	docOps := &DocumentCountersOperation{
+		DocumentID: c.docID,
+	}
+	for _, counter := range uniqueNames {
+		op := &CounterOperation{
+			Type:        CounterOperationTypeGet,
+			CounterName: counter,
+		}
+		docOps.Operations = append(docOps.Operations, op)
+	}
+	batch := &CounterBatch{
+		ReplyWithAllNodesValues: c.returnFullResults,
+		Documents:               []*DocumentCountersOperation{docOps},
+	}
+	d, err := jsonMarshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	url = node.URL + "/databases/" + node.Database + "/counters"
+	return newHttpPost(url, d)
+}
+
+func (c *GetCountersCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return nil
+	}
+
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -17,6 +17,11 @@ type GetDocumentsCommand struct {
 	_ids      []string
 	_includes []string
 
+	_counters           []string
+	_includeAllCounters bool
+
+	_timeSeriesIncludes []*TimeSeriesRange
+
 	_metadataOnly bool
 
 	_startWith  string
@@ -52,6 +57,19 @@ func NewGetDocumentsCommand(ids []string, includes []string, metadataOnly bool)
 	return cmd, nil
 }
 
+// NewGetDocumentsCommandWithCounters is like NewGetDocumentsCommand but the
+// result also includes values of given counters of returned documents or,
+// if includeAllCounters is true, all their counters
+func NewGetDocumentsCommandWithCounters(ids []string, includes []string, counterIncludes []string, includeAllCounters bool, metadataOnly bool) (*GetDocumentsCommand, error) {
+	cmd, err := NewGetDocumentsCommand(ids, includes, metadataOnly)
+	if err != nil {
+		return nil, err
+	}
+	cmd._counters = counterIncludes
+	cmd._includeAllCounters = includeAllCounters
+	return cmd, nil
+}
+
 func NewGetDocumentsCommandFull(startWith string, startAfter string, matches string, exclude string, start int, pageSize int, metadataOnly bool) (*GetDocumentsCommand, error) {
 	if startWith == "" {
 		return nil, newIllegalArgumentError("startWith cannot be null")
@@ -108,6 +126,20 @@ func (c *GetDocumentsCommand) createRequest(node *ServerNode) (*http.Request, er
 		url += include
 	}
 
+	if c._includeAllCounters {
+		url += "&counter=" + CountersAll
+	} else {
+		for _, counter := range c._counters {
+			url += "&counter=" + urlUtilsEscapeDataString(counter)
+		}
+	}
+
+	for _, r := range c._timeSeriesIncludes {
+		url += "&timeseries=" + urlUtilsEscapeDataString(r.Name)
+		url += "&from=" + urlUtilsEscapeDataString(timePtrToServerString(r.From))
+		url += "&to=" + urlUtilsEscapeDataString(timePtrToServerString(r.To))
+	}
+
 	if c._id != "" {
 		url += "&id="
 		url += urlUtilsEscapeDataString(c._id)
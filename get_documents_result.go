@@ -5,4 +5,9 @@ type GetDocumentsResult struct {
 	Includes      map[string]interface{}   `json:"Includes"`
 	Results       []map[string]interface{} `json:"Results"`
 	NextPageStart int                      `json:"NextPageStart"`
+	// CounterIncludes maps document id to values of its included counters
+	CounterIncludes map[string][]*CounterDetail `json:"CounterIncludes"`
+	// TimeSeriesIncludes maps document id and time series name to
+	// included ranges
+	TimeSeriesIncludes map[string]map[string][]*TimeSeriesRangeResult `json:"TimeSeriesIncludes"`
 }
@@ -0,0 +1,106 @@
+package ravendb
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+)
+
+var (
+	_ IOperation = &GetMultipleTimeSeriesOperation{}
+)
+
+// GetMultipleTimeSeriesOperation returns entries of many time series
+// (or many ranges of the same time series) of a document
+type GetMultipleTimeSeriesOperation struct {
+	docID    string
+	ranges   []*TimeSeriesRange
+	start    int
+	pageSize int
+
+	Command *GetMultipleTimeSeriesCommand
+}
+
+// NewGetMultipleTimeSeriesOperation returns new GetMultipleTimeSeriesOperation
+func NewGetMultipleTimeSeriesOperation(docID string, ranges []*TimeSeriesRange) (*GetMultipleTimeSeriesOperation, error) {
+	return NewGetMultipleTimeSeriesOperationWithPaging(docID, ranges, 0, math.MaxInt32)
+}
+
+// NewGetMultipleTimeSeriesOperationWithPaging is like NewGetMultipleTimeSeriesOperation
+// but only returns pageSize entries, skipping first start entries
+func NewGetMultipleTimeSeriesOperationWithPaging(docID string, ranges []*TimeSeriesRange, start int, pageSize int) (*GetMultipleTimeSeriesOperation, error) {
+	if stringIsBlank(docID) {
+		return nil, newIllegalArgumentError("DocId cannot be empty")
+	}
+	if len(ranges) == 0 {
+		return nil, newIllegalArgumentError("Ranges cannot be empty")
+	}
+	for _, r := range ranges {
+		if r == nil || stringIsBlank(r.Name) {
+			return nil, newIllegalArgumentError("Missing name argument in TimeSeriesRange. Name cannot be empty")
+		}
+	}
+	if start < 0 {
+		return nil, newIllegalArgumentError("start cannot be negative")
+	}
+	if pageSize < 0 {
+		return nil, newIllegalArgumentError("pageSize cannot be negative")
+	}
+	return &GetMultipleTimeSeriesOperation{
+		docID:    docID,
+		ranges:   ranges,
+		start:    start,
+		pageSize: pageSize,
+	}, nil
+}
+
+func (o *GetMultipleTimeSeriesOperation) GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error) {
+	o.Command = &GetMultipleTimeSeriesCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		docID:    o.docID,
+		ranges:   o.ranges,
+		start:    o.start,
+		pageSize: o.pageSize,
+	}
+	o.Command.IsReadRequest = true
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetMultipleTimeSeriesCommand{}
+
+// GetMultipleTimeSeriesCommand is a command for GetMultipleTimeSeriesOperation
+type GetMultipleTimeSeriesCommand struct {
+	RavenCommandBase
+
+	docID    string
+	ranges   []*TimeSeriesRange
+	start    int
+	pageSize int
+
+	// Result is nil if the document doesn't exist
+	Result *TimeSeriesDetails
+}
+
+func (c *GetMultipleTimeSeriesCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/timeseries/ranges?docId=" + urlUtilsEscapeDataString(c.docID)
+	if c.start > 0 {
+		url += "&start=" + strconv.Itoa(c.start)
+	}
+	if c.pageSize < math.MaxInt32 {
+		url += "&pageSize=" + strconv.Itoa(c.pageSize)
+	}
+	for _, r := range c.ranges {
+		url += "&name=" + urlUtilsEscapeDataString(r.Name)
+		url += "&from=" + urlUtilsEscapeDataString(timePtrToServerString(r.From))
+		url += "&to=" + urlUtilsEscapeDataString(timePtrToServerString(r.To))
+	}
+	return newHttpGet(url)
+}
+
+func (c *GetMultipleTimeSeriesCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return nil
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,101 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &GetOngoingTaskInfoOperation{}
+)
+
+// GetOngoingTaskInfoOperation returns state and configuration of an ongoing task
+type GetOngoingTaskInfoOperation struct {
+	taskID   int64
+	taskName string
+	taskType OngoingTaskType
+
+	Command *GetOngoingTaskInfoCommand
+}
+
+// NewGetOngoingTaskInfoOperation returns GetOngoingTaskInfoOperation for a task
+// with a given id
+func NewGetOngoingTaskInfoOperation(taskID int64, taskType OngoingTaskType) *GetOngoingTaskInfoOperation {
+	return &GetOngoingTaskInfoOperation{
+		taskID:   taskID,
+		taskType: taskType,
+	}
+}
+
+// NewGetOngoingTaskInfoOperationWithName returns GetOngoingTaskInfoOperation
+// for a task with a given name
+func NewGetOngoingTaskInfoOperationWithName(taskName string, taskType OngoingTaskType) *GetOngoingTaskInfoOperation {
+	return &GetOngoingTaskInfoOperation{
+		taskName: taskName,
+		taskType: taskType,
+	}
+}
+
+func (o *GetOngoingTaskInfoOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = &GetOngoingTaskInfoCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		taskID:   o.taskID,
+		taskName: o.taskName,
+		taskType: o.taskType,
+	}
+	o.Command.IsReadRequest = true
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetOngoingTaskInfoCommand{}
+
+// GetOngoingTaskInfoCommand is a command for GetOngoingTaskInfoOperation
+type GetOngoingTaskInfoCommand struct {
+	RavenCommandBase
+
+	taskID   int64
+	taskName string
+	taskType OngoingTaskType
+
+	// Result is *OngoingTaskRavenEtlDetails, *OngoingTaskSqlEtlDetails,
+	// *OngoingTaskReplication, *OngoingTaskBackup or (for other task types)
+	// *OngoingTask. It's nil if the task doesn't exist
+	Result interface{}
+}
+
+func (c *GetOngoingTaskInfoCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/task?"
+	if c.taskName != "" {
+		url += "taskName=" + urlUtilsEscapeDataString(c.taskName)
+	} else {
+		url += "key=" + i64toa(c.taskID)
+	}
+	url += "&type=" + c.taskType
+	return newHttpGet(url)
+}
+
+func (c *GetOngoingTaskInfoCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		c.Result = nil
+		return nil
+	}
+
+	var res interface{}
+	switch c.taskType {
+	case OngoingTaskTypeRavenEtl:
+		res = &OngoingTaskRavenEtlDetails{}
+	case OngoingTaskTypeSQLEtl:
+		res = &OngoingTaskSqlEtlDetails{}
+	case OngoingTaskTypeReplication:
+		res = &OngoingTaskReplication{}
+	case OngoingTaskTypeBackup:
+		res = &OngoingTaskBackup{}
+	default:
+		res = &OngoingTask{}
+	}
+	if err := jsonUnmarshal(response, res); err != nil {
+		return err
+	}
+	c.Result = res
+	return nil
+}
@@ -0,0 +1,62 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &GetPeriodicBackupStatusOperation{}
+)
+
+// GetPeriodicBackupStatusOperation returns the state of a periodic backup task
+type GetPeriodicBackupStatusOperation struct {
+	taskID int64
+
+	Command *GetPeriodicBackupStatusCommand
+}
+
+// GetPeriodicBackupStatusOperationResult is a result of GetPeriodicBackupStatusOperation
+type GetPeriodicBackupStatusOperationResult struct {
+	// Status is nil if the task didn't run yet
+	Status *PeriodicBackupStatus `json:"Status"`
+}
+
+// NewGetPeriodicBackupStatusOperation returns new GetPeriodicBackupStatusOperation
+func NewGetPeriodicBackupStatusOperation(taskID int64) *GetPeriodicBackupStatusOperation {
+	return &GetPeriodicBackupStatusOperation{
+		taskID: taskID,
+	}
+}
+
+func (o *GetPeriodicBackupStatusOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = &GetPeriodicBackupStatusCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		taskID: o.taskID,
+	}
+	o.Command.IsReadRequest = true
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetPeriodicBackupStatusCommand{}
+
+// GetPeriodicBackupStatusCommand is a command for GetPeriodicBackupStatusOperation
+type GetPeriodicBackupStatusCommand struct {
+	RavenCommandBase
+
+	taskID int64
+
+	Result *GetPeriodicBackupStatusOperationResult
+}
+
+func (c *GetPeriodicBackupStatusCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/periodic-backup/status?name=" + urlUtilsEscapeDataString(node.Database) + "&taskId=" + i64toa(c.taskID)
+	return newHttpGet(url)
+}
+
+func (c *GetPeriodicBackupStatusCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
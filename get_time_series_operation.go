@@ -0,0 +1,117 @@
+package ravendb
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	_ IOperation = &GetTimeSeriesOperation{}
+)
+
+// GetTimeSeriesOperation returns entries of a time series within a time range
+type GetTimeSeriesOperation struct {
+	docID    string
+	name     string
+	from     *time.Time
+	to       *time.Time
+	start    int
+	pageSize int
+
+	Command *GetTimeSeriesCommand
+}
+
+// NewGetTimeSeriesOperation returns operation that gets all entries of
+// a time series of a document within [from, to]. from and to can be nil
+func NewGetTimeSeriesOperation(docID string, name string, from *time.Time, to *time.Time) (*GetTimeSeriesOperation, error) {
+	return NewGetTimeSeriesOperationWithPaging(docID, name, from, to, 0, math.MaxInt32)
+}
+
+// NewGetTimeSeriesOperationWithPaging is like NewGetTimeSeriesOperation but
+// only returns pageSize entries, skipping first start entries
+func NewGetTimeSeriesOperationWithPaging(docID string, name string, from *time.Time, to *time.Time, start int, pageSize int) (*GetTimeSeriesOperation, error) {
+	if stringIsBlank(docID) {
+		return nil, newIllegalArgumentError("DocId cannot be empty")
+	}
+	if stringIsBlank(name) {
+		return nil, newIllegalArgumentError("Timeseries cannot be empty")
+	}
+	if start < 0 {
+		return nil, newIllegalArgumentError("start cannot be negative")
+	}
+	if pageSize < 0 {
+		return nil, newIllegalArgumentError("pageSize cannot be negative")
+	}
+	return &GetTimeSeriesOperation{
+		docID:    docID,
+		name:     name,
+		from:     from,
+		to:       to,
+		start:    start,
+		pageSize: pageSize,
+	}, nil
+}
+
+func (o *GetTimeSeriesOperation) GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error) {
+	o.Command = newGetTimeSeriesCommand(o.docID, o.name, o.from, o.to, o.start, o.pageSize)
+	return o.Command, nil
+}
+
+var _ RavenCommand = &GetTimeSeriesCommand{}
+
+// GetTimeSeriesCommand is a command for GetTimeSeriesOperation
+type GetTimeSeriesCommand struct {
+	RavenCommandBase
+
+	docID    string
+	name     string
+	from     *time.Time
+	to       *time.Time
+	start    int
+	pageSize int
+
+	// Result is nil if the document or time series doesn't exist
+	Result *TimeSeriesRangeResult
+}
+
+func newGetTimeSeriesCommand(docID string, name string, from *time.Time, to *time.Time, start int, pageSize int) *GetTimeSeriesCommand {
+	cmd := &GetTimeSeriesCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		docID:    docID,
+		name:     name,
+		from:     from,
+		to:       to,
+		start:    start,
+		pageSize: pageSize,
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *GetTimeSeriesCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/timeseries?docId=" + urlUtilsEscapeDataString(c.docID)
+	if c.start > 0 {
+		url += "&start=" + strconv.Itoa(c.start)
+	}
+	if c.pageSize < math.MaxInt32 {
+		url += "&pageSize=" + strconv.Itoa(c.pageSize)
+	}
+	url += "&name=" + urlUtilsEscapeDataString(c.name)
+	if c.from != nil {
+		url += "&from=" + urlUtilsEscapeDataString(timePtrToServerString(c.from))
+	}
+	if c.to != nil {
+		url += "&to=" + urlUtilsEscapeDataString(timePtrToServerString(c.to))
+	}
+	return newHttpGet(url)
+}
+
+func (c *GetTimeSeriesCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return nil
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -0,0 +1,12 @@
+package ravendb
+
+// HighlightingOptions describes options of query highlighting
+type HighlightingOptions struct {
+	// GroupKey is a name of the field whose values are used as keys of
+	// Highlightings instead of document ids
+	GroupKey string `json:"GroupKey,omitempty"`
+	// PreTags and PostTags surround highlighted terms e.g. "<b>" and "</b>".
+	// Tags are used in turns for different terms
+	PreTags  []string `json:"PreTags,omitempty"`
+	PostTags []string `json:"PostTags,omitempty"`
+}
@@ -0,0 +1,32 @@
+package ravendb
+
+import (
+	"strconv"
+	"strings"
+)
+
+var _ queryToken = &highlightingToken{}
+
+type highlightingToken struct {
+	fieldName            string
+	fragmentLength       int
+	fragmentCount        int
+	optionsParameterName string
+}
+
+func (t *highlightingToken) writeTo(writer *strings.Builder) error {
+	writer.WriteString("highlight(")
+	writeQueryTokenField(writer, t.fieldName)
+	writer.WriteString(",")
+	writer.WriteString(strconv.Itoa(t.fragmentLength))
+	writer.WriteString(",")
+	writer.WriteString(strconv.Itoa(t.fragmentCount))
+
+	if t.optionsParameterName != "" {
+		writer.WriteString(",$")
+		writer.WriteString(t.optionsParameterName)
+	}
+
+	writer.WriteString(")")
+	return nil
+}
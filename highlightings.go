@@ -0,0 +1,64 @@
+package ravendb
+
+import "sort"
+
+// Highlightings describes highlighted fragments of a field in query results
+type Highlightings struct {
+	fieldName     string
+	highlightings map[string][]string
+}
+
+func newHighlightings(fieldName string) *Highlightings {
+	return &Highlightings{
+		fieldName:     fieldName,
+		highlightings: map[string][]string{},
+	}
+}
+
+// GetFieldName returns name of the highlighted field
+func (h *Highlightings) GetFieldName() string {
+	return h.fieldName
+}
+
+// GetResultIndents returns keys (document ids or values of a group key)
+// of results that have highlighted fragments
+func (h *Highlightings) GetResultIndents() []string {
+	res := make([]string, 0, len(h.highlightings))
+	for key := range h.highlightings {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// GetFragments returns highlighted fragments of a result with a given key
+// (document id or value of a group key)
+func (h *Highlightings) GetFragments(key string) []string {
+	return h.highlightings[key]
+}
+
+func (h *Highlightings) update(highlightings map[string]map[string][]string) {
+	h.highlightings = map[string][]string{}
+	fragments, ok := highlightings[h.fieldName]
+	if !ok {
+		return
+	}
+	for key, v := range fragments {
+		h.highlightings[key] = v
+	}
+}
+
+// queryHighlightings tracks highlightings of all highlighted fields of a query
+type queryHighlightings []*Highlightings
+
+func (q *queryHighlightings) add(fieldName string) *Highlightings {
+	res := newHighlightings(fieldName)
+	*q = append(*q, res)
+	return res
+}
+
+func (q queryHighlightings) update(queryResult *QueryResult) {
+	for _, h := range q {
+		h.update(queryResult.Highlightings)
+	}
+}
@@ -0,0 +1,43 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryHighlight(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+
+	var titleHighlightings, bodyHighlightings *Highlightings
+	options := &HighlightingOptions{
+		PreTags:  []string{"<b>"},
+		PostTags: []string{"</b>"},
+	}
+	q := session.QueryIndex("BlogPosts/ByContent").
+		Search("Title", "ravendb").
+		Include("authorId").
+		Highlight("Title", 128, 1, nil, &titleHighlightings).
+		Highlight("Body", 64, 2, options, &bodyHighlightings)
+	iq, err := q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from index 'BlogPosts/ByContent' where search(Title, $p0) include authorId,highlight(Title,128,1),highlight(Body,64,2,$p1)", iq.GetQuery())
+	assert.Equal(t, options, iq.GetQueryParameters()["p1"])
+	assert.Equal(t, "Title", titleHighlightings.GetFieldName())
+
+	result := &QueryResult{}
+	result.Highlightings = map[string]map[string][]string{
+		"Body": {
+			"posts/2": {"about <b>ravendb</b>"},
+			"posts/1": {"<b>ravendb</b> is", "with <b>ravendb</b>"},
+		},
+	}
+	q.invokeAfterQueryExecuted(result)
+	assert.Equal(t, []string{"posts/1", "posts/2"}, bodyHighlightings.GetResultIndents())
+	assert.Equal(t, []string{"<b>ravendb</b> is", "with <b>ravendb</b>"}, bodyHighlightings.GetFragments("posts/1"))
+	assert.Empty(t, titleHighlightings.GetResultIndents())
+	assert.Nil(t, titleHighlightings.GetFragments("posts/1"))
+
+	q = session.QueryCollection("Posts").Highlight("", 128, 1, nil, &titleHighlightings)
+	assert.Error(t, q.Err())
+}
@@ -1,112 +1,304 @@
 package ravendb
 
 import (
-	//"fmt"
-
+	"container/list"
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// equivalent of com.google.common.cache.Cache, specialized for String -> HttpCacheItem mapping
-// TODO: match semantics
+// HTTPCacheEntry is a response cached by RequestExecutor
+type HTTPCacheEntry struct {
+	// ChangeVector is sent in If-None-Match header to re-validate the response
+	ChangeVector string
+	Payload      []byte
+	// LastServerUpdate is when the server sent or last confirmed the response
+	LastServerUpdate time.Time
+	// Generation is an opaque value used by RequestExecutor to tell if
+	// a response might have been modified. Backends must return it as it was
+	// set
+	Generation int64
+}
+
+// HTTPCacheBackendStats describes usage of HTTPCacheBackend
+type HTTPCacheBackendStats struct {
+	// Items is the number of cached responses
+	Items int
+	// Bytes is the approximate size of cached responses
+	Bytes int64
+	// MaxBytes is the limit of Bytes
+	MaxBytes int64
+	// Evictions is the number of responses evicted to make space for others
+	Evictions int64
+}
+
+// HTTPCacheBackend stores responses cached by RequestExecutor, keyed by url.
+// The default backend keeps them in memory of the process
+// (see NewMemoryHTTPCacheBackend). DiskHTTPCacheBackend keeps them on disk
+// so that they can be shared by processes and survive restarts.
+// Implementations must be safe for concurrent use
+type HTTPCacheBackend interface {
+	// Get returns the response cached for url or nil
+	Get(url string) *HTTPCacheEntry
+	// Set caches the response for url, possibly evicting other responses
+	Set(url string, entry *HTTPCacheEntry)
+	// NotModified is called when the server confirmed that the response
+	// cached for url is up to date
+	NotModified(url string, at time.Time)
+	// Clear removes all cached responses
+	Clear()
+	Stats() *HTTPCacheBackendStats
+}
+
+// equivalent of com.google.common.cache.Cache, specialized for String -> HttpCacheItem mapping.
+// It's a LRU cache: when total weight of items exceeds maximumWeight,
+// least recently used items are evicted. Go has no soft references so, unlike
+// in Java, items are not released under memory pressure
 type genericCache struct {
-	softValues    bool
 	maximumWeight int
-	weighter      func(string, *httpCacheItem) int
+	weighter      func(string, *HTTPCacheEntry) int
+
+	mu        sync.Mutex
+	data      map[string]*list.Element
+	lru       *list.List // of *genericCacheEntry, most recently used first
+	weight    int
+	evictions int64
+}
+
+type genericCacheEntry struct {
+	uri    string
+	item   *HTTPCacheEntry
+	weight int
+}
+
+var _ HTTPCacheBackend = &genericCache{}
 
-	data map[string]*httpCacheItem
+// NewMemoryHTTPCacheBackend returns HTTPCacheBackend that keeps up to
+// maxSize bytes of responses in memory, evicting least recently used ones.
+// It's the default backend (see DocumentConventions.SetMaxHttpCacheSize)
+func NewMemoryHTTPCacheBackend(maxSize int) HTTPCacheBackend {
+	return newGenericCache(maxSize, httpCacheEntryWeight)
 }
 
-func (c *genericCache) size() int {
-	return len(c.data)
+func httpCacheEntryWeight(uri string, entry *HTTPCacheEntry) int {
+	return len(entry.Payload) + len(uri) + 20
 }
 
-func (c *genericCache) invalidateAll() {
-	c.data = nil
+func newGenericCache(maximumWeight int, weighter func(string, *HTTPCacheEntry) int) *genericCache {
+	return &genericCache{
+		maximumWeight: maximumWeight,
+		weighter:      weighter,
+		data:          map[string]*list.Element{},
+		lru:           list.New(),
+	}
+}
+
+func (c *genericCache) Stats() *HTTPCacheBackendStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &HTTPCacheBackendStats{
+		Items:     len(c.data),
+		Bytes:     int64(c.weight),
+		MaxBytes:  int64(c.maximumWeight),
+		Evictions: c.evictions,
+	}
+}
+
+func (c *genericCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data = map[string]*list.Element{}
+	c.lru.Init()
+	c.weight = 0
 }
 
-func (c *genericCache) getIfPresent(uri string) *httpCacheItem {
-	return c.data[uri]
+func (c *genericCache) Get(uri string) *HTTPCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el := c.data[uri]
+	if el == nil {
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	// a copy because NotModified() updates it
+	res := *el.Value.(*genericCacheEntry).item
+	return &res
+}
+
+func (c *genericCache) NotModified(uri string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el := c.data[uri]; el != nil {
+		el.Value.(*genericCacheEntry).item.LastServerUpdate = at
+	}
 }
 
-func (c *genericCache) put(uri string, i *httpCacheItem) {
-	//fmt.Printf("genericCache.put(): url: %s, changeVector: %s, len(result): %d\n", uri, *i.changeVector, len(i.payload))
+func (c *genericCache) Set(uri string, i *HTTPCacheEntry) {
+	weight := c.weighter(uri, i)
+	item := *i
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el := c.data[uri]; el != nil {
+		c.removeElement(el)
+	}
+	if weight > c.maximumWeight {
+		// would evict everything else and itself
+		return
+	}
+	c.data[uri] = c.lru.PushFront(&genericCacheEntry{
+		uri:    uri,
+		item:   &item,
+		weight: weight,
+	})
+	c.weight += weight
+
+	for c.weight > c.maximumWeight {
+		c.removeElement(c.lru.Back())
+		c.evictions++
+	}
+}
 
-	// TODO: probably implement cache eviction
-	c.data[uri] = i
+func (c *genericCache) removeElement(el *list.Element) {
+	entry := c.lru.Remove(el).(*genericCacheEntry)
+	delete(c.data, entry.uri)
+	c.weight -= entry.weight
 }
 
+// HTTPCache is a cache of responses used by RequestExecutor.
+// It's exported so that IOperation can be implemented outside of this package
+type HTTPCache = httpCache
+
 type httpCache struct {
-	items      *genericCache
-	generation int32 // atomic
+	items HTTPCacheBackend
+	// true if items were created by (and are private to) the cache
+	ownsItems bool
+	// generation starts at a value unique to this cache so that items set
+	// by other caches sharing a backend are treated as possibly modified
+	generation int64 // atomic
+
+	// atomic
+	hits        int64
+	misses      int64
+	notModified int64
+}
+
+// HTTPCacheStats describes usage of HTTPCache
+type HTTPCacheStats struct {
+	// Items is the number of cached responses
+	Items int
+	// Bytes is the approximate size of cached responses
+	Bytes int64
+	// MaxBytes is the limit of Bytes (see DocumentConventions.SetMaxHttpCacheSize)
+	MaxBytes int64
+	// Hits is the number of times a response was found in the cache
+	Hits int64
+	// Misses is the number of times a cacheable response was not in the cache
+	Misses int64
+	// NotModified is the number of hits that were re-validated by the
+	// server with 304 Not Modified response
+	NotModified int64
+	// Evictions is the number of responses evicted to make space for others
+	Evictions int64
 }
 
 func (c *httpCache) incGeneration() {
-	atomic.AddInt32(&c.generation, 1)
+	atomic.AddInt64(&c.generation, 1)
 }
 
-func (c *httpCache) getGeneration() int {
-	v := atomic.LoadInt32(&c.generation)
-	return int(v)
+func (c *httpCache) getGeneration() int64 {
+	return atomic.LoadInt64(&c.generation)
 }
 
 func newHttpCache(size int) *httpCache {
 	if size == 0 {
 		size = 1 * 1024 * 1024 // TODO: check what is default size of com.google.common.cache.Cache is
 	}
-	cache := &genericCache{
-		softValues:    true,
-		maximumWeight: size,
-		weighter: func(k string, v *httpCacheItem) int {
-			return len(v.payload) + 20
-		},
-		data: map[string]*httpCacheItem{},
-	}
+	res := newHttpCacheWithBackend(NewMemoryHTTPCacheBackend(size))
+	res.ownsItems = true
+	return res
+}
+
+func newHttpCacheWithBackend(backend HTTPCacheBackend) *httpCache {
 	return &httpCache{
-		items: cache,
+		items:      backend,
+		generation: time.Now().UnixNano(),
+	}
+}
+
+func newHttpCacheForConventions(conventions *DocumentConventions) *httpCache {
+	if conventions.HTTPCacheBackend != nil {
+		return newHttpCacheWithBackend(conventions.HTTPCacheBackend)
 	}
+	return newHttpCache(conventions.GetMaxHttpCacheSize())
 }
 
 func (c *httpCache) GetNumberOfItems() int {
-	return c.items.size()
+	return c.items.Stats().Items
+}
+
+// GetStats returns statistics of the cache
+func (c *httpCache) GetStats() *HTTPCacheStats {
+	stats := c.items.Stats()
+	return &HTTPCacheStats{
+		Items:       stats.Items,
+		Bytes:       stats.Bytes,
+		MaxBytes:    stats.MaxBytes,
+		Hits:        atomic.LoadInt64(&c.hits),
+		Misses:      atomic.LoadInt64(&c.misses),
+		NotModified: atomic.LoadInt64(&c.notModified),
+		Evictions:   stats.Evictions,
+	}
 }
 
 func (c *httpCache) close() {
-	c.items.invalidateAll()
-	c.items = nil
+	// a backend set in conventions might be shared with other request
+	// executors or processes
+	if c.ownsItems {
+		c.items.Clear()
+	}
 }
 
 func (c *httpCache) set(url string, changeVector *string, result []byte) {
-	httpCacheItem := newHttpCacheItem()
-	httpCacheItem.changeVector = changeVector
-	httpCacheItem.payload = result
-	httpCacheItem.cache = c
-	httpCacheItem.generation = c.getGeneration()
-	c.items.put(url, httpCacheItem)
+	entry := &HTTPCacheEntry{
+		Payload:          result,
+		LastServerUpdate: time.Now(),
+		Generation:       c.getGeneration(),
+	}
+	if changeVector != nil {
+		entry.ChangeVector = *changeVector
+	}
+	c.items.Set(url, entry)
 }
 
 // returns cacheItem, changeVector and response
 func (c *httpCache) get(url string) (*releaseCacheItem, *string, []byte) {
-	item := c.items.getIfPresent(url)
-	if item != nil {
-		//fmt.Printf("HttpCache.get(): found url: %s, changeVector: %s, len(payload): %d\n", url, *item.changeVector, len(item.payload))
+	entry := c.items.Get(url)
+	if entry != nil {
+		atomic.AddInt64(&c.hits, 1)
+		item := newHttpCacheItem(c, url, entry)
 		return newReleaseCacheItem(item), item.changeVector, item.payload
 	}
 
-	//fmt.Printf("HttpCache.get(): didn't find url: %s\n", url)
+	atomic.AddInt64(&c.misses, 1)
 	return newReleaseCacheItem(nil), nil, nil
 }
 
+// getNotModified returns cached response after the server responded with
+// 304 Not Modified to a request sent with change vector returned by get()
+func (c *httpCache) getNotModified(url string) []byte {
+	entry := c.items.Get(url)
+	if entry == nil {
+		return nil
+	}
+	newReleaseCacheItem(newHttpCacheItem(c, url, entry)).notModified()
+	return entry.Payload
+}
+
 func (c *httpCache) setNotFound(url string) {
-	//fmt.Printf("HttpCache.setNotFound(): url: %s\n", url)
-	httpCacheItem := newHttpCacheItem()
 	s := "404 response"
-	httpCacheItem.changeVector = &s
-	httpCacheItem.cache = c
-	httpCacheItem.generation = c.getGeneration()
-
-	c.items.put(url, httpCacheItem)
+	c.set(url, &s, nil)
 }
 
 type releaseCacheItem struct {
@@ -122,6 +314,8 @@ func newReleaseCacheItem(item *httpCacheItem) *releaseCacheItem {
 func (i *releaseCacheItem) notModified() {
 	if i.item != nil {
 		i.item.lastServerUpdate = time.Now()
+		i.item.cache.items.NotModified(i.item.url, i.item.lastServerUpdate)
+		atomic.AddInt64(&i.item.cache.notModified, 1)
 	}
 }
 
@@ -2,17 +2,25 @@ package ravendb
 
 import "time"
 
+// httpCacheItem is HTTPCacheEntry returned by httpCache.get()
 type httpCacheItem struct {
+	url              string
 	changeVector     *string // TODO: can probably be string
 	payload          []byte
 	lastServerUpdate time.Time
-	generation       int // TODO: should this be atomicInteger?
+	generation       int64
 
 	cache *httpCache
 }
 
-func newHttpCacheItem() *httpCacheItem {
+func newHttpCacheItem(cache *httpCache, url string, entry *HTTPCacheEntry) *httpCacheItem {
+	changeVector := entry.ChangeVector
 	return &httpCacheItem{
-		lastServerUpdate: time.Now(),
+		url:              url,
+		changeVector:     &changeVector,
+		payload:          entry.Payload,
+		lastServerUpdate: entry.LastServerUpdate,
+		generation:       entry.Generation,
+		cache:            cache,
 	}
 }
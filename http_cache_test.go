@@ -0,0 +1,113 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newHttpCache(100)
+	cv := "A:1"
+	payload := []byte(strings.Repeat("x", 20))
+	// each item weighs 20 + 2 + 20 = 42 so only 2 fit
+	cache.set("/1", &cv, payload)
+	cache.set("/2", &cv, payload)
+
+	// "/1" becomes most recently used so "/2" is evicted
+	_, _, res := cache.get("/1")
+	assert.Equal(t, payload, res)
+	cache.set("/3", &cv, payload)
+
+	_, changeVector, _ := cache.get("/2")
+	assert.Nil(t, changeVector)
+	_, changeVector, _ = cache.get("/1")
+	assert.NotNil(t, changeVector)
+	_, changeVector, _ = cache.get("/3")
+	assert.NotNil(t, changeVector)
+
+	stats := cache.GetStats()
+	assert.Equal(t, 2, stats.Items)
+	assert.Equal(t, int64(84), stats.Bytes)
+	assert.Equal(t, int64(100), stats.MaxBytes)
+	assert.Equal(t, int64(3), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(1), stats.Evictions)
+
+	// replacing an item doesn't count its weight twice
+	cache.set("/3", &cv, payload)
+	assert.Equal(t, int64(84), cache.GetStats().Bytes)
+
+	// items bigger than the cache are not cached
+	cache.set("/big", &cv, []byte(strings.Repeat("x", 100)))
+	_, changeVector, _ = cache.get("/big")
+	assert.Nil(t, changeVector)
+	assert.Equal(t, 2, cache.GetNumberOfItems())
+
+	cache.close()
+	stats = cache.GetStats()
+	assert.Equal(t, 0, stats.Items)
+	assert.Equal(t, int64(0), stats.Bytes)
+}
+
+func TestHttpCacheConcurrentAccess(t *testing.T) {
+	cache := newHttpCache(1000)
+	cv := "A:1"
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				url := "/" + strconv.Itoa((i*j)%50)
+				cache.set(url, &cv, []byte(url))
+				item, _, _ := cache.get(url)
+				item.notModified()
+				_ = cache.GetStats()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := cache.GetStats()
+	assert.True(t, stats.Bytes <= stats.MaxBytes)
+	assert.Equal(t, int64(8*200), stats.Hits+stats.Misses)
+}
+
+func TestRequestExecutorHTTPCacheStats(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headersIfNoneMatch) == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(headersEtag, `"1"`)
+		_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	conventions := NewDocumentConventions()
+	conventions.SetMaxHttpCacheSize(1024)
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, conventions)
+	defer re.Close()
+
+	for i := 0; i < 3; i++ {
+		cmd := NewGetStatisticsCommand("")
+		err := re.ExecuteCommand(cmd, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), cmd.Result.CountOfDocuments)
+	}
+
+	stats := re.GetHTTPCacheStats()
+	assert.Equal(t, 1, stats.Items)
+	assert.Equal(t, int64(1024), stats.MaxBytes)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(2), stats.NotModified)
+	assert.Equal(t, int64(0), stats.Evictions)
+}
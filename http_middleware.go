@@ -0,0 +1,31 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+// HTTPMiddleware wraps http.RoundTripper used by RequestExecutor to send
+// requests to the server. It can modify requests (e.g. add authentication
+// headers or sign them), record responses or rate limit.
+// It applies to all http requests: commands, bulk inserts and health checks
+// (but not to websocket connections of DatabaseChanges and subscriptions)
+type HTTPMiddleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to use a function as http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chainHTTPMiddlewares wraps transport in middlewares. The first middleware
+// is the outermost i.e. it sees the request first and the response last
+func chainHTTPMiddlewares(transport http.RoundTripper, middlewares []HTTPMiddleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			transport = middlewares[i](transport)
+		}
+	}
+	return transport
+}
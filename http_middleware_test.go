@@ -0,0 +1,117 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMiddlewares(t *testing.T) {
+	var mu sync.Mutex
+	var headers []http.Header
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	var calls []string
+	middleware := func(name string) HTTPMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				calls = append(calls, name)
+				mu.Unlock()
+				req.Header.Add("X-Middleware", name)
+				rsp, err := next.RoundTrip(req)
+				mu.Lock()
+				calls = append(calls, name+" done")
+				mu.Unlock()
+				return rsp, err
+			})
+		}
+	}
+
+	store := NewDocumentStore([]string{server.URL}, "db")
+	store.AddHTTPMiddleware(middleware("auth"))
+	store.AddHTTPMiddleware(middleware("signing"))
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, store.GetConventions())
+	defer re.Close()
+
+	cmd := NewGetStatisticsCommand("")
+	err := re.ExecuteCommand(cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cmd.Result.CountOfDocuments)
+
+	// health checks go through middlewares too
+	node := re.GetTopologyNodes()[0]
+	err = re.performHealthCheck(node, 0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(headers))
+	for _, h := range headers {
+		assert.Equal(t, []string{"auth", "signing"}, h["X-Middleware"])
+	}
+	exp := []string{"auth", "signing", "signing done", "auth done"}
+	assert.Equal(t, append(exp, exp...), calls)
+}
+
+func TestHTTPMiddlewareShortCircuit(t *testing.T) {
+	conventions := NewDocumentConventions()
+	conventions.HTTPMiddlewares = []HTTPMiddleware{
+		func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				rec := httptest.NewRecorder()
+				_, _ = rec.WriteString(`{"CountOfDocuments":5}`)
+				return rec.Result(), nil
+			})
+		},
+	}
+	// no server is listening, the middleware responds
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates("http://127.0.0.1:1", "db", nil, nil, conventions)
+	defer re.Close()
+
+	cmd := NewGetStatisticsCommand("")
+	err := re.ExecuteCommand(cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), cmd.Result.CountOfDocuments)
+}
+
+func TestHTTPMiddlewaresWithNilTransport(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	prev := HTTPClientPostProcessor
+	HTTPClientPostProcessor = func(client *http.Client) {
+		client.Transport = nil
+	}
+	defer func() {
+		HTTPClientPostProcessor = prev
+	}()
+
+	var called bool
+	conventions := NewDocumentConventions()
+	conventions.HTTPMiddlewares = append(conventions.HTTPMiddlewares, func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			return next.RoundTrip(req)
+		})
+	})
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, conventions)
+	defer re.Close()
+
+	cmd := NewGetStatisticsCommand("")
+	err := re.ExecuteCommand(cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cmd.Result.CountOfDocuments)
+	assert.True(t, called)
+}
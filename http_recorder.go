@@ -0,0 +1,360 @@
+package ravendb
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HTTPExchange is a recorded http request and its response
+type HTTPExchange struct {
+	Method string `json:"Method"`
+	// URL is path and query of the request, without scheme and host so that
+	// recordings can be replayed against a different server
+	URL string `json:"URL"`
+
+	// RequestJSON or RequestData is set if request had a body
+	RequestJSON json.RawMessage `json:"RequestJSON,omitempty"`
+	RequestData []byte          `json:"RequestData,omitempty"`
+
+	StatusCode int         `json:"StatusCode"`
+	Header     http.Header `json:"Header,omitempty"`
+	// ResponseJSON or ResponseData is set if response had a body
+	ResponseJSON json.RawMessage `json:"ResponseJSON,omitempty"`
+	ResponseData []byte          `json:"ResponseData,omitempty"`
+}
+
+func (e *HTTPExchange) setRequestBody(d []byte) {
+	if json.Valid(d) {
+		e.RequestJSON = json.RawMessage(d)
+	} else if len(d) > 0 {
+		e.RequestData = d
+	}
+}
+
+func (e *HTTPExchange) setResponseBody(d []byte) {
+	if json.Valid(d) {
+		e.ResponseJSON = json.RawMessage(d)
+	} else if len(d) > 0 {
+		e.ResponseData = d
+	}
+}
+
+func (e *HTTPExchange) getResponseBody() []byte {
+	if len(e.ResponseJSON) > 0 {
+		return e.ResponseJSON
+	}
+	return e.ResponseData
+}
+
+// LoadHTTPExchanges reads exchanges saved with HTTPRecorder.SaveToFile
+func LoadHTTPExchanges(path string) ([]*HTTPExchange, error) {
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var res []*HTTPExchange
+	if err = jsonUnmarshal(d, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// HTTPRecorder records http requests sent by request executors and their
+// responses e.g. to create test fixtures replayed with HTTPReplayer:
+//
+//	recorder := NewHTTPRecorder()
+//	store.AddHTTPMiddleware(recorder.Middleware)
+//	// ... use the store
+//	err = recorder.SaveToFile("testdata/fixture.json")
+type HTTPRecorder struct {
+	mu        sync.Mutex
+	exchanges []*HTTPExchange
+}
+
+// NewHTTPRecorder returns a new HTTPRecorder
+func NewHTTPRecorder() *HTTPRecorder {
+	return &HTTPRecorder{}
+}
+
+// Middleware is HTTPMiddleware that records requests
+func (r *HTTPRecorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		// the body is recorded as it's sent. Note that the whole body is
+		// kept in memory, including streaming requests (e.g. bulk insert)
+		var requestBody bytes.Buffer
+		if req.Body != nil {
+			req.Body = &teeReadCloser{
+				Reader: io.TeeReader(req.Body, &requestBody),
+				Closer: req.Body,
+			}
+		}
+		rsp, err := next.RoundTrip(req)
+		if err != nil {
+			return rsp, err
+		}
+
+		responseBody, err := ioutil.ReadAll(rsp.Body)
+		_ = rsp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		rsp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+		exchange := &HTTPExchange{
+			Method:     req.Method,
+			URL:        req.URL.RequestURI(),
+			StatusCode: rsp.StatusCode,
+			Header:     rsp.Header.Clone(),
+		}
+		exchange.Header.Del("Content-Length")
+		exchange.Header.Del("Date")
+		exchange.setRequestBody(requestBody.Bytes())
+		exchange.setResponseBody(responseBody)
+
+		r.mu.Lock()
+		r.exchanges = append(r.exchanges, exchange)
+		r.mu.Unlock()
+		return rsp, nil
+	})
+}
+
+// GetExchanges returns recorded exchanges
+func (r *HTTPRecorder) GetExchanges() []*HTTPExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*HTTPExchange(nil), r.exchanges...)
+}
+
+// SaveToFile saves recorded exchanges as json
+func (r *HTTPRecorder) SaveToFile(path string) error {
+	d, err := json.MarshalIndent(r.GetExchanges(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, d, 0644)
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// HTTPReplayer responds to http requests with recorded responses, without
+// contacting the server. Requests are matched by method and URL (ignoring
+// query parameters listed in IgnoredQueryParameters). Requests with the same
+// method and URL get responses in the order they were recorded, the last
+// response is repeated. Topology requests always get a response describing
+// a single node server at the address of the replayer, because recorded
+// topology points at the server the recording was made with. Client
+// configuration requests that were not recorded get a default response.
+//
+// It can be used as HTTPMiddleware (Middleware) or as a fake server
+// (it implements http.Handler and http.RoundTripper)
+type HTTPReplayer struct {
+	// IgnoredQueryParameters are not compared when matching requests
+	// e.g. because they contain current time
+	IgnoredQueryParameters []string
+
+	mu        sync.Mutex
+	exchanges map[string][]*HTTPExchange
+	// requests without recorded response
+	unmatched []string
+}
+
+var (
+	_ http.RoundTripper = &HTTPReplayer{}
+	_ http.Handler      = &HTTPReplayer{}
+)
+
+// NewHTTPReplayer returns HTTPReplayer for a given recording
+func NewHTTPReplayer(exchanges []*HTTPExchange) *HTTPReplayer {
+	res := &HTTPReplayer{
+		IgnoredQueryParameters: []string{"lastRangeAt"},
+		exchanges:              map[string][]*HTTPExchange{},
+	}
+	for _, e := range exchanges {
+		u, err := url.Parse(e.URL)
+		if err != nil {
+			continue
+		}
+		key := res.key(e.Method, u)
+		res.exchanges[key] = append(res.exchanges[key], e)
+	}
+	return res
+}
+
+// NewHTTPReplayerFromFile returns HTTPReplayer for a recording saved with
+// HTTPRecorder.SaveToFile
+func NewHTTPReplayerFromFile(path string) (*HTTPReplayer, error) {
+	exchanges, err := LoadHTTPExchanges(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewHTTPReplayer(exchanges), nil
+}
+
+func (r *HTTPReplayer) key(method string, u *url.URL) string {
+	q := u.Query()
+	for _, name := range r.IgnoredQueryParameters {
+		q.Del(name)
+	}
+	var params []string
+	for name, values := range q {
+		for _, v := range values {
+			params = append(params, name+"="+v)
+		}
+	}
+	sort.Strings(params)
+	return method + " " + u.Path + "?" + strings.Join(params, "&")
+}
+
+// Middleware is HTTPMiddleware that responds with recorded responses
+// instead of calling next
+func (r *HTTPReplayer) Middleware(next http.RoundTripper) http.RoundTripper {
+	return r
+}
+
+// GetUnmatchedRequests returns requests for which there was no recorded
+// response
+func (r *HTTPReplayer) GetUnmatchedRequests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.unmatched...)
+}
+
+func (r *HTTPReplayer) nextExchange(req *http.Request) *HTTPExchange {
+	key := r.key(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	exchanges := r.exchanges[key]
+	if len(exchanges) == 0 {
+		return nil
+	}
+	res := exchanges[0]
+	if len(exchanges) > 1 {
+		r.exchanges[key] = exchanges[1:]
+	}
+	return res
+}
+
+// RoundTrip responds with recorded response
+func (r *HTTPReplayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		// the request is sent as far as the caller is concerned
+		_, _ = io.Copy(ioutil.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+
+	statusCode, header, body := r.respond(req)
+	if header.Get("Content-Type") == "" && len(body) > 0 {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// ServeHTTP responds with recorded response
+func (r *HTTPReplayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Host == "" {
+		// requests received by a server only have a path
+		req.URL.Host = req.Host
+		req.URL.Scheme = "http"
+		if req.TLS != nil {
+			req.URL.Scheme = "https"
+		}
+	}
+	statusCode, header, body := r.respond(req)
+	for name, values := range header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+func (r *HTTPReplayer) respond(req *http.Request) (int, http.Header, []byte) {
+	if !isTopologyRequest(req) {
+		if exchange := r.nextExchange(req); exchange != nil {
+			return exchange.StatusCode, exchange.Header.Clone(), exchange.getResponseBody()
+		}
+	}
+
+	if body := singleNodeServerResponse(req); body != nil {
+		return http.StatusOK, http.Header{}, body
+	}
+
+	r.mu.Lock()
+	r.unmatched = append(r.unmatched, req.Method+" "+req.URL.RequestURI())
+	r.mu.Unlock()
+
+	body, _ := jsonMarshal(map[string]string{
+		"Type":    "Raven.Client.Exceptions.RavenException",
+		"Message": "No recorded response for " + req.Method + " " + req.URL.RequestURI(),
+		"Error":   "No recorded response for " + req.Method + " " + req.URL.RequestURI(),
+	})
+	return http.StatusNotImplemented, http.Header{}, body
+}
+
+func isTopologyRequest(req *http.Request) bool {
+	return req.Method == http.MethodGet && (req.URL.Path == "/topology" || req.URL.Path == "/cluster/topology")
+}
+
+// singleNodeServerResponse returns response for topology and client
+// configuration requests describing a single node server. Returns nil for
+// other requests
+func singleNodeServerResponse(req *http.Request) []byte {
+	if req.Method != http.MethodGet {
+		return nil
+	}
+	serverURL := req.URL.Scheme + "://" + req.URL.Host
+	path := req.URL.Path
+	var v interface{}
+	switch {
+	case path == "/topology":
+		v = &Topology{
+			Etag: 1,
+			Nodes: []*ServerNode{
+				{
+					URL:        serverURL,
+					Database:   req.URL.Query().Get("name"),
+					ClusterTag: "A",
+					ServerRole: ServerNodeRoleMember,
+				},
+			},
+		}
+	case path == "/cluster/topology":
+		v = &ClusterTopologyResponse{
+			Leader:  "A",
+			NodeTag: "A",
+			Topology: &ClusterTopology{
+				LastNodeID: "A",
+				TopologyID: "replay",
+				Members: map[string]string{
+					"A": serverURL,
+				},
+			},
+		}
+	case strings.HasPrefix(path, "/databases/") && strings.HasSuffix(path, "/configuration/client"):
+		v = &GetClientConfigurationCommandResult{}
+	default:
+		return nil
+	}
+	d, _ := jsonMarshal(v)
+	return d
+}
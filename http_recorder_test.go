@@ -0,0 +1,150 @@
+package ravendb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedUser struct {
+	ID   string
+	Name string
+}
+
+// fakeRavenServer responds to requests sent in useReplayStore
+func fakeRavenServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	docJSON := `{"Name":"John","@metadata":{"@id":"users/1","@change-vector":"A:1-x","@collection":"RecordedUsers","@last-modified":"2020-01-02T03:04:05.0000000Z"}}`
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/topology":
+			_, _ = w.Write([]byte(`{"Etag":1,"Nodes":[{"Url":"` + server.URL + `","Database":"db","ClusterTag":"A","ServerRole":"Member"}]}`))
+		case r.URL.Path == "/databases/db/bulk_docs":
+			_, _ = w.Write([]byte(`{"Results":[{"Type":"PUT","@id":"users/1","@collection":"RecordedUsers","@change-vector":"A:1-x","@last-modified":"2020-01-02T03:04:05.0000000Z"}]}`))
+		case r.URL.Path == "/databases/db/docs":
+			_, _ = w.Write([]byte(`{"Results":[` + docJSON + `],"Includes":{}}`))
+		case r.URL.Path == "/databases/db/queries":
+			_, _ = w.Write([]byte(`{"TotalResults":1,"IndexName":"Auto/RecordedUsers/ByName","Results":[` + docJSON + `],"Includes":{}}`))
+		case r.URL.Path == "/databases/db/stats":
+			_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	server = httptest.NewServer(http.HandlerFunc(handler))
+	return server
+}
+
+func useReplayStore(t *testing.T, store *DocumentStore) {
+	err := store.Initialize()
+	assert.NoError(t, err)
+	defer store.Close()
+
+	session, err := store.OpenSession("")
+	assert.NoError(t, err)
+	err = session.StoreWithID(&recordedUser{Name: "John"}, "users/1")
+	assert.NoError(t, err)
+	err = session.SaveChanges()
+	assert.NoError(t, err)
+	session.Close()
+
+	session, err = store.OpenSession("")
+	assert.NoError(t, err)
+	var user *recordedUser
+	err = session.Load(&user, "users/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "John", user.Name)
+
+	var users []*recordedUser
+	q := session.QueryCollectionForType(reflect.TypeOf(&recordedUser{})).WhereEquals("Name", "John")
+	err = q.GetResults(&users)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(users))
+	session.Close()
+
+	op := NewGetStatisticsOperation("")
+	err = store.Maintenance().Send(op)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), op.Command.Result.CountOfDocuments)
+}
+
+func TestHTTPRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	{
+		server := fakeRavenServer(t)
+		recorder := NewHTTPRecorder()
+		store := NewDocumentStore([]string{server.URL}, "db")
+		store.AddHTTPMiddleware(recorder.Middleware)
+		useReplayStore(t, store)
+		server.Close()
+
+		exchanges := recorder.GetExchanges()
+		assert.True(t, len(exchanges) >= 5)
+		err := recorder.SaveToFile(path)
+		assert.NoError(t, err)
+	}
+
+	// replay without a server
+	replayer, err := NewHTTPReplayerFromFile(path)
+	assert.NoError(t, err)
+	store := NewDocumentStore([]string{"http://127.0.0.1:1"}, "db")
+	store.AddHTTPMiddleware(replayer.Middleware)
+	useReplayStore(t, store)
+	assert.Empty(t, replayer.GetUnmatchedRequests())
+}
+
+func TestHTTPReplayerServer(t *testing.T) {
+	exchanges := []*HTTPExchange{
+		{Method: http.MethodGet, URL: "/databases/db/stats", StatusCode: http.StatusOK, ResponseJSON: []byte(`{"CountOfDocuments":2}`)},
+		{Method: http.MethodGet, URL: "/databases/db/stats", StatusCode: http.StatusOK, ResponseJSON: []byte(`{"CountOfDocuments":3}`)},
+	}
+	replayer := NewHTTPReplayer(exchanges)
+	server := httptest.NewServer(replayer)
+	defer server.Close()
+
+	// topology isn't recorded, a single node topology is returned
+	re := RequestExecutorCreate([]string{server.URL}, "db", nil, nil, nil)
+	defer re.Close()
+	for _, exp := range []int64{2, 3, 3} {
+		cmd := NewGetStatisticsCommand("")
+		err := re.ExecuteCommand(cmd, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, cmd.Result.CountOfDocuments)
+	}
+	assert.Equal(t, server.URL, re.GetTopologyNodes()[0].URL)
+
+	cmd, err := NewGetDocumentsCommand([]string{"users/1"}, nil, false)
+	assert.NoError(t, err)
+	err = re.ExecuteCommand(cmd, nil)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "No recorded response"))
+	assert.Equal(t, 1, len(replayer.GetUnmatchedRequests()))
+}
+
+func TestHTTPReplayerServerIgnoresRecordedTopology(t *testing.T) {
+	recordedTopology := `{"Nodes":[{"Url":"http://10.0.0.1:8080","ClusterTag":"A","Database":"db","ServerRole":"Member"}],"Etag":5}`
+	exchanges := []*HTTPExchange{
+		{Method: http.MethodGet, URL: "/topology?name=db", StatusCode: http.StatusOK, ResponseJSON: []byte(recordedTopology)},
+		{Method: http.MethodGet, URL: "/databases/db/stats", StatusCode: http.StatusOK, ResponseJSON: []byte(`{"CountOfDocuments":2}`)},
+	}
+	replayer := NewHTTPReplayer(exchanges)
+	server := httptest.NewServer(replayer)
+	defer server.Close()
+
+	re := RequestExecutorCreate([]string{server.URL}, "db", nil, nil, nil)
+	defer re.Close()
+	cmd := NewGetStatisticsCommand("")
+	err := re.ExecuteCommand(cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cmd.Result.CountOfDocuments)
+	nodes := re.GetTopologyNodes()
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, server.URL, nodes[0].URL)
+	assert.Empty(t, replayer.GetUnmatchedRequests())
+}
@@ -0,0 +1,37 @@
+package ravendb
+
+import (
+	"reflect"
+)
+
+// IDocumentSession is the part of DocumentSession used for loading, storing,
+// deleting and querying documents. Code that depends on IDocumentSession
+// instead of *DocumentSession can be tested with a session opened on a store
+// created with NewInMemoryDocumentStore.
+// Query methods and Advanced return concrete types, so a hand-written fake
+// must return values obtained from a real (e.g. in-memory) session
+type IDocumentSession interface {
+	Load(result interface{}, id string) error
+	LoadMulti(results interface{}, ids []string) error
+	Exists(id string) (bool, error)
+
+	Store(entity interface{}) error
+	StoreWithID(entity interface{}, id string) error
+	StoreWithChangeVectorAndID(entity interface{}, changeVector string, id string) error
+
+	Delete(entity interface{}) error
+	DeleteByID(id string, expectedChangeVector string) error
+
+	SaveChanges() error
+
+	Query(opts *DocumentQueryOptions) *DocumentQuery
+	QueryCollection(collectionName string) *DocumentQuery
+	QueryCollectionForType(typ reflect.Type) *DocumentQuery
+	QueryIndex(indexName string) *DocumentQuery
+	RawQuery(rawQuery string) *RawDocumentQuery
+
+	Advanced() *AdvancedSessionOperations
+	Close()
+}
+
+var _ IDocumentSession = &DocumentSession{}
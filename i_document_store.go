@@ -0,0 +1,22 @@
+package ravendb
+
+// IDocumentStore is the part of DocumentStore used for opening sessions and
+// executing operations. A store created with NewInMemoryDocumentStore
+// implements it without a RavenDB server.
+// OpenSession returns *DocumentSession, so a hand-written fake must return
+// a session opened on a real (e.g. in-memory) store
+type IDocumentStore interface {
+	Initialize() error
+	Close()
+
+	GetDatabase() string
+	GetConventions() *DocumentConventions
+
+	OpenSession(database string) (*DocumentSession, error)
+	OpenSessionWithOptions(options *SessionOptions) (*DocumentSession, error)
+
+	Maintenance() *MaintenanceOperationExecutor
+	Operations() *OperationExecutor
+}
+
+var _ IDocumentStore = &DocumentStore{}
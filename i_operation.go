@@ -1,5 +1,8 @@
 package ravendb
 
+// IOperation is an operation on documents in a database, executed with
+// OperationExecutor. It can be implemented outside of this package by
+// returning CustomCommand from GetCommand
 type IOperation interface {
 	GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error)
 }
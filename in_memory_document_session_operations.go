@@ -1,8 +1,10 @@
 package ravendb
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync/atomic"
 	"time"
 )
@@ -35,6 +37,12 @@ type InMemoryDocumentSessionOperations struct {
 	saveChangesOptions          *BatchOptions
 	isDisposed                  bool
 
+	// bounds all requests sent by the session, set from SessionOptions.Context
+	ctx context.Context
+
+	transactionMode    TransactionMode
+	clusterTransaction *ClusterTransactionOperations
+
 	// Note: skipping unused isDisposed
 	id string
 
@@ -77,6 +85,13 @@ type InMemoryDocumentSessionOperations struct {
 	// Note: using value type so that lookups are based on value
 	deferredCommandsMap map[idTypeAndName]ICommandData
 
+	// values of counters known to the session, keyed by lower-cased document id
+	countersByDocID map[string]*countersCacheEntry
+
+	// ranges of time series known to the session, keyed by lower-cased
+	// document id and lower-cased time series name
+	timeSeriesByDocID map[string]map[string][]*TimeSeriesRangeResult
+
 	generateEntityIDOnTheClient *generateEntityIDOnTheClient
 	entityToJSON                *entityToJSON
 
@@ -103,6 +118,10 @@ func newInMemoryDocumentSessionOperations(dbName string, store *DocumentStore, r
 		maxNumberOfRequestsPerSession: re.conventions.MaxNumberOfRequestsPerSession,
 		useOptimisticConcurrency:      re.conventions.UseOptimisticConcurrency,
 		deferredCommandsMap:           map[idTypeAndName]ICommandData{},
+		countersByDocID:               map[string]*countersCacheEntry{},
+		timeSeriesByDocID:             map[string]map[string][]*TimeSeriesRangeResult{},
+		ctx:                           context.Background(),
+		transactionMode:               TransactionModeSingleNode,
 	}
 
 	genIDFunc := func(entity interface{}) (string, error) {
@@ -133,6 +152,11 @@ func (s *InMemoryDocumentSessionOperations) GetCurrentSessionNode() (*ServerNode
 	return result.currentNode, nil
 }
 
+// Context returns the context that bounds requests sent by the session
+func (s *InMemoryDocumentSessionOperations) Context() context.Context {
+	return s.ctx
+}
+
 // GetDeferredCommandsCount returns number of deferred commands
 func (s *InMemoryDocumentSessionOperations) GetDeferredCommandsCount() int {
 	return len(s.deferredCommands)
@@ -513,6 +537,8 @@ func (s *InMemoryDocumentSessionOperations) Delete(entity interface{}) error {
 
 	s.deletedEntities.add(entity)
 	delete(s.includedDocumentsByID, value.id)
+	s.removeCountersCacheEntry(value.id)
+	delete(s.timeSeriesByDocID, strings.ToLower(value.id))
 	s.knownMissingIds = append(s.knownMissingIds, value.id)
 	return nil
 }
@@ -543,6 +569,8 @@ func (s *InMemoryDocumentSessionOperations) DeleteByID(id string, expectedChange
 	}
 
 	s.knownMissingIds = append(s.knownMissingIds, id)
+	s.removeCountersCacheEntry(id)
+	delete(s.timeSeriesByDocID, strings.ToLower(id))
 	if !s.useOptimisticConcurrency {
 		changeVector = ""
 	}
@@ -781,9 +809,69 @@ func (s *InMemoryDocumentSessionOperations) prepareForSaveChanges() (*saveChange
 		s.deferredCommands = nil
 		s.deferredCommandsMap = nil
 	}
+
+	if err = s.validateClusterTransaction(result); err != nil {
+		return nil, err
+	}
+	err = s.prepareCompareExchangeEntities(result)
+	if err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+// GetTransactionMode returns transaction mode of the session
+func (s *InMemoryDocumentSessionOperations) GetTransactionMode() TransactionMode {
+	return s.transactionMode
+}
+
+func (s *InMemoryDocumentSessionOperations) prepareCompareExchangeEntities(result *saveChangesData) error {
+	ops := s.clusterTransaction
+	if ops == nil || ops.getNumberOfTrackedCompareExchangeValues() == 0 {
+		return nil
+	}
+
+	if s.transactionMode != TransactionModeClusterWide {
+		return newIllegalStateError("Performing cluster transaction operation require the TransactionMode to be set to %s", TransactionModeClusterWide)
+	}
+
+	for _, key := range ops.storeCompareExchangeKeys {
+		item := ops.storeCompareExchange[key]
+		result.addSessionCommandData(newPutCompareExchangeCommandData(item.Key, item.Value, item.Index))
+	}
+	for _, key := range ops.deleteCompareExchangeKeys {
+		item := ops.deleteCompareExchange[key]
+		result.addSessionCommandData(newDeleteCompareExchangeCommandData(item.Key, item.Index))
+	}
+	ops.clear()
+	return nil
+}
+
+func (s *InMemoryDocumentSessionOperations) validateClusterTransaction(result *saveChangesData) error {
+	if s.transactionMode != TransactionModeClusterWide {
+		return nil
+	}
+
+	if s.useOptimisticConcurrency {
+		return newIllegalStateError("useOptimisticConcurrency is not supported with TransactionMode set to %s", TransactionModeClusterWide)
+	}
+
+	commands := append(append([]ICommandData(nil), result.sessionCommands...), result.deferredCommands...)
+	for _, commandData := range commands {
+		switch commandData.getType() {
+		case CommandPut, CommandDelete:
+			if commandData.getChangeVector() != nil {
+				return newIllegalStateError("Optimistic concurrency for %s is not supported when using a cluster transaction", commandData.getId())
+			}
+		case CommandCompareExchangePut, CommandCompareExchangeDelete:
+			// no-op
+		default:
+			return newUnsupportedOperationError("The command '%s' is not supported in a cluster session", commandData.getType())
+		}
+	}
+	return nil
+}
+
 func (s *InMemoryDocumentSessionOperations) UpdateMetadataModifications(documentInfo *documentInfo) bool {
 	dirty := false
 	metadataInstance := documentInfo.metadataInstance
@@ -1081,9 +1169,11 @@ func (s *InMemoryDocumentSessionOperations) deferInternal(command ICommandData)
 	idType = newIDTypeAndName(command.getId(), CommandClientAnyCommand, "")
 	s.deferredCommandsMap[idType] = command
 
-	cmdType := command.getType()
-	isAttachmentCmd := (cmdType == CommandAttachmentPut) || (cmdType == CommandAttachmentDelete)
-	if !isAttachmentCmd {
+	// attachments, counters and time series are not part of the document so
+	// the document can be modified in the same SaveChanges()
+	switch command.getType() {
+	case CommandAttachmentPut, CommandAttachmentDelete, CommandCounters, CommandTimeSeries:
+	default:
 		idType = newIDTypeAndName(command.getId(), CommandClientNotAttachment, "")
 		s.deferredCommandsMap[idType] = command
 	}
@@ -1138,17 +1228,161 @@ func (s *InMemoryDocumentSessionOperations) registerMissingIncludes(results []ma
 	if len(includePaths) == 0 {
 		return
 	}
-	// TODO: ?? This is a no-op in Java
-	/*
-		for _, result := range results {
-			for _, include := range includePaths {
-				if include == IndexingFieldNameDocumentID {
-					continue
+	// ids are case-insensitive and references might differ in case from
+	// the ids of included documents
+	included := map[string]bool{}
+	for id, document := range includes {
+		if document != nil {
+			included[strings.ToLower(id)] = true
+		}
+	}
+	for _, result := range results {
+		for _, include := range includePaths {
+			if include == IndexingFieldNameDocumentID {
+				continue
+			}
+			includesUtilInclude(result, include, func(id string) {
+				if s.IsLoaded(id) || included[strings.ToLower(id)] {
+					return
 				}
-				// TODO: IncludesUtil.include() but it's a no-op in Java code
+				s.registerMissing(id)
+			})
+		}
+	}
+}
+
+func (s *InMemoryDocumentSessionOperations) getCountersCacheEntry(docID string) *countersCacheEntry {
+	return s.countersByDocID[strings.ToLower(docID)]
+}
+
+func (s *InMemoryDocumentSessionOperations) getOrCreateCountersCacheEntry(docID string) *countersCacheEntry {
+	key := strings.ToLower(docID)
+	cache := s.countersByDocID[key]
+	if cache == nil {
+		cache = newCountersCacheEntry()
+		s.countersByDocID[key] = cache
+	}
+	return cache
+}
+
+func (s *InMemoryDocumentSessionOperations) removeCountersCacheEntry(docID string) {
+	delete(s.countersByDocID, strings.ToLower(docID))
+}
+
+// getTimeSeriesFromCache returns entries within [from, to] if they are
+// covered by a cached range
+func (s *InMemoryDocumentSessionOperations) getTimeSeriesFromCache(docID string, name string, from *time.Time, to *time.Time) ([]*TimeSeriesEntry, bool) {
+	ranges := s.timeSeriesByDocID[strings.ToLower(docID)][strings.ToLower(name)]
+	for _, r := range ranges {
+		if r.covers(from, to) {
+			return r.entriesBetween(from, to), true
+		}
+	}
+	return nil, false
+}
+
+// addTimeSeriesToCache caches a range of a time series. Cached ranges
+// covered by the new range are dropped
+func (s *InMemoryDocumentSessionOperations) addTimeSeriesToCache(docID string, name string, result *TimeSeriesRangeResult) {
+	docKey := strings.ToLower(docID)
+	byName := s.timeSeriesByDocID[docKey]
+	if byName == nil {
+		byName = map[string][]*TimeSeriesRangeResult{}
+		s.timeSeriesByDocID[docKey] = byName
+	}
+	nameKey := strings.ToLower(name)
+	var ranges []*TimeSeriesRangeResult
+	for _, r := range byName[nameKey] {
+		if !result.covers(r.From.toTimePtr(), r.To.toTimePtr()) {
+			ranges = append(ranges, r)
+		}
+	}
+	byName[nameKey] = append(ranges, result)
+}
+
+func (s *InMemoryDocumentSessionOperations) removeTimeSeriesCacheEntry(docID string, name string) {
+	if byName := s.timeSeriesByDocID[strings.ToLower(docID)]; byName != nil {
+		delete(byName, strings.ToLower(name))
+	}
+}
+
+// registerTimeSeries caches time series ranges included in load or query results
+func (s *InMemoryDocumentSessionOperations) registerTimeSeries(timeSeriesIncludes map[string]map[string][]*TimeSeriesRangeResult) {
+	for docID, byName := range timeSeriesIncludes {
+		for name, ranges := range byName {
+			for _, r := range ranges {
+				if r != nil {
+					s.addTimeSeriesToCache(docID, name, r)
+				}
+			}
+		}
+	}
+}
+
+// registerCounters caches values of counters included in load results.
+// countersToInclude are names of requested counters, those not present
+// in the result are remembered as missing
+func (s *InMemoryDocumentSessionOperations) registerCounters(resultCounters map[string][]*CounterDetail, ids []string, countersToInclude []string, gotAll bool) {
+	if len(resultCounters) == 0 {
+		if gotAll {
+			for _, id := range ids {
+				s.getOrCreateCountersCacheEntry(id).gotAll = true
+			}
+			return
+		}
+	} else {
+		s.registerCountersInternal(resultCounters, gotAll)
+	}
+
+	if len(countersToInclude) == 0 {
+		return
+	}
+	for _, id := range ids {
+		cache := s.getOrCreateCountersCacheEntry(id)
+		for _, counter := range countersToInclude {
+			if _, ok := cache.get(counter); !ok {
+				cache.set(counter, nil)
+			}
+		}
+	}
+}
+
+// registerQueryCounters caches values of counters included in query results.
+// includedCounterNames maps document id to names of requested counters
+func (s *InMemoryDocumentSessionOperations) registerQueryCounters(resultCounters map[string][]*CounterDetail, includedCounterNames map[string][]string, gotAll bool) {
+	if len(resultCounters) > 0 {
+		s.registerCountersInternal(resultCounters, gotAll)
+	}
+
+	for id, counters := range includedCounterNames {
+		cache := s.getOrCreateCountersCacheEntry(id)
+		if gotAll {
+			cache.gotAll = true
+		}
+		for _, counter := range counters {
+			if _, ok := cache.get(counter); !ok {
+				cache.set(counter, nil)
+			}
+		}
+	}
+}
+
+func (s *InMemoryDocumentSessionOperations) registerCountersInternal(resultCounters map[string][]*CounterDetail, gotAll bool) {
+	for id, counters := range resultCounters {
+		cache := s.getOrCreateCountersCacheEntry(id)
+		if gotAll {
+			// we got all counters of this document so drop stale values
+			cache.values = map[string]*int64{}
+			cache.gotAll = true
+		}
+		for _, counter := range counters {
+			if counter == nil {
+				continue
 			}
+			value := counter.TotalValue
+			cache.set(counter.CounterName, &value)
 		}
-	*/
+	}
 }
 
 func (s *InMemoryDocumentSessionOperations) deserializeFromTransformer(result interface{}, id string, document map[string]interface{}) error {
@@ -1176,32 +1410,46 @@ func (s *InMemoryDocumentSessionOperations) checkIfIdAlreadyIncluded(ids []strin
 			}
 		}
 
-		if documentInfo.entity == nil {
+		if documentInfo.entity == nil && documentInfo.document == nil {
 			return false
 		}
 
-		if len(includes) == 0 {
-			continue
-		}
-
-		/* TODO: this is no-op in java
+		// all documents referenced by include paths must be in the session
+		// as well
 		for _, include := range includes {
 			hasAll := true
-
-			includesUtilInclude(documentInfo.getDocument(), include, s -> {
-				hasAll[0] &= isLoaded(s);
+			includesUtilInclude(documentInfo.document, include, func(id string) {
+				hasAll = hasAll && s.IsLoaded(id)
 			})
-
 			if !hasAll {
 				return false
 			}
 		}
-		*/
 	}
 
 	return true
 }
 
+// idsToCheckOnServerWithIncludes returns ids of documents to load from the
+// server together with includes. Documents already in the session are
+// loaded again because documents they reference might not be
+func (s *InMemoryDocumentSessionOperations) idsToCheckOnServerWithIncludes(ids []string) []string {
+	var res []string
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		if id == "" || s.IsDeleted(id) {
+			continue
+		}
+		idl := strings.ToLower(id)
+		if _, ok := seen[idl]; ok {
+			continue
+		}
+		seen[idl] = struct{}{}
+		res = append(res, id)
+	}
+	return res
+}
+
 func (s *InMemoryDocumentSessionOperations) refreshInternal(entity interface{}, cmd *GetDocumentsCommand, documentInfo *documentInfo) error {
 	document := cmd.Result.Results[0]
 	if document == nil {
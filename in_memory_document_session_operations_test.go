@@ -0,0 +1,65 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveChangesCommandTypes(data *saveChangesData) []string {
+	var res []string
+	for _, cmd := range data.sessionCommands {
+		res = append(res, cmd.getType())
+	}
+	for _, cmd := range data.deferredCommands {
+		res = append(res, cmd.getType())
+	}
+	return res
+}
+
+func TestPrepareForSaveChangesStoreWithCounters(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	user := &inMemoryUser{Name: "Aviv"}
+	err := session.StoreWithID(user, "users/1")
+	require.NoError(t, err)
+
+	counters, err := session.CountersFor(user)
+	require.NoError(t, err)
+	err = counters.Increment("likes", 10)
+	require.NoError(t, err)
+	err = counters.Increment("dislikes", 1)
+	require.NoError(t, err)
+
+	data, err := session.prepareForSaveChanges()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{CommandPut, CommandCounters}, saveChangesCommandTypes(data))
+}
+
+func TestPrepareForSaveChangesStoreWithTimeSeries(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	user := &inMemoryUser{Name: "Oren"}
+	err := session.StoreWithID(user, "users/1")
+	require.NoError(t, err)
+
+	ts, err := session.TimeSeriesFor(user, "HeartRate")
+	require.NoError(t, err)
+	err = ts.AppendWithTag(time.Now(), "watches/fitbit", 60)
+	require.NoError(t, err)
+
+	data, err := session.prepareForSaveChanges()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{CommandPut, CommandTimeSeries}, saveChangesCommandTypes(data))
+}
+
+func TestPrepareForSaveChangesModifiedDocumentWithDeferredCommand(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	user := &inMemoryUser{Name: "Aviv"}
+	err := session.StoreWithID(user, "users/1")
+	require.NoError(t, err)
+
+	session.Defer(NewDeleteCommandData("users/1", ""))
+	_, err = session.prepareForSaveChanges()
+	assert.Error(t, err)
+}
@@ -0,0 +1,551 @@
+package ravendb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	inMemoryServerURL = "http://in-memory.ravendb"
+	// database id part of change vectors issued by InMemoryServer
+	inMemoryDatabaseID = "aW5NZW1vcnlEYXRhYmFzZQ"
+)
+
+// NewInMemoryDocumentStore returns a DocumentStore that keeps documents in
+// memory of the process (in InMemoryServer) instead of talking to a RavenDB
+// server. It's meant for unit testing code that uses a DocumentStore.
+//
+// Sessions work as usual for loading (including LoadStartingWith and
+// includes), storing and deleting documents. Change vectors, optimistic
+// concurrency and metadata are honored. Queries on collections support
+// WhereEquals, WhereNotEquals, WhereIn, WhereStartsWith, WhereEndsWith,
+// WhereBetween, WhereGreaterThan/WhereLessThan (and their OrEqual variants)
+// combined with AndAlso/OrElse/Not, OrderBy/OrderByDescending, Skip and Take.
+// Anything else (indexes, patches, attachments, changes, operations etc.)
+// fails with an error.
+//
+// Note that InMemoryServer is added as HTTPMiddleware so middlewares added to
+// the store later are not called.
+func NewInMemoryDocumentStore(database string) *DocumentStore {
+	return NewInMemoryServer().NewDocumentStore(database)
+}
+
+// InMemoryServer is a fake RavenDB server that keeps documents in memory.
+// It implements a subset of the server's HTTP API described in
+// NewInMemoryDocumentStore. It can be shared by multiple stores.
+type InMemoryServer struct {
+	mu sync.Mutex
+	// keyed by lower-cased database name
+	databases map[string]*inMemoryDatabase
+}
+
+var _ http.RoundTripper = &InMemoryServer{}
+
+// NewInMemoryServer returns a new InMemoryServer without any documents
+func NewInMemoryServer() *InMemoryServer {
+	return &InMemoryServer{
+		databases: map[string]*inMemoryDatabase{},
+	}
+}
+
+// NewDocumentStore returns a DocumentStore for a given database on this
+// server. Databases are created when first used
+func (s *InMemoryServer) NewDocumentStore(database string) *DocumentStore {
+	store := NewDocumentStore([]string{inMemoryServerURL}, database)
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	store.AddHTTPMiddleware(s.Middleware)
+	return store
+}
+
+// Middleware is HTTPMiddleware that handles requests instead of calling next
+func (s *InMemoryServer) Middleware(next http.RoundTripper) http.RoundTripper {
+	return s
+}
+
+type inMemoryDatabase struct {
+	// lastEtag is incremented on every write
+	lastEtag int64
+	// keyed by lower-cased id because ids are case insensitive
+	documents map[string]*inMemoryDocument
+	// last HiLo values and identities keyed by lower-cased tag or prefix
+	hiLo       map[string]int64
+	identities map[string]int64
+}
+
+type inMemoryDocument struct {
+	id           string
+	etag         int64
+	changeVector string
+	lastModified time.Time
+	// data and metadata are not modified after the document is stored so
+	// they can be serialized outside of a lock
+	data     map[string]interface{}
+	metadata map[string]interface{}
+}
+
+func (d *inMemoryDocument) getCollection() string {
+	if collection, ok := jsonGetAsText(d.metadata, MetadataCollection); ok {
+		return collection
+	}
+	return "@empty"
+}
+
+func (d *inMemoryDocument) toJSON(metadataOnly bool) map[string]interface{} {
+	metadata := make(map[string]interface{}, len(d.metadata)+3)
+	for k, v := range d.metadata {
+		metadata[k] = v
+	}
+	metadata[MetadataID] = d.id
+	metadata[MetadataChangeVector] = d.changeVector
+	metadata[MetadataLastModified] = Time(d.lastModified).Format()
+
+	res := map[string]interface{}{}
+	if !metadataOnly {
+		for k, v := range d.data {
+			res[k] = v
+		}
+	}
+	res[MetadataKey] = metadata
+	return res
+}
+
+func (s *InMemoryServer) getDatabase(name string) *inMemoryDatabase {
+	name = strings.ToLower(name)
+	db := s.databases[name]
+	if db == nil {
+		db = &inMemoryDatabase{
+			documents:  map[string]*inMemoryDocument{},
+			hiLo:       map[string]int64{},
+			identities: map[string]int64{},
+		}
+		s.databases[name] = db
+	}
+	return db
+}
+
+type inMemoryResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func newInMemoryResponse(statusCode int, v interface{}) *inMemoryResponse {
+	res := &inMemoryResponse{
+		statusCode: statusCode,
+		header:     http.Header{},
+	}
+	if v != nil {
+		res.body, _ = jsonMarshal(v)
+		res.header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	return res
+}
+
+func newInMemoryErrorResponse(statusCode int, typ string, format string, args ...interface{}) *inMemoryResponse {
+	msg := fmt.Sprintf(format, args...)
+	return newInMemoryResponse(statusCode, map[string]string{
+		"Type":    typ,
+		"Message": msg,
+		"Error":   msg,
+	})
+}
+
+func newInMemoryNotSupportedResponse(format string, args ...interface{}) *inMemoryResponse {
+	return newInMemoryErrorResponse(http.StatusNotImplemented, "System.NotSupportedException", "In-memory server doesn't support "+format, args...)
+}
+
+// RoundTrip handles the request
+func (s *InMemoryServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rsp := s.handle(req, body)
+	return &http.Response{
+		Status:        http.StatusText(rsp.statusCode),
+		StatusCode:    rsp.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rsp.header,
+		Body:          ioutil.NopCloser(bytes.NewReader(rsp.body)),
+		ContentLength: int64(len(rsp.body)),
+		Request:       req,
+	}, nil
+}
+
+func (s *InMemoryServer) handle(req *http.Request, body []byte) *inMemoryResponse {
+	if d := singleNodeServerResponse(req); d != nil {
+		return &inMemoryResponse{
+			statusCode: http.StatusOK,
+			header:     http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			body:       d,
+		}
+	}
+
+	path := req.URL.Path
+	if !strings.HasPrefix(path, "/databases/") {
+		return newInMemoryNotSupportedResponse("%s %s", req.Method, path)
+	}
+	path = strings.TrimPrefix(path, "/databases/")
+	idx := strings.Index(path, "/")
+	if idx < 0 {
+		return newInMemoryNotSupportedResponse("%s %s", req.Method, req.URL.Path)
+	}
+	dbName, endpoint := path[:idx], path[idx:]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db := s.getDatabase(dbName)
+
+	switch endpoint {
+	case "/docs":
+		switch req.Method {
+		case http.MethodGet, http.MethodPost:
+			return db.getDocuments(req, body)
+		case http.MethodHead:
+			return db.headDocument(req)
+		}
+	case "/bulk_docs":
+		if req.Method == http.MethodPost {
+			return db.batch(req, body)
+		}
+	case "/queries":
+		if req.Method == http.MethodPost {
+			return db.query(body)
+		}
+	case "/hilo/next":
+		if req.Method == http.MethodGet {
+			return db.nextHiLo(req)
+		}
+	case "/hilo/return":
+		if req.Method == http.MethodPut {
+			return newInMemoryResponse(http.StatusNoContent, nil)
+		}
+	case "/stats":
+		if req.Method == http.MethodGet {
+			return newInMemoryResponse(http.StatusOK, &DatabaseStatistics{
+				LastDocEtag:      db.lastEtag,
+				CountOfDocuments: int64(len(db.documents)),
+				Indexes:          []*IndexInformation{},
+			})
+		}
+	}
+	return newInMemoryNotSupportedResponse("%s %s", req.Method, req.URL.Path)
+}
+
+func (db *inMemoryDatabase) getDocuments(req *http.Request, body []byte) *inMemoryResponse {
+	q := req.URL.Query()
+	metadataOnly := q.Get("metadataOnly") == "true"
+
+	if startsWith, ok := q["startsWith"]; ok {
+		if q.Get("matches") != "" || q.Get("exclude") != "" {
+			return newInMemoryNotSupportedResponse("matches and exclude in LoadStartingWith")
+		}
+		return db.getDocumentsStartingWith(startsWith[0], q.Get("startAfter"), q.Get("start"), q.Get("pageSize"), metadataOnly)
+	}
+
+	ids := q["id"]
+	if req.Method == http.MethodPost {
+		var v struct {
+			Ids []string `json:"Ids"`
+		}
+		if err := jsonUnmarshal(body, &v); err != nil {
+			return newInMemoryErrorResponse(http.StatusBadRequest, "System.ArgumentException", "Invalid request: %s", err)
+		}
+		ids = v.Ids
+	}
+
+	results := make([]interface{}, len(ids))
+	var found []*inMemoryDocument
+	for i, id := range ids {
+		doc := db.documents[strings.ToLower(id)]
+		if doc == nil {
+			continue
+		}
+		results[i] = doc.toJSON(metadataOnly)
+		found = append(found, doc)
+	}
+	if len(ids) == 1 && len(found) == 0 {
+		return newInMemoryResponse(http.StatusNotFound, nil)
+	}
+
+	return newInMemoryResponse(http.StatusOK, map[string]interface{}{
+		"Results":  results,
+		"Includes": db.resolveIncludes(found, q["include"]),
+	})
+}
+
+func (db *inMemoryDatabase) getDocumentsStartingWith(prefix string, startAfter string, startStr string, pageSizeStr string, metadataOnly bool) *inMemoryResponse {
+	start, _ := strconv.Atoi(startStr)
+	pageSize := math.MaxInt32
+	if n, err := strconv.Atoi(pageSizeStr); err == nil {
+		pageSize = n
+	}
+
+	prefix = strings.ToLower(prefix)
+	startAfter = strings.ToLower(startAfter)
+	var keys []string
+	for key := range db.documents {
+		if strings.HasPrefix(key, prefix) && (startAfter == "" || key > startAfter) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	results := []interface{}{}
+	for i, key := range keys {
+		if i < start {
+			continue
+		}
+		if len(results) >= pageSize {
+			break
+		}
+		results = append(results, db.documents[key].toJSON(metadataOnly))
+	}
+	return newInMemoryResponse(http.StatusOK, map[string]interface{}{
+		"Results":  results,
+		"Includes": map[string]interface{}{},
+	})
+}
+
+// resolveIncludes returns documents referenced by given paths in documents
+func (db *inMemoryDatabase) resolveIncludes(docs []*inMemoryDocument, paths []string) map[string]interface{} {
+	res := map[string]interface{}{}
+	for _, path := range paths {
+		for _, doc := range docs {
+			includesUtilInclude(doc.data, path, func(id string) {
+				if included := db.documents[strings.ToLower(id)]; included != nil {
+					res[included.id] = included.toJSON(false)
+				}
+			})
+		}
+	}
+	return res
+}
+
+func (db *inMemoryDatabase) headDocument(req *http.Request) *inMemoryResponse {
+	doc := db.documents[strings.ToLower(req.URL.Query().Get("id"))]
+	if doc == nil {
+		return newInMemoryResponse(http.StatusNotFound, nil)
+	}
+	etag := `"` + doc.changeVector + `"`
+	if req.Header.Get(headersIfNoneMatch) == etag {
+		return newInMemoryResponse(http.StatusNotModified, nil)
+	}
+	res := newInMemoryResponse(http.StatusOK, nil)
+	res.header.Set(headersEtag, etag)
+	return res
+}
+
+func (db *inMemoryDatabase) nextHiLo(req *http.Request) *inMemoryResponse {
+	q := req.URL.Query()
+	tag := q.Get("tag")
+	lastBatchSize, _ := strconv.ParseInt(q.Get("lastBatchSize"), 10, 64)
+	if lastBatchSize <= 0 {
+		lastBatchSize = 32
+	}
+	key := strings.ToLower(tag)
+	low := db.hiLo[key] + 1
+	high := db.hiLo[key] + lastBatchSize
+	db.hiLo[key] = high
+
+	now := Time(time.Now().UTC())
+	return newInMemoryResponse(http.StatusOK, &HiLoResult{
+		Prefix:      tag + q.Get("identityPartsSeparator"),
+		Low:         low,
+		High:        high,
+		LastSize:    lastBatchSize,
+		ServerTag:   "A",
+		LastRangeAt: &now,
+	})
+}
+
+// batch applies PUT and DELETE commands. Either all commands are applied or,
+// if any of them fails, none
+func (db *inMemoryDatabase) batch(req *http.Request, body []byte) *inMemoryResponse {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		return newInMemoryNotSupportedResponse("attachments")
+	}
+	var v struct {
+		Commands        []map[string]interface{} `json:"Commands"`
+		TransactionMode string                   `json:"TransactionMode"`
+	}
+	if err := jsonUnmarshal(body, &v); err != nil {
+		return newInMemoryErrorResponse(http.StatusBadRequest, "System.ArgumentException", "Invalid request: %s", err)
+	}
+	if v.TransactionMode != "" && !strings.EqualFold(v.TransactionMode, string(TransactionModeSingleNode)) {
+		return newInMemoryNotSupportedResponse("transaction mode %s", v.TransactionMode)
+	}
+
+	// changes are collected in tx so that they can be discarded if a
+	// command fails
+	tx := &inMemoryTransaction{
+		db:       db,
+		lastEtag: db.lastEtag,
+		changed:  map[string]*inMemoryDocument{},
+		now:      time.Now().UTC(),
+	}
+	results := make([]interface{}, 0, len(v.Commands))
+	for _, cmd := range v.Commands {
+		typ, _ := jsonGetAsText(cmd, "Type")
+		var result map[string]interface{}
+		var errRsp *inMemoryResponse
+		switch typ {
+		case string(CommandPut):
+			result, errRsp = tx.put(cmd)
+		case string(CommandDelete):
+			result, errRsp = tx.delete(cmd)
+		default:
+			errRsp = newInMemoryNotSupportedResponse("%s command", typ)
+		}
+		if errRsp != nil {
+			return errRsp
+		}
+		results = append(results, result)
+	}
+	tx.commit()
+
+	return newInMemoryResponse(http.StatusCreated, map[string]interface{}{
+		"Results": results,
+	})
+}
+
+type inMemoryTransaction struct {
+	db       *inMemoryDatabase
+	lastEtag int64
+	// keyed by lower-cased id, nil value means the document was deleted
+	changed map[string]*inMemoryDocument
+	now     time.Time
+}
+
+func (tx *inMemoryTransaction) get(key string) *inMemoryDocument {
+	if doc, ok := tx.changed[key]; ok {
+		return doc
+	}
+	return tx.db.documents[key]
+}
+
+func (tx *inMemoryTransaction) commit() {
+	for key, doc := range tx.changed {
+		if doc == nil {
+			delete(tx.db.documents, key)
+		} else {
+			tx.db.documents[key] = doc
+		}
+	}
+	tx.db.lastEtag = tx.lastEtag
+}
+
+// inMemoryCheckChangeVector returns an error response if expected change vector
+// doesn't match the document. An empty expected change vector means the
+// document must not exist, nil means there's no check
+func inMemoryCheckChangeVector(operation string, id string, doc *inMemoryDocument, expected interface{}) *inMemoryResponse {
+	if expected == nil {
+		return nil
+	}
+	expectedChangeVector, _ := expected.(string)
+	if doc == nil {
+		if expectedChangeVector == "" {
+			return nil
+		}
+		return newInMemoryErrorResponse(http.StatusConflict, "Raven.Client.Exceptions.ConcurrencyException", "Document %s does not exist, but %s was called with change vector: %s. Optimistic concurrency violation, transaction will be aborted.", id, operation, expectedChangeVector)
+	}
+	if doc.changeVector == expectedChangeVector {
+		return nil
+	}
+	return newInMemoryErrorResponse(http.StatusConflict, "Raven.Client.Exceptions.ConcurrencyException", "Document %s has change vector %s, but %s was called with expecting change vector: %s. Optimistic concurrency violation, transaction will be aborted.", id, doc.changeVector, operation, expectedChangeVector)
+}
+
+func (tx *inMemoryTransaction) put(cmd map[string]interface{}) (map[string]interface{}, *inMemoryResponse) {
+	id, _ := jsonGetAsText(cmd, "Id")
+	document, ok := cmd["Document"].(map[string]interface{})
+	if !ok {
+		return nil, newInMemoryErrorResponse(http.StatusBadRequest, "System.ArgumentException", "Document is missing in PUT command of %s", id)
+	}
+
+	switch {
+	case id == "":
+		id = NewUUID().String()
+	case strings.HasSuffix(id, "/"):
+		// server generated id
+		id = fmt.Sprintf("%s%019d-A", id, tx.lastEtag+1)
+	case strings.HasSuffix(id, "|"):
+		prefix := strings.TrimSuffix(id, "|")
+		key := strings.ToLower(prefix)
+		tx.db.identities[key]++
+		id = prefix + "/" + strconv.FormatInt(tx.db.identities[key], 10)
+	}
+
+	key := strings.ToLower(id)
+	if errRsp := inMemoryCheckChangeVector("PUT", id, tx.get(key), cmd["ChangeVector"]); errRsp != nil {
+		return nil, errRsp
+	}
+
+	data := make(map[string]interface{}, len(document))
+	metadata := map[string]interface{}{}
+	for k, v := range document {
+		if k == MetadataKey {
+			if m, ok := v.(map[string]interface{}); ok {
+				metadata = m
+			}
+			continue
+		}
+		data[k] = v
+	}
+	// those are set by the server
+	for _, k := range []string{MetadataID, MetadataChangeVector, MetadataLastModified, MetadataFlags} {
+		delete(metadata, k)
+	}
+
+	tx.lastEtag++
+	doc := &inMemoryDocument{
+		id:           id,
+		etag:         tx.lastEtag,
+		changeVector: fmt.Sprintf("A:%d-%s", tx.lastEtag, inMemoryDatabaseID),
+		lastModified: tx.now,
+		data:         data,
+		metadata:     metadata,
+	}
+	tx.changed[key] = doc
+
+	return map[string]interface{}{
+		"Type":               string(CommandPut),
+		MetadataID:           doc.id,
+		MetadataCollection:   doc.getCollection(),
+		MetadataChangeVector: doc.changeVector,
+		MetadataLastModified: Time(doc.lastModified).Format(),
+	}, nil
+}
+
+func (tx *inMemoryTransaction) delete(cmd map[string]interface{}) (map[string]interface{}, *inMemoryResponse) {
+	id, _ := jsonGetAsText(cmd, "Id")
+	key := strings.ToLower(id)
+	doc := tx.get(key)
+	if errRsp := inMemoryCheckChangeVector("DELETE", id, doc, cmd["ChangeVector"]); errRsp != nil {
+		return nil, errRsp
+	}
+	if doc != nil {
+		tx.lastEtag++
+		tx.changed[key] = nil
+	}
+	return map[string]interface{}{
+		"Type":     string(CommandDelete),
+		MetadataID: id,
+		"Deleted":  doc != nil,
+	}, nil
+}
@@ -0,0 +1,760 @@
+package ravendb
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// inMemoryQuery is a query in the subset of RQL supported by InMemoryServer:
+//
+//	from <collection> [as <alias>] [where <condition>] [order by <field> [as <type>] [desc], ...]
+type inMemoryQuery struct {
+	// empty for @all_docs
+	collection string
+	where      inMemoryCondition
+	orderBy    []*inMemoryOrderBy
+}
+
+type inMemoryOrderBy struct {
+	field      string
+	descending bool
+	numeric    bool
+}
+
+func (db *inMemoryDatabase) query(body []byte) *inMemoryResponse {
+	var v struct {
+		Query           string                 `json:"Query"`
+		QueryParameters map[string]interface{} `json:"QueryParameters"`
+		Start           int                    `json:"Start"`
+		PageSize        *int                   `json:"PageSize"`
+	}
+	if err := jsonUnmarshal(body, &v); err != nil {
+		return newInMemoryErrorResponse(http.StatusBadRequest, "System.ArgumentException", "Invalid request: %s", err)
+	}
+
+	query, err := parseInMemoryQuery(v.Query, v.QueryParameters)
+	if err != nil {
+		return newInMemoryErrorResponse(http.StatusBadRequest, "Raven.Client.Exceptions.InvalidQueryException", "%s Query: %s", err.Error(), v.Query)
+	}
+
+	var docs []*inMemoryDocument
+	for _, doc := range db.documents {
+		if query.collection != "" && !strings.EqualFold(doc.getCollection(), query.collection) {
+			continue
+		}
+		if query.where != nil && !query.where(doc) {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	// without order by documents are returned in the order they were written
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].etag < docs[j].etag
+	})
+	if len(query.orderBy) > 0 {
+		sort.SliceStable(docs, func(i, j int) bool {
+			return query.less(docs[i], docs[j])
+		})
+	}
+
+	totalResults := len(docs)
+	if v.Start > 0 {
+		if v.Start > len(docs) {
+			v.Start = len(docs)
+		}
+		docs = docs[v.Start:]
+	}
+	if v.PageSize != nil && *v.PageSize < len(docs) {
+		docs = docs[:*v.PageSize]
+	}
+
+	results := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		results[i] = doc.toJSON(false)
+	}
+	indexName := "collection/" + query.collection
+	if query.collection == "" {
+		indexName = "AllDocs"
+	}
+	now := Time(time.Now().UTC())
+	return newInMemoryResponse(http.StatusOK, map[string]interface{}{
+		"TotalResults":   totalResults,
+		"SkippedResults": 0,
+		"DurationInMs":   0,
+		"Results":        results,
+		"Includes":       map[string]interface{}{},
+		"IndexName":      indexName,
+		"IsStale":        false,
+		"ResultEtag":     db.lastEtag,
+		"IndexTimestamp": now,
+		"LastQueryTime":  now,
+	})
+}
+
+func (q *inMemoryQuery) less(a, b *inMemoryDocument) bool {
+	for _, orderBy := range q.orderBy {
+		va := inMemoryFirstValue(a, orderBy.field)
+		vb := inMemoryFirstValue(b, orderBy.field)
+		if orderBy.numeric {
+			va, vb = inMemoryToNumber(va), inMemoryToNumber(vb)
+		}
+		c := inMemoryCompareForSort(va, vb)
+		if c == 0 {
+			continue
+		}
+		if orderBy.descending {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
+
+// inMemoryGetValues returns values of a (possibly nested e.g. Address.City)
+// field of a document. Arrays are flattened so that a query matches a
+// document if any of the values matches
+func inMemoryGetValues(doc *inMemoryDocument, path string) []interface{} {
+	if path == "id()" {
+		return []interface{}{doc.id}
+	}
+	values := []interface{}{doc.data}
+	if strings.HasPrefix(path, MetadataKey+".") {
+		values = []interface{}{doc.toJSON(true)}
+	}
+	for _, part := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, v := range values {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			v, ok = m[part]
+			if !ok {
+				continue
+			}
+			if a, ok := v.([]interface{}); ok {
+				next = append(next, a...)
+			} else {
+				next = append(next, v)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+func inMemoryFirstValue(doc *inMemoryDocument, path string) interface{} {
+	values := inMemoryGetValues(doc, path)
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+func inMemoryToNumber(v interface{}) interface{} {
+	if s, ok := v.(string); ok {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+// inMemoryCompare compares json values. ok is false if values are of
+// different types. Strings are compared case-insensitively, like in RavenDB
+func inMemoryCompare(a, b interface{}) (int, bool) {
+	switch va := a.(type) {
+	case nil:
+		return 0, b == nil
+	case bool:
+		vb, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		if va == vb {
+			return 0, true
+		}
+		if !va {
+			return -1, true
+		}
+		return 1, true
+	case float64:
+		vb, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case va < vb:
+			return -1, true
+		case va > vb:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		vb, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(strings.ToLower(va), strings.ToLower(vb)), true
+	}
+	return 0, false
+}
+
+// inMemoryCompareForSort orders values of different types by type: null,
+// bool, number, string, others
+func inMemoryCompareForSort(a, b interface{}) int {
+	if c, ok := inMemoryCompare(a, b); ok {
+		return c
+	}
+	typeOrder := func(v interface{}) int {
+		switch v.(type) {
+		case nil:
+			return 0
+		case bool:
+			return 1
+		case float64:
+			return 2
+		case string:
+			return 3
+		}
+		return 4
+	}
+	return typeOrder(a) - typeOrder(b)
+}
+
+func inMemoryEquals(a, b interface{}) bool {
+	c, ok := inMemoryCompare(a, b)
+	return ok && c == 0
+}
+
+const (
+	rqlTokenIdentifier = iota
+	rqlTokenString
+	rqlTokenNumber
+	rqlTokenParameter
+	rqlTokenSymbol
+)
+
+type rqlToken struct {
+	kind int
+	text string
+}
+
+func rqlTokenize(s string) ([]*rqlToken, error) {
+	var res []*rqlToken
+	isIdentifierChar := func(c rune) bool {
+		return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_@.[]", c)
+	}
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != c; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, newInvalidQueryError("Unterminated string")
+			}
+			i++
+			res = append(res, &rqlToken{kind: rqlTokenString, text: sb.String()})
+		case c == '$':
+			start := i + 1
+			for i++; i < len(runes) && isIdentifierChar(runes[i]); i++ {
+			}
+			res = append(res, &rqlToken{kind: rqlTokenParameter, text: string(runes[start:i])})
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.'); i++ {
+			}
+			res = append(res, &rqlToken{kind: rqlTokenNumber, text: string(runes[start:i])})
+		case isIdentifierChar(c):
+			start := i
+			for ; i < len(runes) && isIdentifierChar(runes[i]); i++ {
+			}
+			res = append(res, &rqlToken{kind: rqlTokenIdentifier, text: string(runes[start:i])})
+		default:
+			op := string(c)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", "<>", "<=", ">=":
+					op = two
+				}
+			}
+			switch op {
+			case "=", "==", "!=", "<>", "<", "<=", ">", ">=", "(", ")", ",":
+			default:
+				return nil, newInvalidQueryError("Unexpected character '%c'", c)
+			}
+			i += len(op)
+			res = append(res, &rqlToken{kind: rqlTokenSymbol, text: op})
+		}
+	}
+	return res, nil
+}
+
+type inMemoryQueryParser struct {
+	tokens     []*rqlToken
+	pos        int
+	parameters map[string]interface{}
+	alias      string
+}
+
+func parseInMemoryQuery(query string, parameters map[string]interface{}) (*inMemoryQuery, error) {
+	tokens, err := rqlTokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &inMemoryQueryParser{
+		tokens:     tokens,
+		parameters: parameters,
+	}
+	return p.parseQuery()
+}
+
+func (p *inMemoryQueryParser) peek() *rqlToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *inMemoryQueryParser) next() *rqlToken {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *inMemoryQueryParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t != nil && t.kind == rqlTokenIdentifier && strings.EqualFold(t.text, keyword)
+}
+
+func (p *inMemoryQueryParser) isSymbol(symbol string) bool {
+	t := p.peek()
+	return t != nil && t.kind == rqlTokenSymbol && t.text == symbol
+}
+
+func (p *inMemoryQueryParser) expectKeyword(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return p.unexpected("'" + keyword + "'")
+	}
+	p.pos++
+	return nil
+}
+
+func (p *inMemoryQueryParser) expectSymbol(symbol string) error {
+	if !p.isSymbol(symbol) {
+		return p.unexpected("'" + symbol + "'")
+	}
+	p.pos++
+	return nil
+}
+
+func (p *inMemoryQueryParser) unexpected(expected string) error {
+	t := p.peek()
+	if t == nil {
+		return newInvalidQueryError("Expected %s but the query ended", expected)
+	}
+	return newInvalidQueryError("Expected %s but got '%s'. In-memory server supports only simple queries", expected, t.text)
+}
+
+func (p *inMemoryQueryParser) parseQuery() (*inMemoryQuery, error) {
+	if err := p.expectKeyword("from"); err != nil {
+		return nil, err
+	}
+	if p.isKeyword("index") {
+		return nil, newInvalidQueryError("In-memory server doesn't support querying indexes")
+	}
+	t := p.next()
+	if t == nil || (t.kind != rqlTokenIdentifier && t.kind != rqlTokenString) {
+		return nil, newInvalidQueryError("Expected collection name")
+	}
+	res := &inMemoryQuery{}
+	if !strings.EqualFold(t.text, "@all_docs") {
+		res.collection = t.text
+	}
+
+	if p.isKeyword("as") {
+		p.pos++
+		alias := p.next()
+		if alias == nil || alias.kind != rqlTokenIdentifier {
+			return nil, newInvalidQueryError("Expected alias after 'as'")
+		}
+		p.alias = alias.text
+	}
+
+	if p.isKeyword("where") {
+		p.pos++
+		where, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		res.where = where
+	}
+
+	if p.isKeyword("order") {
+		p.pos++
+		if err := p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+		for {
+			orderBy, err := p.parseOrderBy()
+			if err != nil {
+				return nil, err
+			}
+			res.orderBy = append(res.orderBy, orderBy)
+			if !p.isSymbol(",") {
+				break
+			}
+			p.pos++
+		}
+	}
+
+	if t := p.peek(); t != nil {
+		return nil, newInvalidQueryError("In-memory server doesn't support '%s'", t.text)
+	}
+	return res, nil
+}
+
+func (p *inMemoryQueryParser) parseOrderBy() (*inMemoryOrderBy, error) {
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+	res := &inMemoryOrderBy{
+		field: field,
+	}
+	if p.isKeyword("as") {
+		p.pos++
+		t := p.next()
+		if t == nil {
+			return nil, newInvalidQueryError("Expected ordering type after 'as'")
+		}
+		switch strings.ToLower(t.text) {
+		case "long", "double":
+			res.numeric = true
+		case "string", "alphanumeric":
+		default:
+			return nil, newInvalidQueryError("In-memory server doesn't support ordering as '%s'", t.text)
+		}
+	}
+	if p.isKeyword("desc") || p.isKeyword("descending") {
+		p.pos++
+		res.descending = true
+	} else if p.isKeyword("asc") || p.isKeyword("ascending") {
+		p.pos++
+	}
+	return res, nil
+}
+
+func (p *inMemoryQueryParser) parseField() (string, error) {
+	t := p.peek()
+	if t == nil || (t.kind != rqlTokenIdentifier && t.kind != rqlTokenString) {
+		return "", p.unexpected("field name")
+	}
+	p.pos++
+	field := t.text
+	if p.isSymbol("(") {
+		if !strings.EqualFold(field, "id") {
+			return "", newInvalidQueryError("In-memory server doesn't support method '%s'", field)
+		}
+		p.pos++
+		if err := p.expectSymbol(")"); err != nil {
+			return "", err
+		}
+		return "id()", nil
+	}
+	if p.alias != "" {
+		field = strings.TrimPrefix(field, p.alias+".")
+	}
+	return field, nil
+}
+
+type inMemoryCondition = func(doc *inMemoryDocument) bool
+
+func (p *inMemoryQueryParser) parseOr() (inMemoryCondition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(doc *inMemoryDocument) bool {
+			return l(doc) || right(doc)
+		}
+	}
+	return left, nil
+}
+
+func (p *inMemoryQueryParser) parseAnd() (inMemoryCondition, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(doc *inMemoryDocument) bool {
+			return l(doc) && right(doc)
+		}
+	}
+	return left, nil
+}
+
+func (p *inMemoryQueryParser) parseUnary() (inMemoryCondition, error) {
+	switch {
+	case p.isKeyword("not"):
+		p.pos++
+		cond, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(doc *inMemoryDocument) bool {
+			return !cond(doc)
+		}, nil
+	case p.isKeyword("true"):
+		p.pos++
+		return func(doc *inMemoryDocument) bool {
+			return true
+		}, nil
+	case p.isSymbol("("):
+		p.pos++
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return cond, nil
+	case p.isKeyword("startsWith"), p.isKeyword("endsWith"):
+		return p.parseStringMethod()
+	}
+	return p.parsePredicate()
+}
+
+// parseStringMethod parses startsWith(field, $p) and endsWith(field, $p)
+func (p *inMemoryQueryParser) parseStringMethod() (inMemoryCondition, error) {
+	method := strings.ToLower(p.next().text)
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectSymbol(","); err != nil {
+		return nil, err
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, newInvalidQueryError("Argument of %s must be a string", method)
+	}
+	s = strings.ToLower(s)
+	match := strings.HasPrefix
+	if method == "endswith" {
+		match = strings.HasSuffix
+	}
+	return func(doc *inMemoryDocument) bool {
+		for _, v := range inMemoryGetValues(doc, field) {
+			if vs, ok := v.(string); ok && match(strings.ToLower(vs), s) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func (p *inMemoryQueryParser) parsePredicate() (inMemoryCondition, error) {
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	anyValue := func(fn func(v interface{}) bool) inMemoryCondition {
+		return func(doc *inMemoryDocument) bool {
+			for _, v := range inMemoryGetValues(doc, field) {
+				if fn(v) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	switch {
+	case p.isKeyword("in"):
+		p.pos++
+		values, err := p.parseValueList()
+		if err != nil {
+			return nil, err
+		}
+		return anyValue(func(v interface{}) bool {
+			for _, expected := range values {
+				if inMemoryEquals(v, expected) {
+					return true
+				}
+			}
+			return false
+		}), nil
+	case p.isKeyword("between"):
+		p.pos++
+		from, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectKeyword("and"); err != nil {
+			return nil, err
+		}
+		to, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return anyValue(func(v interface{}) bool {
+			c1, ok1 := inMemoryCompare(v, from)
+			c2, ok2 := inMemoryCompare(v, to)
+			return ok1 && ok2 && c1 >= 0 && c2 <= 0
+		}), nil
+	}
+
+	t := p.peek()
+	if t == nil || t.kind != rqlTokenSymbol {
+		return nil, p.unexpected("operator")
+	}
+	p.pos++
+	op := t.text
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "=", "==":
+		if value == nil {
+			// matches documents without the field as well
+			return func(doc *inMemoryDocument) bool {
+				return inMemoryFirstValue(doc, field) == nil
+			}, nil
+		}
+		return anyValue(func(v interface{}) bool {
+			return inMemoryEquals(v, value)
+		}), nil
+	case "!=", "<>":
+		equals := anyValue(func(v interface{}) bool {
+			return inMemoryEquals(v, value)
+		})
+		return func(doc *inMemoryDocument) bool {
+			return !equals(doc)
+		}, nil
+	case "<", "<=", ">", ">=":
+		return anyValue(func(v interface{}) bool {
+			c, ok := inMemoryCompare(v, value)
+			if !ok || v == nil {
+				return false
+			}
+			switch op {
+			case "<":
+				return c < 0
+			case "<=":
+				return c <= 0
+			case ">":
+				return c > 0
+			}
+			return c >= 0
+		}), nil
+	}
+	return nil, newInvalidQueryError("In-memory server doesn't support operator '%s'", op)
+}
+
+// parseValueList parses ($p0, $p1, ...). Array parameters are flattened
+func (p *inMemoryQueryParser) parseValueList() ([]interface{}, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var res []interface{}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if a, ok := value.([]interface{}); ok {
+			res = append(res, a...)
+		} else {
+			res = append(res, value)
+		}
+		if !p.isSymbol(",") {
+			break
+		}
+		p.pos++
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (p *inMemoryQueryParser) parseValue() (interface{}, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, p.unexpected("value")
+	}
+	p.pos++
+	switch t.kind {
+	case rqlTokenParameter:
+		v, ok := p.parameters[t.text]
+		if !ok {
+			return nil, newInvalidQueryError("Query parameter '%s' is missing", t.text)
+		}
+		return v, nil
+	case rqlTokenString:
+		return t.text, nil
+	case rqlTokenNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil || math.IsInf(f, 0) {
+			return nil, newInvalidQueryError("Invalid number '%s'", t.text)
+		}
+		return f, nil
+	case rqlTokenIdentifier:
+		switch strings.ToLower(t.text) {
+		case "null":
+			return nil, nil
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	p.pos--
+	return nil, p.unexpected("value")
+}
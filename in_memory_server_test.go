@@ -0,0 +1,418 @@
+package ravendb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemoryAddress struct {
+	City string
+}
+
+type inMemoryUser struct {
+	ID       string
+	Name     string
+	Age      int
+	Address  *inMemoryAddress
+	Tags     []string
+	FriendID string
+}
+
+func newTestInMemoryStore(t *testing.T) *DocumentStore {
+	store := NewInMemoryDocumentStore("db")
+	err := store.Initialize()
+	require.NoError(t, err)
+	return store
+}
+
+// renameUser is an example of application code that depends on
+// IDocumentSession instead of *DocumentSession
+func renameUser(session IDocumentSession, id string, name string) error {
+	var user *inMemoryUser
+	if err := session.Load(&user, id); err != nil {
+		return err
+	}
+	if user == nil {
+		return newIllegalArgumentError("user %s doesn't exist", id)
+	}
+	user.Name = name
+	return session.SaveChanges()
+}
+
+func inMemoryStoreUsers(t *testing.T, store IDocumentStore, users ...*inMemoryUser) {
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+	for _, user := range users {
+		err = session.Store(user)
+		require.NoError(t, err)
+	}
+	err = session.SaveChanges()
+	require.NoError(t, err)
+}
+
+func TestInMemoryStoreLoadDelete(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	user := &inMemoryUser{Name: "John", Age: 30}
+	inMemoryStoreUsers(t, store, user)
+	assert.Equal(t, "inMemoryUsers/1-A", user.ID)
+
+	{
+		session, err := store.OpenSession("")
+		require.NoError(t, err)
+		var loaded *inMemoryUser
+		err = session.Load(&loaded, "INMEMORYUSERS/1-a")
+		require.NoError(t, err)
+		require.NotNil(t, loaded)
+		assert.Equal(t, "John", loaded.Name)
+		assert.Equal(t, 30, loaded.Age)
+
+		changeVector, err := session.Advanced().GetChangeVectorFor(loaded)
+		require.NoError(t, err)
+		assert.Equal(t, "A:1-"+inMemoryDatabaseID, *changeVector)
+
+		var missing *inMemoryUser
+		err = session.Load(&missing, "inMemoryUsers/2-A")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+
+		ok, err := session.Exists(user.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = session.Exists("inMemoryUsers/2-A")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		session.Close()
+	}
+
+	{
+		session, err := store.OpenSession("")
+		require.NoError(t, err)
+		err = renameUser(session, user.ID, "Jack")
+		require.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session, err := store.OpenSession("")
+		require.NoError(t, err)
+		var loaded *inMemoryUser
+		err = session.Load(&loaded, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Jack", loaded.Name)
+		changeVector, err := session.Advanced().GetChangeVectorFor(loaded)
+		require.NoError(t, err)
+		assert.Equal(t, "A:2-"+inMemoryDatabaseID, *changeVector)
+
+		err = session.DeleteByID(user.ID, "")
+		require.NoError(t, err)
+		err = session.SaveChanges()
+		require.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session, err := store.OpenSession("")
+		require.NoError(t, err)
+		var loaded *inMemoryUser
+		err = session.Load(&loaded, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, loaded)
+		session.Close()
+	}
+}
+
+func TestInMemoryStoreMetadata(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	{
+		session, err := store.OpenSession("")
+		require.NoError(t, err)
+		user := &inMemoryUser{Name: "John"}
+		err = session.StoreWithID(user, "users/john")
+		require.NoError(t, err)
+		metadata, err := session.Advanced().GetMetadataFor(user)
+		require.NoError(t, err)
+		metadata.Put("Department", "Sales")
+		err = session.SaveChanges()
+		require.NoError(t, err)
+		session.Close()
+	}
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+	var user *inMemoryUser
+	err = session.Load(&user, "users/john")
+	require.NoError(t, err)
+	metadata, err := session.Advanced().GetMetadataFor(user)
+	require.NoError(t, err)
+	v, _ := metadata.Get("Department")
+	assert.Equal(t, "Sales", v)
+	v, _ = metadata.Get(MetadataCollection)
+	assert.Equal(t, "inMemoryUsers", v)
+	v, _ = metadata.Get(MetadataLastModified)
+	assert.NotEmpty(t, v)
+}
+
+func TestInMemoryStoreOptimisticConcurrency(t *testing.T) {
+	store := NewInMemoryDocumentStore("db")
+	store.GetConventions().UseOptimisticConcurrency = true
+	err := store.Initialize()
+	require.NoError(t, err)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store, &inMemoryUser{ID: "users/1", Name: "John"})
+
+	session1, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session1.Close()
+	session2, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session2.Close()
+
+	var user1, user2 *inMemoryUser
+	err = session1.Load(&user1, "users/1")
+	require.NoError(t, err)
+	err = session2.Load(&user2, "users/1")
+	require.NoError(t, err)
+
+	user1.Name = "Jack"
+	err = session1.SaveChanges()
+	require.NoError(t, err)
+
+	user2.Name = "Jim"
+	err = session2.SaveChanges()
+	require.Error(t, err)
+	_, ok := err.(*ConcurrencyError)
+	assert.True(t, ok, "expected ConcurrencyError, got %T", err)
+
+	// storing a new document with an id that is already taken fails as well
+	session3, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session3.Close()
+	err = session3.StoreWithID(&inMemoryUser{Name: "Other"}, "users/2")
+	require.NoError(t, err)
+	err = session3.StoreWithID(&inMemoryUser{Name: "Duplicate"}, "users/1")
+	require.NoError(t, err)
+	err = session3.SaveChanges()
+	_, ok = err.(*ConcurrencyError)
+	assert.True(t, ok, "expected ConcurrencyError, got %T", err)
+
+	// the batch failed so users/2 wasn't stored either
+	session4, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session4.Close()
+	ok, err = session4.Exists("users/2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	var user *inMemoryUser
+	err = session4.Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, "Jack", user.Name)
+}
+
+func TestInMemoryStoreStoreWithChangeVector(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store, &inMemoryUser{ID: "users/1", Name: "John"})
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+	err = session.StoreWithChangeVectorAndID(&inMemoryUser{Name: "Jack"}, "A:7-"+inMemoryDatabaseID, "users/1")
+	require.NoError(t, err)
+	err = session.SaveChanges()
+	_, ok := err.(*ConcurrencyError)
+	assert.True(t, ok, "expected ConcurrencyError, got %T", err)
+}
+
+func TestInMemoryStoreQuery(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store,
+		&inMemoryUser{ID: "users/1", Name: "John", Age: 30, Address: &inMemoryAddress{City: "London"}, Tags: []string{"a", "b"}},
+		&inMemoryUser{ID: "users/2", Name: "Jack", Age: 25, Address: &inMemoryAddress{City: "Paris"}, Tags: []string{"b"}},
+		&inMemoryUser{ID: "users/3", Name: "Jim", Age: 40, Address: &inMemoryAddress{City: "London"}},
+		&inMemoryUser{ID: "users/4", Name: "john", Age: 20},
+	)
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	userType := reflect.TypeOf(&inMemoryUser{})
+	names := func(q *DocumentQuery) []string {
+		var users []*inMemoryUser
+		err := q.GetResults(&users)
+		require.NoError(t, err)
+		var res []string
+		for _, u := range users {
+			res = append(res, u.Name)
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"John", "john"}, names(session.QueryCollectionForType(userType).WhereEquals("Name", "JOHN")))
+	assert.Equal(t, []string{"Jack", "Jim"}, names(session.QueryCollectionForType(userType).WhereNotEquals("Name", "john").OrderBy("Age")))
+	assert.Equal(t, []string{"Jim", "John"}, names(session.QueryCollectionForType(userType).WhereEquals("Address.City", "London").OrderByDescending("Age")))
+	assert.Equal(t, []string{"Jack", "Jim"}, names(session.QueryCollectionForType(userType).WhereEquals("Name", "Jim").OrElse().WhereEquals("Tags", "b").AndAlso().WhereLessThan("Age", 30)))
+	assert.Equal(t, []string{"Jack", "Jim"}, names(session.QueryCollectionForType(userType).WhereIn("Name", []interface{}{"Jim", "Jack"}).OrderBy("Name")))
+	assert.Equal(t, []string{"Jim", "John"}, names(session.QueryCollectionForType(userType).WhereStartsWith("Name", "j").OrderBy("Name").Skip(1).Take(2)))
+	assert.Equal(t, []string{"John", "Jim"}, names(session.QueryCollectionForType(userType).WhereBetween("Age", 30, 40)))
+	assert.Equal(t, []string{"Jim"}, names(session.QueryCollectionForType(userType).WhereEquals("id()", "users/3")))
+
+	count, err := session.QueryCollection("inMemoryUsers").WhereGreaterThanOrEqual("Age", 25).Count()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	var users []*inMemoryUser
+	err = session.QueryIndex("Users/ByName").GetResults(&users)
+	_, ok := err.(*InvalidQueryError)
+	assert.True(t, ok, "expected InvalidQueryError, got %T", err)
+}
+
+func TestInMemoryStoreLoadStartingWithAndIncludes(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store,
+		&inMemoryUser{ID: "users/1", Name: "John", FriendID: "users/2"},
+		&inMemoryUser{ID: "users/2", Name: "Jack"},
+		&inMemoryUser{ID: "people/1", Name: "Jim"},
+	)
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var users []*inMemoryUser
+	err = session.LoadStartingWith(&users, &StartsWithArgs{StartsWith: "users/"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(users))
+
+	// includes are checked in a fresh session so that users/2 isn't already
+	// tracked because of LoadStartingWith
+	session2, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session2.Close()
+
+	var user *inMemoryUser
+	err = session2.Include("FriendID").Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, session2.GetNumberOfRequests())
+	assert.True(t, session2.IsLoaded("users/2"))
+
+	var friend *inMemoryUser
+	err = session2.Load(&friend, user.FriendID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jack", friend.Name)
+	assert.Equal(t, 1, session2.GetNumberOfRequests())
+
+	// without the include, loading the friend needs another request
+	session3, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session3.Close()
+
+	user = nil
+	err = session3.Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.False(t, session3.IsLoaded("users/2"))
+	friend = nil
+	err = session3.Load(&friend, user.FriendID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jack", friend.Name)
+	assert.Equal(t, 2, session3.GetNumberOfRequests())
+}
+
+func TestInMemoryServerResolvesIncludes(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store,
+		&inMemoryUser{ID: "users/1", Name: "John", FriendID: "Users/2", Tags: []string{"users/3", "users/404"}},
+		&inMemoryUser{ID: "users/2", Name: "Jack"},
+		&inMemoryUser{ID: "users/3", Name: "Jim"},
+	)
+
+	// the server sends included documents without help of the session
+	cmd, err := NewGetDocumentsCommand([]string{"users/1"}, []string{"FriendID", "Tags[]"}, false)
+	require.NoError(t, err)
+	err = store.GetRequestExecutor("").ExecuteCommand(cmd, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cmd.Result.Results))
+	var ids []string
+	for id := range cmd.Result.Includes {
+		ids = append(ids, id)
+	}
+	assert.ElementsMatch(t, []string{"users/2", "users/3"}, ids)
+}
+
+func TestInMemoryServerSharedByStores(t *testing.T) {
+	server := NewInMemoryServer()
+	store1 := server.NewDocumentStore("db")
+	require.NoError(t, store1.Initialize())
+	defer store1.Close()
+	store2 := server.NewDocumentStore("db")
+	require.NoError(t, store2.Initialize())
+	defer store2.Close()
+	otherDB := server.NewDocumentStore("other")
+	require.NoError(t, otherDB.Initialize())
+	defer otherDB.Close()
+
+	inMemoryStoreUsers(t, store1, &inMemoryUser{ID: "users/1", Name: "John"})
+
+	op := NewGetStatisticsOperation("")
+	err := store2.Maintenance().Send(op)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), op.Command.Result.CountOfDocuments)
+
+	session, err := store2.OpenSession("")
+	require.NoError(t, err)
+	ok, err := session.Exists("users/1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	session.Close()
+
+	session, err = otherDB.OpenSession("")
+	require.NoError(t, err)
+	ok, err = session.Exists("users/1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	session.Close()
+}
+
+func TestParseInMemoryQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		ok    bool
+	}{
+		{"from Users", true},
+		{"from 'Users' as u where u.Name = $p0 order by u.Age as long desc", true},
+		{"from @all_docs where (Name = $p0 or Name = null) and not Age > 3", true},
+		{"from Users where startsWith(Name, $p0) and Age between 1 and 10", true},
+		{"from index 'Users/ByName'", false},
+		{"from Users where search(Name, $p0)", false},
+		{"from Users where Name = $missing", false},
+		{"from Users select Name", false},
+		{"from Users include FriendID", false},
+		{"from Users order by random()", false},
+	}
+	params := map[string]interface{}{"p0": "a"}
+	for _, test := range tests {
+		_, err := parseInMemoryQuery(test.query, params)
+		if test.ok {
+			assert.NoError(t, err, test.query)
+		} else {
+			assert.Error(t, err, test.query)
+		}
+	}
+}
@@ -1,9 +1,125 @@
 package ravendb
 
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// includesUtilInclude calls loadID with ids of documents referenced by
+// document at include path. The path is a list of properties separated
+// by '.' e.g. "Order.Customer". Arrays are traversed at any level, which can
+// be made explicit with "[]" or ',' (e.g. "Lines[].Product" or
+// "Lines,Product"). "$Keys" and "$Values" select keys and values of
+// a dictionary. A path can end with a prefix in parentheses (e.g.
+// "Supplier(suppliers/)") in which case the value is a suffix of the id
+// e.g. 5 in "suppliers/5".
 func includesUtilInclude(document map[string]interface{}, include string, loadID func(string)) {
 	if stringIsEmpty(include) || document == nil {
 		return
 	}
 
-	//TBD:
+	path, prefix := includesUtilSplitPrefix(include)
+	path = strings.Replace(path, "[]", "", -1)
+	path = strings.Replace(path, ",", ".", -1)
+
+	values := []interface{}{document}
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			continue
+		}
+		var next []interface{}
+		for _, v := range values {
+			next = includesUtilSelect(next, v, part)
+		}
+		values = next
+	}
+
+	for _, v := range values {
+		includesUtilLoadValue(v, prefix, loadID)
+	}
+}
+
+// includesUtilSplitPrefix splits "Supplier(suppliers/)" into "Supplier"
+// and "suppliers/"
+func includesUtilSplitPrefix(include string) (string, string) {
+	if !strings.HasSuffix(include, ")") {
+		return include, ""
+	}
+	idx := strings.LastIndex(include, "(")
+	if idx < 0 {
+		return include, ""
+	}
+	return include[:idx], include[idx+1 : len(include)-1]
+}
+
+// includesUtilSelect appends values of property name of v to res, traversing
+// arrays
+func includesUtilSelect(res []interface{}, v interface{}, name string) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, el := range v {
+			res = includesUtilSelect(res, el, name)
+		}
+	case map[string]interface{}:
+		switch name {
+		case "$Keys", "$Values":
+			// sorted for deterministic order
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if name == "$Keys" {
+					res = append(res, key)
+				} else {
+					res = append(res, v[key])
+				}
+			}
+		default:
+			if el, ok := v[name]; ok && el != nil {
+				res = append(res, el)
+			}
+		}
+	}
+	return res
+}
+
+func includesUtilLoadValue(v interface{}, prefix string, loadID func(string)) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, el := range v {
+			includesUtilLoadValue(el, prefix, loadID)
+		}
+	case string:
+		if v != "" {
+			loadID(prefix + v)
+		}
+	case float64:
+		loadID(prefix + strconv.FormatFloat(v, 'f', -1, 64))
+	}
+}
+
+// includesUtilRequiresQuotes returns true if include path must be quoted
+// in a query, together with its escaped version
+func includesUtilRequiresQuotes(include string) (string, bool) {
+	for _, ch := range include {
+		if !isLetterOrDigit(ch) && ch != '_' && ch != '.' {
+			return strings.Replace(include, "'", "\\'", -1), true
+		}
+	}
+	return "", false
+}
+
+// includesUtilWriteInclude writes include path to a query, quoting it
+// if necessary
+func includesUtilWriteInclude(queryText *strings.Builder, include string) {
+	if escaped, ok := includesUtilRequiresQuotes(include); ok {
+		queryText.WriteString("'")
+		queryText.WriteString(escaped)
+		queryText.WriteString("'")
+	} else {
+		queryText.WriteString(include)
+	}
 }
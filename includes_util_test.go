@@ -0,0 +1,134 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncludesUtilInclude(t *testing.T) {
+	js := `{
+		"Customer": "customers/1",
+		"Supplier": 5,
+		"Order": {"Employee": "employees/1"},
+		"Lines": [
+			{"Product": "products/1", "Tags": ["tags/1", "tags/2"]},
+			{"Product": "products/2"},
+			{"Product": null}
+		],
+		"Related": ["orders/1", "orders/2"],
+		"ByRegion": {"west": "regions/2", "east": "regions/1"},
+		"Empty": ""
+	}`
+	var document map[string]interface{}
+	err := jsonUnmarshal([]byte(js), &document)
+	require.NoError(t, err)
+
+	tests := []struct {
+		include string
+		exp     []string
+	}{
+		{"Customer", []string{"customers/1"}},
+		{"Order.Employee", []string{"employees/1"}},
+		{"Lines.Product", []string{"products/1", "products/2"}},
+		{"Lines[].Product", []string{"products/1", "products/2"}},
+		{"Lines,Product", []string{"products/1", "products/2"}},
+		{"Lines[].Tags[]", []string{"tags/1", "tags/2"}},
+		{"Related", []string{"orders/1", "orders/2"}},
+		{"ByRegion.$Values", []string{"regions/1", "regions/2"}},
+		{"ByRegion.$Keys", []string{"east", "west"}},
+		{"Supplier(suppliers/)", []string{"suppliers/5"}},
+		{"Lines[].Product(catalog/)", []string{"catalog/products/1", "catalog/products/2"}},
+		{"Missing", nil},
+		{"Customer.Name", nil},
+		{"Empty", nil},
+		{"", nil},
+	}
+	for _, test := range tests {
+		var got []string
+		includesUtilInclude(document, test.include, func(id string) {
+			got = append(got, id)
+		})
+		assert.Equal(t, test.exp, got, "include: %s", test.include)
+	}
+
+	includesUtilInclude(nil, "Customer", func(id string) {
+		assert.Fail(t, "unexpected id "+id)
+	})
+}
+
+func TestSessionLoadWithIncludesSkipsServerIfAllDocumentsAreLoaded(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store,
+		&inMemoryUser{ID: "users/1", Name: "John", FriendID: "users/2"},
+		&inMemoryUser{ID: "users/2", Name: "Jack"},
+		&inMemoryUser{ID: "users/3", Name: "Jim", FriendID: "users/404"},
+	)
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var user *inMemoryUser
+	err = session.Include("FriendID").Load(&user, "users/1")
+	require.NoError(t, err)
+	requests := session.GetNumberOfRequests()
+
+	// both users/1 and the included users/2 are in the session
+	err = session.Include("FriendID").Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, "John", user.Name)
+	assert.Equal(t, requests, session.GetNumberOfRequests())
+
+	// users/1 is loaded but the document included by path Name isn't
+	err = session.Include("Name").Load(&user, "users/1")
+	require.NoError(t, err)
+	requests++
+	assert.Equal(t, requests, session.GetNumberOfRequests())
+	assert.True(t, session.IsLoaded("John"))
+
+	// the included document doesn't exist and is remembered as missing
+	err = session.Include("FriendID").Load(&user, "users/3")
+	require.NoError(t, err)
+	requests++
+	assert.Equal(t, requests, session.GetNumberOfRequests())
+	assert.True(t, session.Advanced().IsLoaded("users/404"))
+
+	var friend *inMemoryUser
+	err = session.Load(&friend, "users/404")
+	require.NoError(t, err)
+	assert.Nil(t, friend)
+	err = session.Include("FriendID").Load(&user, "users/3")
+	require.NoError(t, err)
+	assert.Equal(t, requests, session.GetNumberOfRequests())
+}
+
+func TestSessionLoadWithIncludesOfMixedCaseReference(t *testing.T) {
+	store := newTestInMemoryStore(t)
+	defer store.Close()
+
+	inMemoryStoreUsers(t, store,
+		&inMemoryUser{ID: "users/1", Name: "John", FriendID: "Users/2"},
+		&inMemoryUser{ID: "users/2", Name: "Jack"},
+	)
+
+	session, err := store.OpenSession("")
+	require.NoError(t, err)
+	defer session.Close()
+
+	var user *inMemoryUser
+	err = session.Include("FriendID").Load(&user, "users/1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, session.GetNumberOfRequests())
+	assert.False(t, session.IsDeleted("users/2"))
+
+	var friend *inMemoryUser
+	err = session.Load(&friend, "users/2")
+	require.NoError(t, err)
+	require.NotNil(t, friend)
+	assert.Equal(t, "Jack", friend.Name)
+	assert.Equal(t, 1, session.GetNumberOfRequests())
+}
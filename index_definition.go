@@ -9,7 +9,7 @@ type IndexDefinition struct {
 	Reduce            *string                       `json:"Reduce"`
 	Fields            map[string]*IndexFieldOptions `json:"Fields"`
 	Configuration     IndexConfiguration            `json:"Configuration"`
-	IndexType         IndexType                     `json:"Type"`
+	IndexType         IndexType                     `json:"Type,omitempty"`
 	//TBD 4.1  bool testIndex;
 	OutputReduceToCollection *string `json:"OutputReduceToCollection"`
 }
@@ -27,9 +27,14 @@ func newLazyLoadOperation(session *InMemoryDocumentSessionOperations, loadOperat
 // needed for ILazyOperation
 func (o *LazyLoadOperation) createRequest() *getRequest {
 	var idsToCheckOnServer []string
-	for _, id := range o._ids {
-		if !o._session.IsLoadedOrDeleted(id) {
-			idsToCheckOnServer = append(idsToCheckOnServer, id)
+	if len(o._includes) > 0 {
+		// documents referenced by loaded documents might not be loaded
+		idsToCheckOnServer = o._session.idsToCheckOnServerWithIncludes(o._ids)
+	} else {
+		for _, id := range o._ids {
+			if !o._session.IsLoadedOrDeleted(id) {
+				idsToCheckOnServer = append(idsToCheckOnServer, id)
+			}
 		}
 	}
 	queryBuilder := "?"
@@ -13,6 +13,11 @@ type LoadOperation struct {
 	ids                []string
 	includes           []string
 	idsToCheckOnServer []string
+
+	countersToInclude  []string
+	includeAllCounters bool
+
+	timeSeriesToInclude []*TimeSeriesRange
 }
 
 func NewLoadOperation(session *InMemoryDocumentSessionOperations) *LoadOperation {
@@ -22,7 +27,7 @@ func NewLoadOperation(session *InMemoryDocumentSessionOperations) *LoadOperation
 }
 
 func (o *LoadOperation) createRequest() (*GetDocumentsCommand, error) {
-	if len(o.idsToCheckOnServer) == 0 {
+	if len(o.idsToCheckOnServer) == 0 && len(o.includes) == 0 {
 		return nil, nil
 	}
 
@@ -30,11 +35,30 @@ func (o *LoadOperation) createRequest() (*GetDocumentsCommand, error) {
 		return nil, nil
 	}
 
+	idsToCheckOnServer := o.idsToCheckOnServer
+	if len(o.includes) > 0 {
+		idsToCheckOnServer = o.session.idsToCheckOnServerWithIncludes(o.ids)
+		if len(idsToCheckOnServer) == 0 {
+			return nil, nil
+		}
+	}
+
 	if err := o.session.incrementRequestCount(); err != nil {
 		return nil, err
 	}
 
-	return NewGetDocumentsCommand(o.idsToCheckOnServer, o.includes, false)
+	var cmd *GetDocumentsCommand
+	var err error
+	if o.includeAllCounters || len(o.countersToInclude) > 0 {
+		cmd, err = NewGetDocumentsCommandWithCounters(idsToCheckOnServer, o.includes, o.countersToInclude, o.includeAllCounters, false)
+	} else {
+		cmd, err = NewGetDocumentsCommand(idsToCheckOnServer, o.includes, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cmd._timeSeriesIncludes = o.timeSeriesToInclude
+	return cmd, nil
 }
 
 func (o *LoadOperation) byID(id string) *LoadOperation {
@@ -59,6 +83,17 @@ func (o *LoadOperation) withIncludes(includes []string) *LoadOperation {
 	return o
 }
 
+func (o *LoadOperation) withCounters(counters []string, includeAllCounters bool) *LoadOperation {
+	o.countersToInclude = counters
+	o.includeAllCounters = includeAllCounters
+	return o
+}
+
+func (o *LoadOperation) withTimeSeries(timeSeries []*TimeSeriesRange) *LoadOperation {
+	o.timeSeriesToInclude = timeSeries
+	return o
+}
+
 func (o *LoadOperation) byIds(ids []string) *LoadOperation {
 	o.ids = stringArrayCopy(ids)
 
@@ -164,4 +199,12 @@ func (o *LoadOperation) setResult(result *GetDocumentsResult) {
 	}
 
 	o.session.registerMissingIncludes(result.Results, result.Includes, o.includes)
+
+	if o.includeAllCounters || len(o.countersToInclude) > 0 {
+		o.session.registerCounters(result.CounterIncludes, o.ids, o.countersToInclude, o.includeAllCounters)
+	}
+
+	if len(o.timeSeriesToInclude) > 0 {
+		o.session.registerTimeSeries(result.TimeSeriesIncludes)
+	}
 }
@@ -0,0 +1,89 @@
+package ravendb
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// LogLevel is a severity of a log message
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// Logger receives structured logs from RequestExecutor, NodeSelector,
+// DatabaseChanges, SubscriptionWorker and BulkInsertOperation.
+// keyvals are alternating key/value pairs e.g. "node", url, "error", err.
+// Set it with DocumentStore.SetLogger or DocumentConventions.Logger.
+// It must be safe for concurrent use
+type Logger interface {
+	Log(level LogLevel, msg string, keyvals ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(level LogLevel, msg string, keyvals ...interface{}) {}
+
+type stdLogger struct {
+	logger   *log.Logger
+	minLevel LogLevel
+}
+
+// NewStdLogger returns Logger that writes messages with level of at least
+// minLevel to a standard library logger as:
+// [LEVEL] msg key1=value1 key2=value2
+func NewStdLogger(logger *log.Logger, minLevel LogLevel) Logger {
+	return &stdLogger{
+		logger:   logger,
+		minLevel: minLevel,
+	}
+}
+
+func (l *stdLogger) Log(level LogLevel, msg string, keyvals ...interface{}) {
+	if level < l.minLevel {
+		return
+	}
+	l.logger.Print(formatLogMessage(level, msg, keyvals))
+}
+
+func formatLogMessage(level LogLevel, msg string, keyvals []interface{}) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(level.String())
+	sb.WriteString("] ")
+	sb.WriteString(msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		sb.WriteString(" ")
+		sb.WriteString(fmt.Sprintf("%v", keyvals[i]))
+		sb.WriteString("=")
+		if i+1 < len(keyvals) {
+			v := fmt.Sprintf("%v", keyvals[i+1])
+			if strings.ContainsAny(v, " \t\n\"") {
+				v = fmt.Sprintf("%q", v)
+			}
+			sb.WriteString(v)
+		} else {
+			sb.WriteString("MISSING")
+		}
+	}
+	return sb.String()
+}
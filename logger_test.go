@@ -0,0 +1,143 @@
+package ravendb
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	level   LogLevel
+	msg     string
+	keyvals []interface{}
+}
+
+type capturingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *capturingLogger) Log(level LogLevel, msg string, keyvals ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level, msg, keyvals})
+}
+
+func (l *capturingLogger) find(msg string) *logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range l.entries {
+		if l.entries[i].msg == msg {
+			return &l.entries[i]
+		}
+	}
+	return nil
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0), LogLevelInfo)
+
+	logger.Log(LogLevelDebug, "not logged")
+	assert.Equal(t, "", buf.String())
+
+	logger.Log(LogLevelWarn, "failing over", "node", "http://a", "error", errors.New("connection refused"), "odd")
+	assert.Equal(t, "[WARN] failing over node=http://a error=\"connection refused\" odd=MISSING\n", buf.String())
+}
+
+func TestDocumentConventionsGetLogger(t *testing.T) {
+	var c *DocumentConventions
+	assert.Equal(t, nopLogger{}, c.GetLogger())
+
+	c = NewDocumentConventions()
+	assert.Equal(t, nopLogger{}, c.GetLogger())
+
+	logger := &capturingLogger{}
+	c.Logger = logger
+	assert.Equal(t, logger, c.Clone().GetLogger())
+}
+
+func TestRequestExecutorLogsFailover(t *testing.T) {
+	downServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	downURL := downServer.URL
+	downServer.Close()
+
+	upServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer upServer.Close()
+
+	logger := &capturingLogger{}
+	conventions := NewDocumentConventions()
+	conventions.Logger = logger
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(downURL, "db", nil, nil, conventions)
+	defer re.Close()
+	topology := &Topology{
+		Etag: -1,
+		Nodes: []*ServerNode{
+			{URL: downURL, Database: "db", ClusterTag: "A"},
+			{URL: upServer.URL, Database: "db", ClusterTag: "B"},
+		},
+	}
+	re.setNodeSelector(NewNodeSelector(topology))
+
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.NoError(t, err)
+
+	entry := logger.find("request to node failed")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, LogLevelWarn, entry.level)
+	}
+	entry = logger.find("failing over to next node")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, LogLevelWarn, entry.level)
+		assert.Equal(t, []interface{}{"command", "*ravendb.GetStatisticsCommand", "node", downURL, "nextNode", upServer.URL}, entry.keyvals[:6])
+	}
+}
+
+func TestRequestExecutorLogsCache(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headersIfNoneMatch) == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(headersEtag, `"1"`)
+		_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	logger := &capturingLogger{}
+	conventions := NewDocumentConventions()
+	conventions.Logger = logger
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, conventions)
+	defer re.Close()
+
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.NoError(t, err)
+	url := server.URL + "/databases/db/stats"
+	entry := logger.find("cache miss")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, LogLevelDebug, entry.level)
+		assert.Equal(t, []interface{}{"url", url}, entry.keyvals)
+	}
+	assert.Nil(t, logger.find("cache revalidation"))
+
+	err = re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.NoError(t, err)
+	entry = logger.find("cache revalidation")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, []interface{}{"url", url, "changeVector", "1"}, entry.keyvals)
+	}
+	entry = logger.find("cache revalidated, not modified")
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, []interface{}{"url", url}, entry.keyvals)
+	}
+}
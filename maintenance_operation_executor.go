@@ -1,6 +1,9 @@
 package ravendb
 
-import "strings"
+import (
+	"context"
+	"strings"
+)
 
 type MaintenanceOperationExecutor struct {
 	store                   *DocumentStore
@@ -46,6 +49,11 @@ func (e *MaintenanceOperationExecutor) ForDatabase(databaseName string) *Mainten
 }
 
 func (e *MaintenanceOperationExecutor) Send(operation IMaintenanceOperation) error {
+	return e.SendWithContext(context.Background(), operation)
+}
+
+// SendWithContext is like Send but is bounded by ctx
+func (e *MaintenanceOperationExecutor) SendWithContext(ctx context.Context, operation IMaintenanceOperation) error {
 	if err := e.assertDatabaseNameSet(); err != nil {
 		return err
 	}
@@ -53,10 +61,15 @@ func (e *MaintenanceOperationExecutor) Send(operation IMaintenanceOperation) err
 	if err != nil {
 		return err
 	}
-	return e.GetRequestExecutor().ExecuteCommand(command, nil)
+	return e.GetRequestExecutor().ExecuteCommandWithContext(ctx, command, nil)
 }
 
 func (e *MaintenanceOperationExecutor) SendAsync(operation IMaintenanceOperation) (*Operation, error) {
+	return e.SendAsyncWithContext(context.Background(), operation)
+}
+
+// SendAsyncWithContext is like SendAsync but starting the operation is bounded by ctx
+func (e *MaintenanceOperationExecutor) SendAsyncWithContext(ctx context.Context, operation IMaintenanceOperation) (*Operation, error) {
 	if err := e.assertDatabaseNameSet(); err != nil {
 		return nil, err
 	}
@@ -64,14 +77,17 @@ func (e *MaintenanceOperationExecutor) SendAsync(operation IMaintenanceOperation
 	if err != nil {
 		return nil, err
 	}
-	if err = e.GetRequestExecutor().ExecuteCommand(command, nil); err != nil {
+	if err = e.GetRequestExecutor().ExecuteCommandWithContext(ctx, command, nil); err != nil {
 		return nil, err
 	}
 	fn := func() *DatabaseChanges {
-		return e.store.Changes("")
+		return e.store.Changes(e.databaseName)
 	}
 	re := e.GetRequestExecutor()
-	id := getCommandOperationIDResult(command)
+	id, err := getCommandOperationIDResult(command)
+	if err != nil {
+		return nil, err
+	}
 	return NewOperation(re, fn, re.GetConventions(), id.OperationID), nil
 }
 
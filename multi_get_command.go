@@ -129,11 +129,7 @@ func (c *MultiGetCommand) maybeReadFromCache(getResponse *GetResponse, command *
 	}
 
 	cacheKey, _ := c.getCacheKey(command)
-	{
-		cacheItem, _, cachedResponse := c.cache.get(cacheKey)
-		getResponse.Result = cachedResponse
-		cacheItem.close()
-	}
+	getResponse.Result = c.cache.getNotModified(cacheKey)
 }
 
 func (c *MultiGetCommand) maybeSetCache(getResponse *GetResponse, command *getRequest) {
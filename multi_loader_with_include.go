@@ -2,6 +2,7 @@ package ravendb
 
 import (
 	"reflect"
+	"time"
 )
 
 // ILoaderWithInclude is NewMultiLoaderWithInclude
@@ -9,6 +10,10 @@ import (
 type MultiLoaderWithInclude struct {
 	session  *DocumentSession
 	includes []string
+
+	counterIncludes    []string
+	includeAllCounters bool
+	timeSeriesIncludes []*TimeSeriesRange
 }
 
 func NewMultiLoaderWithInclude(session *DocumentSession) *MultiLoaderWithInclude {
@@ -22,6 +27,35 @@ func (l *MultiLoaderWithInclude) Include(path string) *MultiLoaderWithInclude {
 	return l
 }
 
+// IncludeCounter includes the value of a given counter of loaded documents
+func (l *MultiLoaderWithInclude) IncludeCounter(name string) *MultiLoaderWithInclude {
+	l.counterIncludes = append(l.counterIncludes, name)
+	return l
+}
+
+// IncludeCounters includes values of given counters of loaded documents
+func (l *MultiLoaderWithInclude) IncludeCounters(names []string) *MultiLoaderWithInclude {
+	l.counterIncludes = append(l.counterIncludes, names...)
+	return l
+}
+
+// IncludeAllCounters includes values of all counters of loaded documents
+func (l *MultiLoaderWithInclude) IncludeAllCounters() *MultiLoaderWithInclude {
+	l.includeAllCounters = true
+	return l
+}
+
+// IncludeTimeSeries includes entries of a given time series of loaded
+// documents within [from, to]. nil from or to means that the range is not bounded
+func (l *MultiLoaderWithInclude) IncludeTimeSeries(name string, from *time.Time, to *time.Time) *MultiLoaderWithInclude {
+	l.timeSeriesIncludes = append(l.timeSeriesIncludes, &TimeSeriesRange{
+		Name: name,
+		From: from,
+		To:   to,
+	})
+	return l
+}
+
 // results should be map[string]*struct
 func (l *MultiLoaderWithInclude) LoadMulti(results interface{}, ids []string) error {
 	if len(ids) == 0 {
@@ -31,7 +65,7 @@ func (l *MultiLoaderWithInclude) LoadMulti(results interface{}, ids []string) er
 		return err
 	}
 
-	return l.session.loadInternalMulti(results, ids, l.includes)
+	return l.session.loadInternalMulti(results, ids, l.includes, l.counterIncludes, l.includeAllCounters, l.timeSeriesIncludes)
 }
 
 // TODO: needs a test
@@ -52,7 +86,7 @@ func (l *MultiLoaderWithInclude) Load(result interface{}, id string) error {
 	mapType := reflect.MapOf(stringType, rt)
 	m := reflect.MakeMap(mapType)
 	ids := []string{id}
-	err := l.session.loadInternalMulti(m.Interface(), ids, l.includes)
+	err := l.session.loadInternalMulti(m.Interface(), ids, l.includes, l.counterIncludes, l.includeAllCounters, l.timeSeriesIncludes)
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 type NodeSelector struct {
 	updateFastestNodeTimer *time.Timer
 	state                  *NodeSelectorState
+	logger                 Logger
 }
 
 // NewNodeSelector creates a new NodeSelector
@@ -18,6 +19,13 @@ func NewNodeSelector(t *Topology) *NodeSelector {
 	}
 }
 
+func (s *NodeSelector) getLogger() Logger {
+	if s.logger == nil {
+		return nopLogger{}
+	}
+	return s.logger
+}
+
 func (s *NodeSelector) getTopology() *Topology {
 	return s.state.topology
 }
@@ -28,7 +36,8 @@ func (s *NodeSelector) onFailedRequest(nodeIndex int) {
 		return // probably already changed
 	}
 
-	state.failures[nodeIndex].incrementAndGet()
+	failures := state.failures[nodeIndex].incrementAndGet()
+	s.getLogger().Log(LogLevelWarn, "request to node failed", "node", state.nodes[nodeIndex].URL, "failures", failures)
 }
 
 func (s *NodeSelector) onUpdateTopology(topology *Topology, forceUpdate bool) bool {
@@ -130,6 +139,7 @@ func (s *NodeSelector) switchToSpeedTestPhase() {
 	for i := 0; i < len(state.fastestRecords); i++ {
 		state.fastestRecords[i] = 0
 	}
+	s.getLogger().Log(LogLevelDebug, "switching to speed test phase")
 
 	state.speedTestMode.incrementAndGet()
 }
@@ -188,6 +198,7 @@ func (s *NodeSelector) findMaxIndex(state *NodeSelectorState) int {
 func (s *NodeSelector) selectFastest(state *NodeSelectorState, index int) {
 	state.fastest = index
 	state.speedTestMode.set(0)
+	s.getLogger().Log(LogLevelInfo, "selected fastest node", "node", state.nodes[index].URL)
 
 	if s.updateFastestNodeTimer != nil {
 		s.updateFastestNodeTimer.Reset(time.Minute)
@@ -0,0 +1,82 @@
+package ravendb
+
+// OngoingTaskType describes a type of an ongoing task
+type OngoingTaskType = string
+
+const (
+	OngoingTaskTypeReplication  OngoingTaskType = "Replication"
+	OngoingTaskTypeRavenEtl     OngoingTaskType = "RavenEtl"
+	OngoingTaskTypeSQLEtl       OngoingTaskType = "SqlEtl"
+	OngoingTaskTypeBackup       OngoingTaskType = "Backup"
+	OngoingTaskTypeSubscription OngoingTaskType = "Subscription"
+)
+
+// OngoingTaskState describes if an ongoing task is enabled
+type OngoingTaskState = string
+
+const (
+	OngoingTaskStateEnabled          OngoingTaskState = "Enabled"
+	OngoingTaskStateDisabled         OngoingTaskState = "Disabled"
+	OngoingTaskStatePartiallyEnabled OngoingTaskState = "PartiallyEnabled"
+)
+
+// OngoingTaskConnectionStatus describes if an ongoing task is running
+type OngoingTaskConnectionStatus = string
+
+const (
+	OngoingTaskConnectionStatusNone          OngoingTaskConnectionStatus = "None"
+	OngoingTaskConnectionStatusActive        OngoingTaskConnectionStatus = "Active"
+	OngoingTaskConnectionStatusNotActive     OngoingTaskConnectionStatus = "NotActive"
+	OngoingTaskConnectionStatusReconnect     OngoingTaskConnectionStatus = "Reconnect"
+	OngoingTaskConnectionStatusNotOnThisNode OngoingTaskConnectionStatus = "NotOnThisNode"
+)
+
+// OngoingTask describes state of an ongoing task
+type OngoingTask struct {
+	TaskID               int64                       `json:"TaskId"`
+	TaskType             OngoingTaskType             `json:"TaskType"`
+	ResponsibleNode      *NodeID                     `json:"ResponsibleNode"`
+	TaskState            OngoingTaskState            `json:"TaskState"`
+	TaskConnectionStatus OngoingTaskConnectionStatus `json:"TaskConnectionStatus"`
+	TaskName             string                      `json:"TaskName"`
+	// Error is a description of the last error of the task
+	Error      string `json:"Error"`
+	MentorNode string `json:"MentorNode"`
+}
+
+// OngoingTaskRavenEtlDetails describes state of a RavenDB ETL task
+type OngoingTaskRavenEtlDetails struct {
+	OngoingTask
+	DestinationURL        string                 `json:"DestinationUrl"`
+	DestinationDatabase   string                 `json:"DestinationDatabase"`
+	ConnectionStringName  string                 `json:"ConnectionStringName"`
+	TopologyDiscoveryUrls []string               `json:"TopologyDiscoveryUrls"`
+	Configuration         *RavenEtlConfiguration `json:"Configuration"`
+}
+
+// OngoingTaskSqlEtlDetails describes state of a SQL ETL task
+type OngoingTaskSqlEtlDetails struct {
+	OngoingTask
+	DestinationServer    string               `json:"DestinationServer"`
+	DestinationDatabase  string               `json:"DestinationDatabase"`
+	ConnectionStringName string               `json:"ConnectionStringName"`
+	Configuration        *SqlEtlConfiguration `json:"Configuration"`
+}
+
+// OngoingTaskReplication describes state of an external replication task
+type OngoingTaskReplication struct {
+	OngoingTask
+	DestinationURL        string   `json:"DestinationUrl"`
+	TopologyDiscoveryUrls []string `json:"TopologyDiscoveryUrls"`
+	DestinationDatabase   string   `json:"DestinationDatabase"`
+	ConnectionStringName  string   `json:"ConnectionStringName"`
+}
+
+// OngoingTaskBackup describes state of a periodic backup task
+type OngoingTaskBackup struct {
+	OngoingTask
+	BackupType            BackupType `json:"BackupType"`
+	BackupDestinations    []string   `json:"BackupDestinations"`
+	LastFullBackup        *Time      `json:"LastFullBackup"`
+	LastIncrementalBackup *Time      `json:"LastIncrementalBackup"`
+}
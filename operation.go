@@ -1,36 +1,69 @@
 package ravendb
 
 import (
+	"context"
 	"time"
 )
 
+const (
+	// how often status is polled when notifications are not available
+	operationPollInterval = 500 * time.Millisecond
+	// how often status is polled when notifications are available, in case
+	// a notification was missed
+	operationFallbackPollInterval = 5 * time.Second
+)
+
 // Operation describes async operation being executed on the server
 type Operation struct {
 	requestExecutor *RequestExecutor
-	//TBD private readonly Func<DatabaseChanges> _changes;
-	conventions *DocumentConventions
-	id          int64
+	changes         func() *DatabaseChanges
+	conventions     *DocumentConventions
+	id              int64
 
 	// if true, this represents ServerWideOperation
 	IsServerWide bool
 }
 
+// OperationProgress describes progress of an operation reported by the server
+type OperationProgress struct {
+	// Processed and Total are set by operations with determinate progress
+	// e.g. PatchByQueryOperation and DeleteByQueryOperation
+	Processed int64
+	Total     int64
+	// Raw is progress as sent by the server
+	Raw map[string]interface{}
+}
+
+// OperationWaitOptions configures Operation.WaitForCompletionWithOptions
+type OperationWaitOptions struct {
+	// Timeout limits how long to wait. 0 means no limit
+	Timeout time.Duration
+	// OnProgress, if set, is called when the server reports progress of
+	// the operation
+	OnProgress func(*OperationProgress)
+	// PollInterval is how often the status is fetched if notifications from
+	// DatabaseChanges are not available. 0 means 500 ms
+	PollInterval time.Duration
+}
+
 func (o *Operation) GetID() int64 {
 	return o.id
 }
 
+// NewOperation returns Operation with a given id. changes, if not nil, is used
+// to get notifications about the status of the operation instead of polling
 func NewOperation(requestExecutor *RequestExecutor, changes func() *DatabaseChanges, conventions *DocumentConventions, id int64) *Operation {
 	return &Operation{
 		requestExecutor: requestExecutor,
-		//TBD _changes = changes;
-		conventions: conventions,
-		id:          id,
+		changes:         changes,
+		conventions:     conventions,
+		id:              id,
 	}
 }
 
-func (o *Operation) fetchOperationsStatus() (map[string]interface{}, error) {
+func (o *Operation) fetchOperationsStatus(ctx context.Context) (map[string]interface{}, error) {
 	command := o.getOperationStateCommand(o.conventions, o.id)
-	err := o.requestExecutor.ExecuteCommand(command, nil)
+	err := o.requestExecutor.ExecuteCommandWithContext(ctx, command, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -52,35 +85,176 @@ func (o *Operation) getOperationStateCommand(conventions *DocumentConventions, i
 	return NewGetOperationStateCommand(o.conventions, o.id)
 }
 
+// WaitForCompletion waits until the operation completes on the server
 func (o *Operation) WaitForCompletion() error {
+	return o.WaitForCompletionWithOptions(context.Background(), nil)
+}
+
+// WaitForCompletionWithContext is like WaitForCompletion but stops waiting
+// when ctx is done. The operation itself keeps running on the server
+// (see Kill).
+func (o *Operation) WaitForCompletionWithContext(ctx context.Context) error {
+	return o.WaitForCompletionWithOptions(ctx, nil)
+}
+
+// WaitForCompletionWithOptions is like WaitForCompletionWithContext but
+// also allows to limit the time of waiting and to observe progress.
+// It's notified about status changes with DatabaseChanges.ForOperationID and
+// only polls the server if notifications are not available.
+// opts can be nil.
+func (o *Operation) WaitForCompletionWithOptions(ctx context.Context, opts *OperationWaitOptions) error {
+	if opts == nil {
+		opts = &OperationWaitOptions{}
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = operationPollInterval
+	}
+
+	// subscribing waits for confirmation from the server so it's done in
+	// the background while we poll
+	stop := make(chan struct{})
+	defer close(stop)
+	chSubscribed := o.subscribeToChanges(stop)
+	var changes *DatabaseChanges
+	var notifications <-chan *OperationStatusChange
+
+	status, err := o.fetchOperationsStatus(ctx)
 	for {
-		status, err := o.fetchOperationsStatus()
 		if err != nil {
 			return err
 		}
+		if done, statusErr := o.processStatus(status, opts.OnProgress); done {
+			return statusErr
+		}
 
-		operationStatus, ok := jsonGetAsText(status, "Status")
-		if !ok {
-			return newRavenError("missing 'Status' field in response")
+		interval := pollInterval
+		if notifications != nil && changes.GetConnectionState() == ChangesConnectionStateConnected {
+			interval = operationFallbackPollInterval
 		}
-		switch operationStatus {
-		case "Completed":
-			return nil
-		case "Cancelled":
-			return newOperationCancelledError("")
-		case "Faulted":
-			result, ok := status["Result"].(map[string]interface{})
+		timer := time.NewTimer(interval)
+		select {
+		case sub := <-chSubscribed:
+			timer.Stop()
+			chSubscribed = nil
+			changes = sub.changes
+			notifications = sub.notifications
+			defer sub.cancel()
+			// the status might have changed before we subscribed
+			status, err = o.fetchOperationsStatus(ctx)
+		case change, ok := <-notifications:
+			timer.Stop()
 			if !ok {
-				return newRavenError("status has no 'Result' object. Status: #%v", status)
+				notifications = nil
+				status, err = o.fetchOperationsStatus(ctx)
+				continue
 			}
-			var exceptionResult OperationExceptionResult
-			err = structFromJSONMap(result, &exceptionResult)
-			if err != nil {
-				return err
+			status = change.State
+		case <-timer.C:
+			status, err = o.fetchOperationsStatus(ctx)
+		case <-ctx.Done():
+			timer.Stop()
+			return newContextDoneError(ctx, "waiting for operation completion")
+		}
+	}
+}
+
+type operationChangesSubscription struct {
+	changes       *DatabaseChanges
+	notifications <-chan *OperationStatusChange
+	cancel        CancelFunc
+}
+
+// subscribeToChanges subscribes to notifications about the operation and
+// sends the subscription to returned channel. The subscription is cancelled
+// if stop is closed before it's received
+func (o *Operation) subscribeToChanges(stop chan struct{}) chan *operationChangesSubscription {
+	// server-wide operations are not reported by DatabaseChanges
+	if o.changes == nil || o.IsServerWide {
+		return nil
+	}
+	changes := o.changes()
+	if changes == nil {
+		return nil
+	}
+	res := make(chan *operationChangesSubscription)
+	go func() {
+		ch, cancel, err := changes.ForOperationIDChan(o.id, nil)
+		if err != nil {
+			// we keep polling
+			return
+		}
+		sub := &operationChangesSubscription{
+			changes:       changes,
+			notifications: ch,
+			cancel:        cancel,
+		}
+		select {
+		case res <- sub:
+		case <-stop:
+			cancel()
+		}
+	}()
+	return res
+}
+
+// processStatus returns true if the operation is no longer running and
+// the error it failed with
+func (o *Operation) processStatus(status map[string]interface{}, onProgress func(*OperationProgress)) (bool, error) {
+	operationStatus, ok := jsonGetAsText(status, "Status")
+	if !ok {
+		return true, newRavenError("missing 'Status' field in response")
+	}
+	switch operationStatus {
+	case "Completed":
+		return true, nil
+	case "Cancelled":
+		return true, newOperationCancelledError("")
+	case "Faulted":
+		result, ok := status["Result"].(map[string]interface{})
+		if !ok {
+			return true, newRavenError("status has no 'Result' object. Status: #%v", status)
+		}
+		var exceptionResult OperationExceptionResult
+		err := structFromJSONMap(result, &exceptionResult)
+		if err != nil {
+			return true, err
+		}
+		return true, exceptionDispatcherGet(exceptionResult.Message, exceptionResult.Error, exceptionResult.Type, exceptionResult.StatusCode, nil)
+	}
+
+	if onProgress != nil {
+		if progress, ok := status["Progress"].(map[string]interface{}); ok {
+			res := &OperationProgress{
+				Raw: progress,
 			}
-			return exceptionDispatcherGet(exceptionResult.Message, exceptionResult.Error, exceptionResult.Type, exceptionResult.StatusCode, nil)
+			res.Processed, _ = jsonGetAsInt64(progress, "Processed")
+			res.Total, _ = jsonGetAsInt64(progress, "Total")
+			onProgress(res)
 		}
+	}
+	return false, nil
+}
 
-		time.Sleep(500 * time.Millisecond)
+// Kill asks the server to stop the operation. Use WaitForCompletion
+// to wait until it's stopped, it'll return *OperationCancelledError
+func (o *Operation) Kill() error {
+	return o.KillWithContext(context.Background())
+}
+
+// KillWithContext is like Kill but is bounded by ctx
+func (o *Operation) KillWithContext(ctx context.Context) error {
+	if o.IsServerWide {
+		return newUnsupportedOperationError("killing server-wide operations is not supported")
+	}
+	command, err := NewKillOperationCommand(i64toa(o.id))
+	if err != nil {
+		return err
 	}
+	return o.requestExecutor.ExecuteCommandWithContext(ctx, command, nil)
 }
@@ -1,6 +1,7 @@
 package ravendb
 
 import (
+	"context"
 	"net/http"
 	"strings"
 )
@@ -37,28 +38,44 @@ func (e *OperationExecutor) ForDatabase(databaseName string) *OperationExecutor
 // command and its result
 // sessionInfo can be nil
 func (e *OperationExecutor) Send(operation IOperation, sessionInfo *SessionInfo) error {
+	return e.SendWithContext(context.Background(), operation, sessionInfo)
+}
+
+// SendWithContext is like Send but is bounded by ctx
+// sessionInfo can be nil
+func (e *OperationExecutor) SendWithContext(ctx context.Context, operation IOperation, sessionInfo *SessionInfo) error {
 	command, err := operation.GetCommand(e.store, e.requestExecutor.GetConventions(), e.requestExecutor.Cache)
 	if err != nil {
 		return err
 	}
-	return e.requestExecutor.ExecuteCommand(command, sessionInfo)
+	return e.requestExecutor.ExecuteCommandWithContext(ctx, command, sessionInfo)
 }
 
 // sessionInfo can be nil
 func (e *OperationExecutor) SendAsync(operation IOperation, sessionInfo *SessionInfo) (*Operation, error) {
+	return e.SendAsyncWithContext(context.Background(), operation, sessionInfo)
+}
+
+// SendAsyncWithContext is like SendAsync but starting the operation is bounded by ctx.
+// Use Operation.WaitForCompletionWithContext to bound waiting for its completion.
+// sessionInfo can be nil
+func (e *OperationExecutor) SendAsyncWithContext(ctx context.Context, operation IOperation, sessionInfo *SessionInfo) (*Operation, error) {
 	command, err := operation.GetCommand(e.store, e.requestExecutor.GetConventions(), e.requestExecutor.Cache)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = e.requestExecutor.ExecuteCommand(command, sessionInfo); err != nil {
+	if err = e.requestExecutor.ExecuteCommandWithContext(ctx, command, sessionInfo); err != nil {
 		return nil, err
 	}
 
 	changes := func() *DatabaseChanges {
-		return e.store.Changes("")
+		return e.store.Changes(e.databaseName)
+	}
+	result, err := getCommandOperationIDResult(command)
+	if err != nil {
+		return nil, err
 	}
-	result := getCommandOperationIDResult(command)
 
 	return NewOperation(e.requestExecutor, changes, e.requestExecutor.GetConventions(), result.OperationID), nil
 }
@@ -68,13 +85,18 @@ func (e *OperationExecutor) SendAsync(operation IOperation, sessionInfo *Session
 // public PatchStatus send(PatchOperation operation, SessionInfo sessionInfo) {
 
 func (e *OperationExecutor) SendPatchOperation(operation *PatchOperation, sessionInfo *SessionInfo) (*PatchOperationResult, error) {
+	return e.SendPatchOperationWithContext(context.Background(), operation, sessionInfo)
+}
+
+// SendPatchOperationWithContext is like SendPatchOperation but is bounded by ctx
+func (e *OperationExecutor) SendPatchOperationWithContext(ctx context.Context, operation *PatchOperation, sessionInfo *SessionInfo) (*PatchOperationResult, error) {
 	conventions := e.requestExecutor.GetConventions()
 	cache := e.requestExecutor.Cache
 	command, err := operation.GetCommand(e.store, conventions, cache)
 	if err != nil {
 		return nil, err
 	}
-	if err = e.requestExecutor.ExecuteCommand(command, sessionInfo); err != nil {
+	if err = e.requestExecutor.ExecuteCommandWithContext(ctx, command, sessionInfo); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,263 @@
+package ravendb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOperationServer serves operation state, kill requests and changes
+type fakeOperationServer struct {
+	changes *fakeChangesServer
+
+	mu       sync.Mutex
+	states   []string // returned in order, the last one is repeated
+	fetches  int
+	killedID string
+	paths    []string
+}
+
+func (s *fakeOperationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.paths = append(s.paths, r.URL.Path)
+	s.mu.Unlock()
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		s.changes.ServeHTTP(w, r)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/operations/state"):
+		state := s.states[0]
+		if len(s.states) > 1 {
+			s.states = s.states[1:]
+		}
+		s.fetches++
+		_, _ = w.Write([]byte(state))
+	case strings.HasSuffix(r.URL.Path, "/operations/kill"):
+		s.killedID = r.URL.Query().Get("id")
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasSuffix(r.URL.Path, "/admin/backup/database"):
+		_, _ = w.Write([]byte(`{"OperationId":1,"ResponsibleNode":"A"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeOperationServer) getPaths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.paths...)
+}
+
+func (s *fakeOperationServer) getFetches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func newFakeOperationServer(states ...string) (*fakeOperationServer, *httptest.Server, *RequestExecutor) {
+	server := &fakeOperationServer{
+		changes: &fakeChangesServer{
+			commands: make(chan string, 16),
+		},
+		states: states,
+	}
+	httpServer := httptest.NewServer(server)
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(httpServer.URL, "db", nil, nil, NewDocumentConventions())
+	return server, httpServer, re
+}
+
+const (
+	operationStateInProgress = `{"Status":"InProgress","Progress":{"Processed":%d,"Total":10}}`
+	operationStateCompleted  = `{"Status":"Completed","Result":{"Total":10}}`
+)
+
+func operationStateWithProgress(processed int) string {
+	return fmt.Sprintf(operationStateInProgress, processed)
+}
+
+func TestOperationWaitForCompletionPolls(t *testing.T) {
+	server, httpServer, re := newFakeOperationServer(operationStateWithProgress(3), operationStateWithProgress(7), operationStateCompleted)
+	defer httpServer.Close()
+	defer re.Close()
+
+	var processed []int64
+	opts := &OperationWaitOptions{
+		PollInterval: time.Millisecond * 10,
+		OnProgress: func(progress *OperationProgress) {
+			assert.Equal(t, int64(10), progress.Total)
+			processed = append(processed, progress.Processed)
+		},
+	}
+	operation := NewOperation(re, nil, re.GetConventions(), 1)
+	err := operation.WaitForCompletionWithOptions(context.Background(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 7}, processed)
+	assert.Equal(t, 3, server.getFetches())
+}
+
+func TestOperationWaitForCompletionUsesChanges(t *testing.T) {
+	server, httpServer, re := newFakeOperationServer(operationStateWithProgress(0))
+	defer httpServer.Close()
+	defer re.Close()
+
+	changes := newDatabaseChanges(re, "db", nil)
+	defer changes.Close()
+
+	go func() {
+		waitForString(t, server.changes.commands, "watch-operation 1")
+		for _, state := range []string{operationStateWithProgress(5), operationStateCompleted} {
+			var stateJSON map[string]interface{}
+			assert.NoError(t, jsonUnmarshal([]byte(state), &stateJSON))
+			msg := []map[string]interface{}{
+				{
+					"Type": "OperationStatusChange",
+					"Value": map[string]interface{}{
+						"OperationId": 1,
+						"State":       stateJSON,
+					},
+				},
+			}
+			// give the client time to register for changes after
+			// the server confirmed the command
+			time.Sleep(time.Millisecond * 100)
+			assert.NoError(t, server.changes.send(msg))
+		}
+	}()
+
+	progress := make(chan int64, 16)
+	opts := &OperationWaitOptions{
+		// would time out if waiting relied on polling
+		PollInterval: time.Hour,
+		Timeout:      time.Second * 10,
+		OnProgress: func(p *OperationProgress) {
+			progress <- p.Processed
+		},
+	}
+	fn := func() *DatabaseChanges {
+		return changes
+	}
+	operation := NewOperation(re, fn, re.GetConventions(), 1)
+	err := operation.WaitForCompletionWithOptions(context.Background(), opts)
+	assert.NoError(t, err)
+	// the status is fetched before and after subscribing
+	assert.Equal(t, 2, server.getFetches())
+	close(progress)
+	var got []int64
+	for p := range progress {
+		got = append(got, p)
+	}
+	assert.Equal(t, []int64{0, 0, 5}, got)
+}
+
+func TestOperationWaitForCompletionTimeout(t *testing.T) {
+	_, httpServer, re := newFakeOperationServer(operationStateWithProgress(0))
+	defer httpServer.Close()
+	defer re.Close()
+
+	opts := &OperationWaitOptions{
+		Timeout:      time.Millisecond * 50,
+		PollInterval: time.Millisecond * 10,
+	}
+	operation := NewOperation(re, nil, re.GetConventions(), 1)
+	err := operation.WaitForCompletionWithOptions(context.Background(), opts)
+	_, ok := err.(*TimeoutError)
+	assert.True(t, ok, "expected *TimeoutError, got %T", err)
+}
+
+func TestOperationWaitForCompletionFaulted(t *testing.T) {
+	faulted := `{"Status":"Faulted","Result":{"Message":"boom","Error":"System.InvalidOperationException: boom","Type":"System.InvalidOperationException","StatusCode":500}}`
+	_, httpServer, re := newFakeOperationServer(faulted)
+	defer httpServer.Close()
+	defer re.Close()
+
+	operation := NewOperation(re, nil, re.GetConventions(), 1)
+	err := operation.WaitForCompletion()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestOperationKill(t *testing.T) {
+	server, httpServer, re := newFakeOperationServer(`{"Status":"Cancelled"}`)
+	defer httpServer.Close()
+	defer re.Close()
+
+	operation := NewOperation(re, nil, re.GetConventions(), 12)
+	err := operation.Kill()
+	assert.NoError(t, err)
+	assert.Equal(t, "12", server.killedID)
+
+	err = operation.WaitForCompletion()
+	_, ok := err.(*OperationCancelledError)
+	assert.True(t, ok, "expected *OperationCancelledError, got %T", err)
+
+	operation = NewServerWideOperation(re, re.GetConventions(), 12)
+	err = operation.Kill()
+	assert.Error(t, err)
+}
+
+func TestOperationWaitForCompletionForDatabase(t *testing.T) {
+	server, httpServer, re := newFakeOperationServer(operationStateWithProgress(0), operationStateCompleted)
+	defer httpServer.Close()
+	re.Close()
+
+	// no default database
+	store := NewDocumentStore([]string{httpServer.URL}, "")
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	require.NoError(t, store.Initialize())
+	defer store.Close()
+
+	operation, err := store.Maintenance().ForDatabase("other").SendAsync(NewStartBackupOperation(true, 1))
+	require.NoError(t, err)
+	opts := &OperationWaitOptions{
+		PollInterval: time.Millisecond * 10,
+		Timeout:      time.Second * 10,
+	}
+	err = operation.WaitForCompletionWithOptions(context.Background(), opts)
+	assert.NoError(t, err)
+
+	// subscribed to changes of the database of the operation
+	waitForString(t, server.changes.commands, "watch-operation 1")
+	paths := server.getPaths()
+	assert.Contains(t, paths, "/databases/other/operations/state")
+	assert.Contains(t, paths, "/databases/other/changes")
+	for _, path := range paths {
+		assert.True(t, strings.HasPrefix(path, "/databases/other/"), "unexpected path %s", path)
+	}
+}
+
+func TestDocumentStoreChangesConcurrently(t *testing.T) {
+	_, httpServer, re := newFakeOperationServer(operationStateCompleted)
+	defer httpServer.Close()
+	re.Close()
+
+	store := NewDocumentStore([]string{httpServer.URL}, "db")
+	store.GetConventions().SetDisableTopologyUpdates(true)
+	require.NoError(t, store.Initialize())
+	defer store.Close()
+
+	// concurrent waits for operations share DatabaseChanges
+	var wg sync.WaitGroup
+	results := make([]*DatabaseChanges, 16)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = store.Changes("")
+		}(i)
+	}
+	wg.Wait()
+	for _, changes := range results {
+		assert.True(t, changes == results[0])
+	}
+}
@@ -14,6 +14,8 @@ type orderByToken struct {
 	fieldName  string
 	descending bool
 	ordering   OrderingType
+	// if not empty, results are ordered by a custom sorter
+	sorterName string
 }
 
 func newOrderByToken(fieldName string, descending bool, ordering OrderingType) *orderByToken {
@@ -57,8 +59,28 @@ func orderByTokenCreateDescending(fieldName string, ordering OrderingType) *orde
 	return newOrderByToken(fieldName, true, ordering)
 }
 
+func orderByTokenCreateAscendingWithSorter(fieldName string, sorterName string) *orderByToken {
+	res := newOrderByToken(fieldName, false, OrderingTypeString)
+	res.sorterName = sorterName
+	return res
+}
+
+func orderByTokenCreateDescendingWithSorter(fieldName string, sorterName string) *orderByToken {
+	res := newOrderByToken(fieldName, true, OrderingTypeString)
+	res.sorterName = sorterName
+	return res
+}
+
 func (t *orderByToken) writeTo(writer *strings.Builder) error {
+	if t.sorterName != "" {
+		writer.WriteString("custom(")
+	}
 	writeQueryTokenField(writer, t.fieldName)
+	if t.sorterName != "" {
+		writer.WriteString(", '")
+		writer.WriteString(strings.Replace(t.sorterName, "'", "\\'", -1))
+		writer.WriteString("')")
+	}
 
 	switch t.ordering {
 	case OrderingTypeLong:
@@ -0,0 +1,32 @@
+package ravendb
+
+// LocalBackup describes the last backup written to a local folder
+type LocalBackup struct {
+	BackupDirectory       string `json:"BackupDirectory"`
+	FileName              string `json:"FileName"`
+	TempFolderUsed        bool   `json:"TempFolderUsed"`
+	LastFullBackup        *Time  `json:"LastFullBackup"`
+	LastIncrementalBackup *Time  `json:"LastIncrementalBackup"`
+	Skipped               bool   `json:"Skipped"`
+}
+
+// BackupError describes an error of the last backup
+type BackupError struct {
+	Exception string `json:"Exception"`
+	At        *Time  `json:"At"`
+}
+
+// PeriodicBackupStatus describes the state of a periodic backup task
+type PeriodicBackupStatus struct {
+	TaskID                int64        `json:"TaskId"`
+	BackupType            BackupType   `json:"BackupType"`
+	IsFull                bool         `json:"IsFull"`
+	NodeTag               string       `json:"NodeTag"`
+	LastFullBackup        *Time        `json:"LastFullBackup"`
+	LastIncrementalBackup *Time        `json:"LastIncrementalBackup"`
+	LocalBackup           *LocalBackup `json:"LocalBackup"`
+	LastEtag              *int64       `json:"LastEtag"`
+	LastOperationID       *int64       `json:"LastOperationId"`
+	Error                 *BackupError `json:"Error"`
+	DurationInMs          *int64       `json:"DurationInMs"`
+}
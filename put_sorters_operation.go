@@ -0,0 +1,68 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &PutSortersOperation{}
+)
+
+// PutSortersOperation adds (or replaces) custom sorters of a database.
+// Sorters are used in queries with DocumentQuery.OrderByCustom. Index
+// definitions don't reference sorters, any index can be queried with them
+type PutSortersOperation struct {
+	sortersToAdd []*SorterDefinition
+
+	Command *PutSortersCommand
+}
+
+// NewPutSortersOperation returns new PutSortersOperation
+func NewPutSortersOperation(sortersToAdd ...*SorterDefinition) *PutSortersOperation {
+	return &PutSortersOperation{
+		sortersToAdd: sortersToAdd,
+	}
+}
+
+func (o *PutSortersOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if len(o.sortersToAdd) == 0 {
+		return nil, newIllegalArgumentError("sortersToAdd cannot be empty")
+	}
+	for _, sorter := range o.sortersToAdd {
+		if sorter == nil {
+			return nil, newIllegalArgumentError("sorter cannot be nil")
+		}
+		if stringIsBlank(sorter.Name) {
+			return nil, newIllegalArgumentError("Name of a sorter cannot be empty")
+		}
+	}
+	o.Command = &PutSortersCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		sortersToAdd: o.sortersToAdd,
+	}
+	o.Command.ResponseType = RavenCommandResponseTypeEmpty
+	return o.Command, nil
+}
+
+var _ RavenCommand = &PutSortersCommand{}
+
+// PutSortersCommand is a command for PutSortersOperation
+type PutSortersCommand struct {
+	RavenCommandBase
+
+	sortersToAdd []*SorterDefinition
+}
+
+func (c *PutSortersCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/sorters"
+
+	m := map[string]interface{}{
+		"Sorters": c.sortersToAdd,
+	}
+	d, err := jsonMarshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPut(url, d)
+}
@@ -18,6 +18,8 @@ type queryOperation struct {
 	fieldsToFetch           *fieldsToFetchToken
 	startTime               time.Time
 	disableEntitiesTracking bool
+	// true if query includes all counters of returned documents
+	includeAllCounters bool
 
 	// static  Log logger = LogFactory.getLog(queryOperation.class);
 }
@@ -118,6 +120,12 @@ func (o *queryOperation) complete(results interface{}) error {
 
 	if !o.disableEntitiesTracking {
 		o.session.registerIncludes(queryResult.Includes)
+		if queryResult.CounterIncludes != nil {
+			o.session.registerQueryCounters(queryResult.CounterIncludes, queryResult.IncludedCounterNames, o.includeAllCounters)
+		}
+		if queryResult.TimeSeriesIncludes != nil {
+			o.session.registerTimeSeries(queryResult.TimeSeriesIncludes)
+		}
 	}
 
 	slice, err := makeSliceForResults(results)
@@ -8,18 +8,24 @@ type QueryResult struct {
 func (r *QueryResult) createSnapshot() *QueryResult {
 	queryResult := *r
 
-	/* TBD 4.1
-	Map<String, Map<String, List<String>>> highlightings = getHighlightings();
-
-	if (highlightings != null) {
-		Map<String, Map<String, List<String>>> newHighlights = new HashMap<>();
-		for (Map.Entry<String, Map<String, List<String>>> hightlightEntry : getHighlightings().entrySet()) {
-			newHighlights.put(hightlightEntry.getKey(), new HashMap<>(hightlightEntry.getValue()));
+	if r.Highlightings != nil {
+		queryResult.Highlightings = make(map[string]map[string][]string, len(r.Highlightings))
+		for fieldName, fragments := range r.Highlightings {
+			m := make(map[string][]string, len(fragments))
+			for key, v := range fragments {
+				m[key] = v
+			}
+			queryResult.Highlightings[fieldName] = m
 		}
-		queryResult.setHighlightings(highlightings);
-	}*/
+	}
 
 	queryResult.ScoreExplanations = dupMapStringString(r.ScoreExplanations)
 	queryResult.TimingsInMs = dupMapStringFloat64(r.TimingsInMs)
+	if r.Explanations != nil {
+		queryResult.Explanations = make(map[string][]string, len(r.Explanations))
+		for key, v := range r.Explanations {
+			queryResult.Explanations[key] = v
+		}
+	}
 	return &queryResult
 }
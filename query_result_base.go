@@ -14,4 +14,11 @@ type queryResultBase struct {
 	IndexName      string                   `json:"IndexName"`
 	ResultEtag     int64                    `json:"ResultEtag"`
 	LastQueryTime  *Time                    `json:"LastQueryTime"`
+	// CounterIncludes maps document id to values of its included counters
+	CounterIncludes map[string][]*CounterDetail `json:"CounterIncludes"`
+	// IncludedCounterNames maps document id to names of included counters
+	IncludedCounterNames map[string][]string `json:"IncludedCounterNames"`
+	// TimeSeriesIncludes maps document id and time series name to
+	// included ranges
+	TimeSeriesIncludes map[string]map[string][]*TimeSeriesRangeResult `json:"TimeSeriesIncludes"`
 }
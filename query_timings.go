@@ -0,0 +1,18 @@
+package ravendb
+
+// QueryTimings describes time spent by the server in stages of a query.
+// Timings of sub-stages are in Timings, keyed by stage name e.g. "Query"
+type QueryTimings struct {
+	DurationInMs int64                    `json:"DurationInMs"`
+	Timings      map[string]*QueryTimings `json:"Timings"`
+}
+
+func (t *QueryTimings) update(queryResult *QueryResult) {
+	t.DurationInMs = 0
+	t.Timings = nil
+	if queryResult.Timings == nil {
+		return
+	}
+	t.DurationInMs = queryResult.Timings.DurationInMs
+	t.Timings = queryResult.Timings.Timings
+}
@@ -0,0 +1,122 @@
+package ravendb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryTimingsAndExplanations(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+
+	var timings *QueryTimings
+	var explanations *Explanations
+	q := session.QueryCollection("Users").
+		Search("name", "bob").
+		Include("companyId").
+		IncludeExplanations(&ExplanationOptions{GroupKey: "name"}, &explanations).
+		Timings(&timings)
+	iq, err := q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users where search(name, $p0) include companyId,explanations($p1),timings()", iq.GetQuery())
+
+	result := &QueryResult{}
+	result.Timings = &QueryTimings{
+		DurationInMs: 12,
+		Timings: map[string]*QueryTimings{
+			"Query": {DurationInMs: 10},
+		},
+	}
+	result.Explanations = map[string][]string{
+		"users/2": {"1.5 = weight(name:bob)"},
+		"users/1": {"2.5 = weight(name:bob)"},
+	}
+	q.invokeAfterQueryExecuted(result)
+	assert.Equal(t, int64(12), timings.DurationInMs)
+	assert.Equal(t, int64(10), timings.Timings["Query"].DurationInMs)
+	assert.Equal(t, []string{"users/1", "users/2"}, explanations.GetKeys())
+	assert.Equal(t, []string{"2.5 = weight(name:bob)"}, explanations.GetExplanations("users/1"))
+
+	q.invokeAfterQueryExecuted(&QueryResult{})
+	assert.Equal(t, int64(0), timings.DurationInMs)
+	assert.Nil(t, timings.Timings)
+	assert.Empty(t, explanations.GetKeys())
+
+	q = q.IncludeExplanations(nil, &explanations)
+	assert.Error(t, q.Err())
+}
+
+func TestRawQueryTimingsAndExplanations(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+
+	var timings *QueryTimings
+	var explanations *Explanations
+	q := session.RawQuery("from Users where search(name, 'bob')\n").Timings(&timings).IncludeExplanations(nil, &explanations)
+	iq, err := q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users where search(name, 'bob') include explanations(),timings()", iq.GetQuery())
+
+	q = session.RawQuery("from Users include companyId").Timings(&timings)
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users include companyId,timings()", iq.GetQuery())
+
+	q = session.RawQuery("from Users where Name = 'include'").Timings(&timings)
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users where Name = 'include' include timings()", iq.GetQuery())
+
+	// the include clause must be before limit
+	q = session.RawQuery("from Users where Name = 'a' limit 10, 5").Timings(&timings)
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users where Name = 'a' include timings() limit 10, 5", iq.GetQuery())
+
+	q = session.RawQuery("from Users include companyId\nlimit $p0 offset $p1").Timings(&timings).IncludeExplanations(nil, &explanations)
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users include companyId,explanations(),timings()\nlimit $p0 offset $p1", iq.GetQuery())
+
+	// a field named limit isn't a limit clause
+	q = session.RawQuery("from Users where limit = true // comment").Timings(&timings)
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users where limit = true include timings() // comment", iq.GetQuery())
+
+	q = session.RawQuery("from Users")
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users", iq.GetQuery())
+
+	q = session.RawQuery("from Users").Timings(nil)
+	_, ok := q.Err().(*IllegalArgumentError)
+	assert.True(t, ok, "err is %T", q.Err())
+	dq := session.QueryCollection("Users").Timings(nil)
+	_, ok = dq.Err().(*IllegalArgumentError)
+	assert.True(t, ok, "err is %T", dq.Err())
+}
+
+func TestRawQueryHasInclude(t *testing.T) {
+	tests := []struct {
+		query string
+		exp   bool
+	}{
+		{"from Users include companyId", true},
+		{"from Users\nINCLUDE companyId", true},
+		{"from Users as u select u.Name include u.CompanyId", true},
+		{"from Users where Name = 'include'", false},
+		{`from Users where Name = "include"`, false},
+		{`from Users where Name = 'it\'s include'`, false},
+		{"from Users where u.include = true", false},
+		{"from Users where Name = $include", false},
+		{"from Users where Included = true", false},
+		{"from Users as u select { Name: u.Name, include: 1 }", false},
+		{"from Users where exists(include)", false},
+		{"from Users // include\nwhere Name = 'a'", false},
+		{"from Users /* include */ where Name = 'a'", false},
+		{"from Users /* comment */ include companyId", true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.exp, rawQueryHasInclude(test.query), "query: %s", test.query)
+	}
+}
@@ -181,16 +181,32 @@ func (c *RavenCommandBase) onResponseFailure(response *http.Response) {
 // Note: hackish solution due to lack of generics
 // Returns OperationIDReuslt for commands that have it as a result
 // When new command returning OperationIDResult are added, we must extend it
-func getCommandOperationIDResult(cmd RavenCommand) *OperationIDResult {
+func getCommandOperationIDResult(cmd RavenCommand) (*OperationIDResult, error) {
+	var res *OperationIDResult
 	switch c := cmd.(type) {
 	case *CompactDatabaseCommand:
-		return c.Result
+		res = c.Result
 	case *PatchByQueryCommand:
-		return c.Result
+		res = c.Result
 	case *DeleteByIndexCommand:
-		return c.Result
+		res = c.Result
+	case *RestoreBackupCommand:
+		res = c.Result
+	case *StartBackupCommand:
+		if c.Result != nil {
+			res = &OperationIDResult{OperationID: c.Result.OperationID}
+		}
+	case *CustomCommand:
+		h, ok := c.Handler.(CustomCommandOperationIDHandler)
+		if !ok {
+			return nil, newIllegalArgumentError("handler %T of CustomCommand must implement CustomCommandOperationIDHandler to be used as async operation", c.Handler)
+		}
+		res = h.GetOperationIDResult()
+	default:
+		return nil, newIllegalArgumentError("command %T doesn't return OperationIDResult", cmd)
 	}
-
-	panicIf(true, "called on a command %T that doesn't return OperationIDResult", cmd)
-	return nil
+	if res == nil {
+		return nil, newIllegalStateError("command %T didn't return operation id", cmd)
+	}
+	return res, nil
 }
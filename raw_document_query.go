@@ -30,7 +30,28 @@ func (q *RawDocumentQuery) WaitForNonStaleResultsWithTimeout(waitTimeout time.Du
 	return q
 }
 
-//TBD 4.1  RawDocumentQuery<T> showTimings() {
+// Timings requests timings of query stages from the server. timings is set
+// to QueryTimings that are filled when the query is executed.
+// "timings()" is added to the include clause of the query
+func (q *RawDocumentQuery) Timings(timings **QueryTimings) *RawDocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.includeTimings(timings)
+	return q
+}
+
+// IncludeExplanations requests Lucene explanations of scores of results.
+// explanations is set to Explanations that are filled when the query
+// is executed. "explanations()" is added to the include clause of the query.
+// options can be nil
+func (q *RawDocumentQuery) IncludeExplanations(options *ExplanationOptions, explanations **Explanations) *RawDocumentQuery {
+	if q.err != nil {
+		return q
+	}
+	q.err = q.includeExplanations(options, explanations)
+	return q
+}
 
 func (q *RawDocumentQuery) NoTracking() *RawDocumentQuery {
 	q.noTracking()
@@ -1,6 +1,7 @@
 package ravendb
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -20,6 +21,9 @@ var (
 	// HTTPClientPostProcessor allows to tweak http client after it has been created
 	// this allows replacing Transport with a custom transport that does logging,
 	// proxying or tweaks each http request
+	//
+	// Deprecated: it applies to all stores, use DocumentStore.AddHTTPMiddleware
+	// or DocumentConventions.HTTPMiddlewares
 	HTTPClientPostProcessor func(*http.Client)
 
 	// if true, adds lots of logging to track bugs in request executor
@@ -61,6 +65,7 @@ type RequestExecutor struct {
 
 	updateTopologyTimer *time.Timer
 	nodeSelector        atomic.Value // atomic to avoid data races
+	requestObserver     atomic.Value // requestObserverHolder
 
 	NumberOfServerRequests  atomicInteger
 	TopologyEtag            int64
@@ -106,9 +111,32 @@ func (re *RequestExecutor) getNodeSelector() *NodeSelector {
 }
 
 func (re *RequestExecutor) setNodeSelector(s *NodeSelector) {
+	if s != nil {
+		s.logger = re.logger()
+	}
 	re.nodeSelector.Store(s)
 }
 
+type requestObserverHolder struct {
+	observer RequestObserver
+}
+
+// SetRequestObserver sets RequestObserver notified about every request sent
+// by this executor. nil removes the observer
+func (re *RequestExecutor) SetRequestObserver(observer RequestObserver) {
+	re.requestObserver.Store(requestObserverHolder{observer})
+}
+
+// GetRequestObserver returns RequestObserver of this executor or nil
+func (re *RequestExecutor) GetRequestObserver() RequestObserver {
+	v, _ := re.requestObserver.Load().(requestObserverHolder)
+	return v.observer
+}
+
+func (re *RequestExecutor) logger() Logger {
+	return re.conventions.GetLogger()
+}
+
 func (re *RequestExecutor) markDisposed() {
 	atomic.StoreInt32(&re.disposed, 1)
 }
@@ -165,7 +193,7 @@ func NewRequestExecutor(databaseName string, certificate *tls.Certificate, trust
 		updateDatabaseTopologySemaphore:    NewSemaphore(1),
 		updateClientConfigurationSemaphore: NewSemaphore(1),
 
-		Cache:               newHttpCache(conventions.getMaxHttpCacheSize()),
+		Cache:               newHttpCacheForConventions(conventions),
 		readBalanceBehavior: conventions.ReadBalanceBehavior,
 		databaseName:        databaseName,
 		Certificate:         certificate,
@@ -174,6 +202,7 @@ func NewRequestExecutor(databaseName string, certificate *tls.Certificate, trust
 		conventions: conventions.Clone(),
 	}
 	res.lastReturnedResponse.Store(time.Now())
+	res.SetRequestObserver(conventions.RequestObserver)
 	res.setNodeSelector(nil)
 	// TODO: handle an error
 	// TODO: java globally caches http clients
@@ -181,6 +210,11 @@ func NewRequestExecutor(databaseName string, certificate *tls.Certificate, trust
 	return res
 }
 
+// GetHTTPCacheStats returns statistics of cache of http responses
+func (re *RequestExecutor) GetHTTPCacheStats() *HTTPCacheStats {
+	return re.Cache.GetStats()
+}
+
 // GetHTTPClient returns http client for sending the requests
 func (re *RequestExecutor) GetHTTPClient() (*http.Client, error) {
 	if re.httpClient != nil {
@@ -439,6 +473,7 @@ func (re *RequestExecutor) clusterUpdateTopologyAsyncWithForceUpdate(node *Serve
 		newTopology := &Topology{
 			Nodes: nodes,
 		}
+		re.logger().Log(LogLevelInfo, "cluster topology updated", "node", node.URL, "nodes", len(nodes))
 
 		nodeSelector := re.getNodeSelector()
 		if nodeSelector == nil {
@@ -492,6 +527,7 @@ func (re *RequestExecutor) updateTopologyAsyncWithForceUpdate(node *ServerNode,
 		}
 		result := command.Result
 		dbgPrintTopology(result)
+		re.logger().Log(LogLevelInfo, "topology updated", "database", re.databaseName, "node", node.URL, "etag", result.Etag, "nodes", len(result.Nodes))
 		nodeSelector := re.getNodeSelector()
 		if nodeSelector == nil {
 			nodeSelector = NewNodeSelector(result)
@@ -525,11 +561,21 @@ func (re *RequestExecutor) disposeAllFailedNodesTimers() {
 
 // sessionInfo can be nil
 func (re *RequestExecutor) ExecuteCommand(command RavenCommand, sessionInfo *SessionInfo) error {
+	return re.ExecuteCommandWithContext(context.Background(), command, sessionInfo)
+}
+
+// ExecuteCommandWithContext is like ExecuteCommand but the request, retries
+// and failover to other nodes are bounded by ctx.
+// sessionInfo can be nil
+func (re *RequestExecutor) ExecuteCommandWithContext(ctx context.Context, command RavenCommand, sessionInfo *SessionInfo) error {
 	redbg("RequestExector.ExecuteCommand: %T\n", command)
 	if re.isDisposed() {
 		// can happen if e.g. we create BulkInsertOperation, close the store and then call Close() on BulkInsertOperation
 		return newIllegalStateError("RequestExecutor has been disposed")
 	}
+	if err := newContextDoneError(ctx, fmt.Sprintf("%T was not sent", command)); err != nil {
+		return err
+	}
 	topologyUpdate := re.firstTopologyUpdateFuture
 	isDone := topologyUpdate != nil && topologyUpdate.IsDone() && !topologyUpdate.IsCompletedExceptionally() && !topologyUpdate.isCancelled()
 	if isDone || re.disableTopologyUpdates {
@@ -537,9 +583,9 @@ func (re *RequestExecutor) ExecuteCommand(command RavenCommand, sessionInfo *Ses
 		if err != nil {
 			return err
 		}
-		return re.Execute(currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, true, sessionInfo)
+		return re.ExecuteWithContext(ctx, currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, true, sessionInfo)
 	} else {
-		return re.unlikelyExecute(command, topologyUpdate, sessionInfo)
+		return re.unlikelyExecute(ctx, command, topologyUpdate, sessionInfo)
 	}
 }
 
@@ -565,7 +611,7 @@ func (re *RequestExecutor) chooseNodeForRequest(cmd RavenCommand, sessionInfo *S
 	return nil, nil
 }
 
-func (re *RequestExecutor) unlikelyExecuteInner(command RavenCommand, topologyUpdate *completableFuture, sessionInfo *SessionInfo) (*completableFuture, error) {
+func (re *RequestExecutor) unlikelyExecuteInner(ctx context.Context, command RavenCommand, topologyUpdate *completableFuture, sessionInfo *SessionInfo) (*completableFuture, error) {
 
 	if topologyUpdate == nil {
 		re.mu.Lock()
@@ -581,13 +627,17 @@ func (re *RequestExecutor) unlikelyExecuteInner(command RavenCommand, topologyUp
 		re.mu.Unlock()
 	}
 
-	_, err := topologyUpdate.Get()
+	_, err := topologyUpdate.getWithContext(ctx)
 	return topologyUpdate, err
 }
 
-func (re *RequestExecutor) unlikelyExecute(command RavenCommand, topologyUpdate *completableFuture, sessionInfo *SessionInfo) error {
+func (re *RequestExecutor) unlikelyExecute(ctx context.Context, command RavenCommand, topologyUpdate *completableFuture, sessionInfo *SessionInfo) error {
 	var err error
-	topologyUpdate, err = re.unlikelyExecuteInner(command, topologyUpdate, sessionInfo)
+	topologyUpdate, err = re.unlikelyExecuteInner(ctx, command, topologyUpdate, sessionInfo)
+	if ctxErr := newContextDoneError(ctx, "waiting for topology update"); ctxErr != nil {
+		// topology update is still in progress, don't discard it
+		return ctxErr
+	}
 	if err != nil {
 		re.mu.Lock()
 		if re.firstTopologyUpdateFuture == topologyUpdate {
@@ -601,7 +651,7 @@ func (re *RequestExecutor) unlikelyExecute(command RavenCommand, topologyUpdate
 	if err != nil {
 		return err
 	}
-	err = re.Execute(currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, true, sessionInfo)
+	err = re.ExecuteWithContext(ctx, currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, true, sessionInfo)
 	return err
 }
 
@@ -750,16 +800,39 @@ func isNetworkTimeoutError(err error) bool {
 // Execute executes a command on a given node
 // If nodeIndex is -1, we don't know the index
 func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, command RavenCommand, shouldRetry bool, sessionInfo *SessionInfo) error {
+	return re.ExecuteWithContext(context.Background(), chosenNode, nodeIndex, command, shouldRetry, sessionInfo)
+}
+
+// ExecuteWithContext is like Execute but the request and failover to
+// other nodes are bounded by ctx
+func (re *RequestExecutor) ExecuteWithContext(ctx context.Context, chosenNode *ServerNode, nodeIndex int, command RavenCommand, shouldRetry bool, sessionInfo *SessionInfo) (err error) {
 	// nodeIndex -1 is equivalent to Java's null
-	request, err := re.createRequest(chosenNode, command)
+	request, err := re.createRequest(ctx, chosenNode, command)
 	if err != nil {
 		return err
 	}
 	urlRef := request.URL.String()
 
+	var obs *requestObservation
+	if observer := re.GetRequestObserver(); observer != nil {
+		obs = newRequestObservation(ctx, observer, re.databaseName, chosenNode, command, request)
+		ctx = obs.ctx
+		request = request.WithContext(ctx)
+		defer func() {
+			obs.end(err)
+		}()
+	}
+
 	cachedItem, cachedChangeVector, cachedValue := re.getFromCache(command, urlRef)
 	defer cachedItem.close()
 
+	if re.isCacheable(command) {
+		obs.setCacheOutcome(CacheOutcomeMiss)
+		if cachedChangeVector == nil {
+			re.logger().Log(LogLevelDebug, "cache miss", "url", urlRef)
+		}
+	}
+
 	if cachedChangeVector != nil {
 		aggressiveCacheOptions := re.aggressiveCaching
 		if aggressiveCacheOptions != nil {
@@ -767,10 +840,13 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 			if !expired &&
 				!cachedItem.getMightHaveBeenModified() &&
 				command.getBase().CanCacheAggressively {
+				re.logger().Log(LogLevelDebug, "aggressive cache hit", "url", urlRef)
+				obs.setCacheOutcome(CacheOutcomeAggressiveHit)
 				return command.setResponse(cachedValue, true)
 			}
 		}
 
+		re.logger().Log(LogLevelDebug, "cache revalidation", "url", urlRef, "changeVector", *cachedChangeVector)
 		request.Header.Set(headersIfNoneMatch, "\""+*cachedChangeVector+"\"")
 	}
 
@@ -788,12 +864,19 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 	var response *http.Response
 	re.NumberOfServerRequests.incrementAndGet()
 	if re.shouldExecuteOnAll(chosenNode, command) {
-		response, err = re.executeOnAllToFigureOutTheFastest(chosenNode, command)
+		response, err = re.executeOnAllToFigureOutTheFastest(ctx, chosenNode, command)
 	} else {
 		response, err = command.send(re.httpClient, request)
 	}
 
+	obs.setResponse(response)
 	if err != nil {
+		obs.received()
+		obs.setError(err)
+		// the node is fine, it's the caller who gave up
+		if ctxErr := newContextDoneError(ctx, fmt.Sprintf("%T failed", command)); ctxErr != nil {
+			return ctxErr
+		}
 		if !shouldRetry && isNetworkTimeoutError(err) {
 			return err
 		}
@@ -801,7 +884,7 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 		// but for us that propagates the wrong error to RequestExecutorTest_failsWhenServerIsOffline
 		urlRef = request.URL.String()
 		var ok bool
-		ok, err = re.handleServerDown(urlRef, chosenNode, nodeIndex, command, request, response, err, sessionInfo)
+		ok, err = re.handleServerDown(ctx, urlRef, chosenNode, nodeIndex, command, request, response, err, sessionInfo)
 		if err != nil {
 			return err
 		}
@@ -818,6 +901,8 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 
 	if response.StatusCode == http.StatusNotModified {
 		cachedItem.notModified()
+		re.logger().Log(LogLevelDebug, "cache revalidated, not modified", "url", urlRef)
+		obs.setCacheOutcome(CacheOutcomeNotModified)
 
 		if command.getBase().ResponseType == RavenCommandResponseTypeObject {
 			err = command.setResponse(cachedValue, true)
@@ -827,7 +912,7 @@ func (re *RequestExecutor) Execute(chosenNode *ServerNode, nodeIndex int, comman
 
 	var ok bool
 	if response.StatusCode >= 400 {
-		ok, err = re.handleUnsuccessfulResponse(chosenNode, nodeIndex, command, request, response, urlRef, sessionInfo, shouldRetry)
+		ok, err = re.handleUnsuccessfulResponse(ctx, chosenNode, nodeIndex, command, request, response, urlRef, sessionInfo, shouldRetry)
 		if err != nil {
 			return err
 		}
@@ -927,6 +1012,7 @@ func (re *RequestExecutor) throwFailedToContactAllNodes(command RavenCommand, re
 		message += "\nI was able to fetch " + re.topologyTakenFromNode.Database + " topology from " + re.topologyTakenFromNode.URL + ".\n" + "Fetched topology: " + nodesStr
 	}
 
+	re.logger().Log(LogLevelError, "failed to contact all nodes", "command", commandName, "url", request.URL.String(), "error", e)
 	return newAllTopologyNodesDownError("%s", message)
 }
 
@@ -954,7 +1040,7 @@ type responseAndError struct {
 	err      error
 }
 
-func (re *RequestExecutor) executeOnAllToFigureOutTheFastest(chosenNode *ServerNode, command RavenCommand) (*http.Response, error) {
+func (re *RequestExecutor) executeOnAllToFigureOutTheFastest(ctx context.Context, chosenNode *ServerNode, command RavenCommand) (*http.Response, error) {
 	// note: implementation is intentionally different than Java
 
 	var fastestWasRecorded int32 // atomic
@@ -973,7 +1059,7 @@ func (re *RequestExecutor) executeOnAllToFigureOutTheFastest(chosenNode *ServerN
 
 		go func(nodeIndex int, node *ServerNode) {
 			var response *http.Response
-			request, err := re.createRequest(node, command)
+			request, err := re.createRequest(ctx, node, command)
 			if err == nil {
 				response, err = command.send(re.httpClient, request)
 				n := atomic.AddInt32(&fastestWasRecorded, 1)
@@ -1003,28 +1089,38 @@ func (re *RequestExecutor) executeOnAllToFigureOutTheFastest(chosenNode *ServerN
 		return ret.response, ret.err
 	case <-time.After(time.Second * 15):
 		return nil, fmt.Errorf("request timed out")
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 }
 
-func (re *RequestExecutor) getFromCache(command RavenCommand, url string) (*releaseCacheItem, *string, []byte) {
+func (re *RequestExecutor) isCacheable(command RavenCommand) bool {
 	cmd := command.getBase()
-	if cmd.CanCache && cmd.IsReadRequest && cmd.ResponseType == RavenCommandResponseTypeObject {
+	return cmd.CanCache && cmd.IsReadRequest && cmd.ResponseType == RavenCommandResponseTypeObject
+}
+
+func (re *RequestExecutor) getFromCache(command RavenCommand, url string) (*releaseCacheItem, *string, []byte) {
+	if re.isCacheable(command) {
 		return re.Cache.get(url)
 	}
 
 	return newReleaseCacheItem(nil), nil, nil
 }
 
-func (re *RequestExecutor) createRequest(node *ServerNode, command RavenCommand) (*http.Request, error) {
+func (re *RequestExecutor) createRequest(ctx context.Context, node *ServerNode, command RavenCommand) (*http.Request, error) {
 	request, err := command.createRequest(node)
 	if err != nil {
 		return nil, err
 	}
+	request = request.WithContext(ctx)
 	request.Header.Set(headersClientVersion, goClientVersion)
+	if traceParent := traceParentFromContext(ctx); traceParent != "" {
+		request.Header.Set(headersTraceParent, traceParent)
+	}
 	return request, err
 }
 
-func (re *RequestExecutor) handleUnsuccessfulResponse(chosenNode *ServerNode, nodeIndex int, command RavenCommand, request *http.Request, response *http.Response, url string, sessionInfo *SessionInfo, shouldRetry bool) (bool, error) {
+func (re *RequestExecutor) handleUnsuccessfulResponse(ctx context.Context, chosenNode *ServerNode, nodeIndex int, command RavenCommand, request *http.Request, response *http.Response, url string, sessionInfo *SessionInfo, shouldRetry bool) (bool, error) {
 	var err error
 	switch response.StatusCode {
 	case http.StatusNotFound:
@@ -1048,7 +1144,12 @@ func (re *RequestExecutor) handleUnsuccessfulResponse(chosenNode *ServerNode, no
 		}
 
 		updateFuture := re.updateTopologyAsyncWithForceUpdate(chosenNode, int(math.MaxInt32), true)
-		result := <-updateFuture
+		var result *clusterUpdateAsyncResult
+		select {
+		case result = <-updateFuture:
+		case <-ctx.Done():
+			return false, newContextDoneError(ctx, "waiting for topology update")
+		}
 		if result.Err != nil {
 			return false, result.Err
 		}
@@ -1058,11 +1159,11 @@ func (re *RequestExecutor) handleUnsuccessfulResponse(chosenNode *ServerNode, no
 		if err != nil {
 			return false, err
 		}
-		err = re.Execute(currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, false, sessionInfo)
+		err = re.ExecuteWithContext(ctx, currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, false, sessionInfo)
 		return false, err
 	case http.StatusGatewayTimeout, http.StatusRequestTimeout,
 		http.StatusBadGateway, http.StatusServiceUnavailable:
-		ok, err := re.handleServerDown(url, chosenNode, nodeIndex, command, request, response, nil, sessionInfo)
+		ok, err := re.handleServerDown(ctx, url, chosenNode, nodeIndex, command, request, response, nil, sessionInfo)
 		return ok, err
 	case http.StatusConflict:
 		err = requestExecutorHandleConflict(response)
@@ -1077,7 +1178,7 @@ func requestExecutorHandleConflict(response *http.Response) error {
 	return exceptionDispatcherThrowError(response)
 }
 
-func (re *RequestExecutor) handleServerDown(url string, chosenNode *ServerNode, nodeIndex int, command RavenCommand, request *http.Request, response *http.Response, e error, sessionInfo *SessionInfo) (bool, error) {
+func (re *RequestExecutor) handleServerDown(ctx context.Context, url string, chosenNode *ServerNode, nodeIndex int, command RavenCommand, request *http.Request, response *http.Response, e error, sessionInfo *SessionInfo) (bool, error) {
 	if command.getBase().FailedNodes == nil {
 		command.getBase().FailedNodes = map[*ServerNode]error{}
 	}
@@ -1108,7 +1209,14 @@ func (re *RequestExecutor) handleServerDown(url string, chosenNode *ServerNode,
 		return false, nil
 	}
 
-	err = re.Execute(currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, false, sessionInfo)
+	// don't fail over to the next node if the caller is no longer waiting
+	if err = newContextDoneError(ctx, fmt.Sprintf("%T was not retried on %s", command, currentIndexAndNode.currentNode.URL)); err != nil {
+		return false, err
+	}
+
+	re.logger().Log(LogLevelWarn, "failing over to next node", "command", fmt.Sprintf("%T", command), "node", chosenNode.URL, "nextNode", currentIndexAndNode.currentNode.URL, "error", e)
+
+	err = re.ExecuteWithContext(ctx, currentIndexAndNode.currentNode, currentIndexAndNode.currentIndex, command, false, sessionInfo)
 	if err != nil {
 		return false, err
 	}
@@ -1142,7 +1250,7 @@ func (re *RequestExecutor) checkNodeStatusCallback(nodeStatus *NodeStatus) {
 
 	err := re.performHealthCheck(serverNode, idx)
 	if err != nil {
-		// TODO: logging
+		re.logger().Log(LogLevelDebug, "health check failed", "node", serverNode.URL, "error", err)
 		status := re.getFailedNodeTimer(nodeStatus.node)
 		if status != nil {
 			status.updateTimer()
@@ -1157,6 +1265,7 @@ func (re *RequestExecutor) checkNodeStatusCallback(nodeStatus *NodeStatus) {
 		status.Close()
 	}
 
+	re.logger().Log(LogLevelInfo, "node is reachable again", "node", serverNode.URL)
 	nodeSelector := re.getNodeSelector()
 	if nodeSelector != nil {
 		nodeSelector.restoreNodeIndex(idx)
@@ -1270,6 +1379,12 @@ func (re *RequestExecutor) createClient() (*http.Client, error) {
 	if HTTPClientPostProcessor != nil {
 		HTTPClientPostProcessor(client)
 	}
+	if client.Transport == nil {
+		// http.Client uses http.DefaultTransport for nil Transport but
+		// middlewares call next directly
+		client.Transport = http.DefaultTransport
+	}
+	client.Transport = chainHTTPMiddlewares(client.Transport, re.conventions.HTTPMiddlewares)
 	return client, nil
 }
 
@@ -0,0 +1,61 @@
+package ravendb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteCommandWithContextDeadline(t *testing.T) {
+	var nRequests int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nRequests, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	cmd := NewGetStatisticsCommand("")
+	err := re.ExecuteCommandWithContext(ctx, cmd, nil)
+	assert.Error(t, err)
+	_, ok := err.(*TimeoutError)
+	assert.True(t, ok, "err is %T", err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	// the node must not be marked as failed and the request must not be retried
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nRequests))
+	assert.Empty(t, cmd.FailedNodes)
+}
+
+func TestExecuteCommandWithContextCancelled(t *testing.T) {
+	var nRequests int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&nRequests, 1)
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, nil)
+	defer re.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := re.ExecuteCommandWithContext(ctx, NewGetStatisticsCommand(""), nil)
+	_, ok := err.(*OperationCancelledError)
+	assert.True(t, ok, "err is %T", err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&nRequests))
+}
@@ -0,0 +1,188 @@
+package ravendb
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// CacheOutcome describes how http cache was used for a request
+type CacheOutcome = string
+
+const (
+	// CacheOutcomeNone is for requests that can't be cached
+	CacheOutcomeNone CacheOutcome = "None"
+	// CacheOutcomeMiss is for requests that were sent to the server
+	// because there was no (valid) cached response
+	CacheOutcomeMiss CacheOutcome = "Miss"
+	// CacheOutcomeNotModified is for requests for which the server
+	// confirmed that cached response is still valid
+	CacheOutcomeNotModified CacheOutcome = "NotModified"
+	// CacheOutcomeAggressiveHit is for requests served from cache
+	// without contacting the server
+	CacheOutcomeAggressiveHit CacheOutcome = "AggressiveHit"
+)
+
+// RequestInfo describes a request sent by RequestExecutor
+type RequestInfo struct {
+	// Command is a name of the command e.g. "GetDocumentsCommand"
+	Command  string
+	Database string
+	NodeTag  string
+	NodeURL  string
+	Method   string
+	URL      string
+	// Retry is 0 for the first attempt and is incremented every time
+	// the command fails over to another node
+	Retry int
+	// Header are headers of the outgoing request. Observers can add
+	// headers e.g. to propagate a trace context
+	Header http.Header
+}
+
+// RequestResult describes the outcome of a request sent by RequestExecutor
+type RequestResult struct {
+	// StatusCode is 0 if there was no response from the server
+	StatusCode int
+	// Duration is the time until the response (or error) was received
+	// from the node. It doesn't include time spent failing over
+	Duration     time.Duration
+	CacheOutcome CacheOutcome
+	// RequestSize and ResponseSize are -1 if not known
+	RequestSize  int64
+	ResponseSize int64
+	Err          error
+}
+
+// RequestObserver is notified about every request sent by RequestExecutor.
+// It can be used to collect metrics and create tracing spans.
+// It must be safe for concurrent use
+type RequestObserver interface {
+	// OnRequestStart is called before sending the request. Returned context
+	// is passed to OnRequestEnd and used for the request
+	OnRequestStart(ctx context.Context, info *RequestInfo) context.Context
+	// OnRequestEnd is called after the response was processed or the
+	// request has failed. If the request failed over to another node,
+	// OnRequestEnd for the retry is called before OnRequestEnd of the
+	// failed request
+	OnRequestEnd(ctx context.Context, info *RequestInfo, result *RequestResult)
+}
+
+type multiRequestObserver []RequestObserver
+
+// NewMultiRequestObserver returns RequestObserver that notifies all observers
+func NewMultiRequestObserver(observers ...RequestObserver) RequestObserver {
+	return multiRequestObserver(observers)
+}
+
+func (m multiRequestObserver) OnRequestStart(ctx context.Context, info *RequestInfo) context.Context {
+	for _, o := range m {
+		ctx = o.OnRequestStart(ctx, info)
+	}
+	return ctx
+}
+
+func (m multiRequestObserver) OnRequestEnd(ctx context.Context, info *RequestInfo, result *RequestResult) {
+	for i := len(m) - 1; i >= 0; i-- {
+		m[i].OnRequestEnd(ctx, info, result)
+	}
+}
+
+type traceParentKey struct{}
+
+// WithTraceParent returns a context that makes RequestExecutor send
+// traceparent header (https://www.w3.org/TR/trace-context/) with requests
+// executed with this context
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	return context.WithValue(ctx, traceParentKey{}, traceParent)
+}
+
+func traceParentFromContext(ctx context.Context) string {
+	s, _ := ctx.Value(traceParentKey{}).(string)
+	return s
+}
+
+// requestObservation tracks a single request for RequestObserver
+type requestObservation struct {
+	observer RequestObserver
+	ctx      context.Context
+	info     *RequestInfo
+	start    time.Time
+	result   RequestResult
+}
+
+func commandName(command RavenCommand) string {
+	t := reflect.TypeOf(command)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func newRequestObservation(ctx context.Context, observer RequestObserver, databaseName string, node *ServerNode, command RavenCommand, request *http.Request) *requestObservation {
+	info := &RequestInfo{
+		Command:  commandName(command),
+		Database: databaseName,
+		NodeTag:  node.ClusterTag,
+		NodeURL:  node.URL,
+		Method:   request.Method,
+		URL:      request.URL.String(),
+		Retry:    len(command.getBase().FailedNodes),
+		Header:   request.Header,
+	}
+	res := &requestObservation{
+		observer: observer,
+		info:     info,
+		start:    time.Now(),
+		result: RequestResult{
+			CacheOutcome: CacheOutcomeNone,
+			RequestSize:  request.ContentLength,
+			ResponseSize: -1,
+		},
+	}
+	res.ctx = observer.OnRequestStart(ctx, info)
+	if res.ctx == nil {
+		res.ctx = ctx
+	}
+	return res
+}
+
+// received marks the time the node has replied or failed
+func (o *requestObservation) received() {
+	if o != nil && o.result.Duration == 0 {
+		o.result.Duration = time.Since(o.start)
+	}
+}
+
+func (o *requestObservation) setResponse(response *http.Response) {
+	if o == nil || response == nil {
+		return
+	}
+	o.received()
+	o.result.StatusCode = response.StatusCode
+	o.result.ResponseSize = response.ContentLength
+}
+
+func (o *requestObservation) setCacheOutcome(outcome CacheOutcome) {
+	if o != nil {
+		o.result.CacheOutcome = outcome
+	}
+}
+
+// setError records an error of this attempt that might be hidden
+// by a successful failover to another node
+func (o *requestObservation) setError(err error) {
+	if o != nil && o.result.Err == nil {
+		o.result.Err = err
+	}
+}
+
+func (o *requestObservation) end(err error) {
+	if o == nil {
+		return
+	}
+	o.setError(err)
+	o.received()
+	o.observer.OnRequestEnd(o.ctx, o.info, &o.result)
+}
@@ -0,0 +1,126 @@
+package ravendb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type observedRequest struct {
+	info   RequestInfo
+	result RequestResult
+	ctx    context.Context
+}
+
+type recordingObserver struct {
+	mu       sync.Mutex
+	requests []observedRequest
+}
+
+type observerCtxKey struct{}
+
+func (o *recordingObserver) OnRequestStart(ctx context.Context, info *RequestInfo) context.Context {
+	info.Header.Set("X-Span", info.Command)
+	return context.WithValue(ctx, observerCtxKey{}, info.NodeTag)
+}
+
+func (o *recordingObserver) OnRequestEnd(ctx context.Context, info *RequestInfo, result *RequestResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.requests = append(o.requests, observedRequest{*info, *result, ctx})
+}
+
+func TestRequestObserverCacheOutcomeAndHeaders(t *testing.T) {
+	var headers []http.Header
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		if r.Header.Get(headersIfNoneMatch) == `"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(headersEtag, `"1"`)
+		_, _ = w.Write([]byte(`{"CountOfDocuments":1}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	conventions := NewDocumentConventions()
+	conventions.RequestObserver = observer
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(server.URL, "db", nil, nil, conventions)
+	defer re.Close()
+
+	ctx := WithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	for i := 0; i < 2; i++ {
+		cmd := NewGetStatisticsCommand("")
+		err := re.ExecuteCommandWithContext(ctx, cmd, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), cmd.Result.CountOfDocuments)
+	}
+
+	assert.Equal(t, 2, len(observer.requests))
+	first, second := observer.requests[0], observer.requests[1]
+	assert.Equal(t, "GetStatisticsCommand", first.info.Command)
+	assert.Equal(t, "db", first.info.Database)
+	assert.Equal(t, 0, first.info.Retry)
+	assert.Equal(t, http.StatusOK, first.result.StatusCode)
+	assert.Equal(t, CacheOutcomeMiss, first.result.CacheOutcome)
+	assert.NoError(t, first.result.Err)
+	assert.True(t, first.result.Duration > 0)
+	assert.Equal(t, http.StatusNotModified, second.result.StatusCode)
+	assert.Equal(t, CacheOutcomeNotModified, second.result.CacheOutcome)
+	assert.NotNil(t, second.ctx.Value(observerCtxKey{}))
+
+	for _, h := range headers {
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", h.Get("traceparent"))
+		assert.Equal(t, "GetStatisticsCommand", h.Get("X-Span"))
+	}
+
+	re.SetRequestObserver(nil)
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(observer.requests))
+}
+
+func TestRequestObserverFailover(t *testing.T) {
+	downServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	downURL := downServer.URL
+	downServer.Close()
+
+	upServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer upServer.Close()
+
+	re := RequestExecutorCreateForSingleNodeWithoutConfigurationUpdates(downURL, "db", nil, nil, nil)
+	defer re.Close()
+	observer := &recordingObserver{}
+	re.SetRequestObserver(NewMultiRequestObserver(observer))
+	topology := &Topology{
+		Etag: -1,
+		Nodes: []*ServerNode{
+			{URL: downURL, Database: "db", ClusterTag: "A"},
+			{URL: upServer.URL, Database: "db", ClusterTag: "B"},
+		},
+	}
+	re.setNodeSelector(NewNodeSelector(topology))
+
+	err := re.ExecuteCommand(NewGetStatisticsCommand(""), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(observer.requests))
+	// the retry finishes first
+	retry, failed := observer.requests[0], observer.requests[1]
+	assert.Equal(t, "B", retry.info.NodeTag)
+	assert.Equal(t, 1, retry.info.Retry)
+	assert.Equal(t, http.StatusOK, retry.result.StatusCode)
+	assert.NoError(t, retry.result.Err)
+	assert.Equal(t, "A", failed.info.NodeTag)
+	assert.Equal(t, 0, failed.info.Retry)
+	assert.Equal(t, 0, failed.result.StatusCode)
+	assert.Error(t, failed.result.Err)
+}
@@ -0,0 +1,58 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &ResetEtlOperation{}
+)
+
+// ResetEtlOperation resets state of a transformation of an ETL task so that
+// all documents are processed again
+type ResetEtlOperation struct {
+	configurationName  string
+	transformationName string
+
+	Command *ResetEtlCommand
+}
+
+// NewResetEtlOperation returns new ResetEtlOperation
+func NewResetEtlOperation(configurationName string, transformationName string) *ResetEtlOperation {
+	return &ResetEtlOperation{
+		configurationName:  configurationName,
+		transformationName: transformationName,
+	}
+}
+
+func (o *ResetEtlOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if stringIsBlank(o.configurationName) {
+		return nil, newIllegalArgumentError("configurationName cannot be empty")
+	}
+	if stringIsBlank(o.transformationName) {
+		return nil, newIllegalArgumentError("transformationName cannot be empty")
+	}
+	o.Command = &ResetEtlCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configurationName:  o.configurationName,
+		transformationName: o.transformationName,
+	}
+	o.Command.ResponseType = RavenCommandResponseTypeEmpty
+	return o.Command, nil
+}
+
+var _ RavenCommand = &ResetEtlCommand{}
+
+// ResetEtlCommand is a command for ResetEtlOperation
+type ResetEtlCommand struct {
+	RavenCommandBase
+
+	configurationName  string
+	transformationName string
+}
+
+func (c *ResetEtlCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/etl?configurationName=" + urlUtilsEscapeDataString(c.configurationName) + "&transformationName=" + urlUtilsEscapeDataString(c.transformationName)
+	return newHttpReset(url)
+}
@@ -0,0 +1,71 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IServerOperation = &RestoreBackupOperation{}
+)
+
+// RestoreBackupOperation restores a backup into a new database.
+// Use ServerOperationExecutor.SendAsync to get an Operation that can
+// be waited on
+type RestoreBackupOperation struct {
+	configuration *RestoreBackupConfiguration
+
+	Command *RestoreBackupCommand
+}
+
+// NewRestoreBackupOperation returns new RestoreBackupOperation
+func NewRestoreBackupOperation(configuration *RestoreBackupConfiguration) *RestoreBackupOperation {
+	return &RestoreBackupOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *RestoreBackupOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if o.configuration == nil {
+		return nil, newIllegalArgumentError("Configuration cannot be nil")
+	}
+	if stringIsBlank(o.configuration.DatabaseName) {
+		return nil, newIllegalArgumentError("DatabaseName cannot be empty")
+	}
+	if stringIsBlank(o.configuration.BackupLocation) {
+		return nil, newIllegalArgumentError("BackupLocation cannot be empty")
+	}
+	o.Command = &RestoreBackupCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: o.configuration,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &RestoreBackupCommand{}
+
+// RestoreBackupCommand is a command for RestoreBackupOperation
+type RestoreBackupCommand struct {
+	RavenCommandBase
+
+	configuration *RestoreBackupConfiguration
+
+	Result *OperationIDResult
+}
+
+func (c *RestoreBackupCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/admin/restore/database"
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPost(url, d)
+}
+
+func (c *RestoreBackupCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -21,3 +21,9 @@ func NewServerNode() *ServerNode {
 		ServerRole: ServerNodeRoleNone,
 	}
 }
+
+// GetDatabaseURL returns url of an endpoint of node's database e.g. for
+// path "/stats" it returns "${URL}/databases/${Database}/stats"
+func (n *ServerNode) GetDatabaseURL(path string) string {
+	return n.URL + "/databases/" + n.Database + path
+}
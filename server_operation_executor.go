@@ -1,5 +1,7 @@
 package ravendb
 
+import "context"
+
 type ServerOperationExecutor struct {
 	requestExecutor *ClusterRequestExecutor
 }
@@ -23,23 +25,36 @@ func NewServerOperationExecutor(store *DocumentStore) *ServerOperationExecutor {
 }
 
 func (e *ServerOperationExecutor) Send(operation IServerOperation) error {
+	return e.SendWithContext(context.Background(), operation)
+}
+
+// SendWithContext is like Send but is bounded by ctx
+func (e *ServerOperationExecutor) SendWithContext(ctx context.Context, operation IServerOperation) error {
 	command, err := operation.GetCommand(e.requestExecutor.GetConventions())
 	if err != nil {
 		return err
 	}
-	return e.requestExecutor.ExecuteCommand(command, nil)
+	return e.requestExecutor.ExecuteCommandWithContext(ctx, command, nil)
 }
 
 func (e *ServerOperationExecutor) SendAsync(operation IServerOperation) (*Operation, error) {
+	return e.SendAsyncWithContext(context.Background(), operation)
+}
+
+// SendAsyncWithContext is like SendAsync but starting the operation is bounded by ctx
+func (e *ServerOperationExecutor) SendAsyncWithContext(ctx context.Context, operation IServerOperation) (*Operation, error) {
 	requestExecutor := e.requestExecutor
 	command, err := operation.GetCommand(requestExecutor.GetConventions())
 	if err != nil {
 		return nil, err
 	}
-	if err = requestExecutor.ExecuteCommand(command, nil); err != nil {
+	if err = requestExecutor.ExecuteCommandWithContext(ctx, command, nil); err != nil {
+		return nil, err
+	}
+	result, err := getCommandOperationIDResult(command)
+	if err != nil {
 		return nil, err
 	}
-	result := getCommandOperationIDResult(command)
 	return NewServerWideOperation(requestExecutor, requestExecutor.GetConventions(), result.OperationID), nil
 }
 
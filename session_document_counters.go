@@ -0,0 +1,335 @@
+package ravendb
+
+import (
+	"strings"
+)
+
+// countersCacheEntry holds values of counters of a document known to the session
+type countersCacheEntry struct {
+	// true if values has all counters of the document
+	gotAll bool
+	// nil value means that the counter doesn't exist
+	values map[string]*int64
+}
+
+func newCountersCacheEntry() *countersCacheEntry {
+	return &countersCacheEntry{
+		values: map[string]*int64{},
+	}
+}
+
+// counter names are case insensitive
+func (e *countersCacheEntry) findName(counter string) (string, bool) {
+	if _, ok := e.values[counter]; ok {
+		return counter, true
+	}
+	for name := range e.values {
+		if strings.EqualFold(name, counter) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (e *countersCacheEntry) get(counter string) (*int64, bool) {
+	name, ok := e.findName(counter)
+	if !ok {
+		return nil, false
+	}
+	return e.values[name], true
+}
+
+func (e *countersCacheEntry) set(counter string, value *int64) {
+	if name, ok := e.findName(counter); ok {
+		delete(e.values, name)
+	}
+	e.values[counter] = value
+}
+
+func (e *countersCacheEntry) remove(counter string) {
+	if name, ok := e.findName(counter); ok {
+		delete(e.values, name)
+	}
+}
+
+// SessionDocumentCounters gives access to counters of a document in a session.
+// Increment and Delete are sent to the server on SaveChanges.
+// Values are cached in the session and only fetched from the server if needed
+type SessionDocumentCounters struct {
+	session *InMemoryDocumentSessionOperations
+	docID   string
+}
+
+func newSessionDocumentCounters(session *InMemoryDocumentSessionOperations, docID string) *SessionDocumentCounters {
+	return &SessionDocumentCounters{
+		session: session,
+		docID:   docID,
+	}
+}
+
+// CountersFor returns counters of a given entity, which must be tracked by the session
+func (s *DocumentSession) CountersFor(entity interface{}) (*SessionDocumentCounters, error) {
+	if err := checkValidEntityIn(entity, "entity"); err != nil {
+		return nil, err
+	}
+	document := getDocumentInfoByEntity(s.documentsByEntity, entity)
+	if document == nil {
+		return nil, throwEntityNotInSession(entity)
+	}
+	return newSessionDocumentCounters(s.InMemoryDocumentSessionOperations, document.id), nil
+}
+
+// CountersForDocumentID returns counters of a document with a given id
+func (s *DocumentSession) CountersForDocumentID(docID string) (*SessionDocumentCounters, error) {
+	if stringIsBlank(docID) {
+		return nil, newIllegalArgumentError("docID cannot be empty")
+	}
+	return newSessionDocumentCounters(s.InMemoryDocumentSessionOperations, docID), nil
+}
+
+// GetDocumentID returns id of the document whose counters are accessed
+func (c *SessionDocumentCounters) GetDocumentID() string {
+	return c.docID
+}
+
+func (c *SessionDocumentCounters) isDocumentDeletedInSession() bool {
+	documentInfo := c.session.documentsByID.getValue(c.docID)
+	return documentInfo != nil && c.session.deletedEntities.contains(documentInfo.entity)
+}
+
+func (c *SessionDocumentCounters) getDeferredCommand() *CountersBatchCommandData {
+	key := newIDTypeAndName(c.docID, CommandCounters, "")
+	command, ok := c.session.deferredCommandsMap[key]
+	if !ok {
+		return nil
+	}
+	return command.(*CountersBatchCommandData)
+}
+
+func (c *SessionDocumentCounters) deferOperation(op *CounterOperation) error {
+	command, err := NewCountersBatchCommandData(c.docID, op)
+	if err != nil {
+		return err
+	}
+	c.session.Defer(command)
+	return nil
+}
+
+// Increment increments a counter by delta (which can be negative) on SaveChanges.
+// The counter is created if it doesn't exist
+func (c *SessionDocumentCounters) Increment(counter string, delta int64) error {
+	if stringIsBlank(counter) {
+		return newIllegalArgumentError("counter cannot be empty")
+	}
+
+	if c.isDocumentDeletedInSession() {
+		return newIllegalStateError("Can't increment counter %s of document %s, the document was already deleted in this session", counter, c.docID)
+	}
+
+	op := &CounterOperation{
+		Type:        CounterOperationTypeIncrement,
+		CounterName: counter,
+		Delta:       delta,
+	}
+
+	command := c.getDeferredCommand()
+	if command == nil {
+		return c.deferOperation(op)
+	}
+	if command.HasDelete(counter) {
+		return newIllegalStateError("Can't increment counter %s of document %s, there is a deferred command registered to delete a counter with the same name", counter, c.docID)
+	}
+	command.Counters.Operations = append(command.Counters.Operations, op)
+	return nil
+}
+
+// Delete deletes a counter on SaveChanges
+func (c *SessionDocumentCounters) Delete(counter string) error {
+	if stringIsBlank(counter) {
+		return newIllegalArgumentError("counter cannot be empty")
+	}
+
+	key := newIDTypeAndName(c.docID, CommandDelete, "")
+	if _, ok := c.session.deferredCommandsMap[key]; ok {
+		// the document will be deleted together with its counters
+		return nil
+	}
+	if c.isDocumentDeletedInSession() {
+		return nil
+	}
+
+	op := &CounterOperation{
+		Type:        CounterOperationTypeDelete,
+		CounterName: counter,
+	}
+
+	command := c.getDeferredCommand()
+	if command == nil {
+		if err := c.deferOperation(op); err != nil {
+			return err
+		}
+	} else {
+		if command.HasIncrement(counter) {
+			return newIllegalStateError("Can't delete counter %s of document %s, there is a deferred command registered to increment a counter with the same name", counter, c.docID)
+		}
+		command.Counters.Operations = append(command.Counters.Operations, op)
+	}
+
+	if cache := c.session.getCountersCacheEntry(c.docID); cache != nil {
+		cache.remove(counter)
+	}
+	return nil
+}
+
+// returns true if we have to ask the server for the value of a counter
+// not present in the cache
+func (c *SessionDocumentCounters) shouldFetch(cache *countersCacheEntry, counter string) bool {
+	document := c.session.documentsByID.getValue(c.docID)
+	if document == nil {
+		return !cache.gotAll
+	}
+	// we know from the metadata which counters the document has
+	for _, name := range getMetadataCounters(document.metadata) {
+		if strings.EqualFold(name, counter) {
+			return true
+		}
+	}
+	return false
+}
+
+func getMetadataCounters(metadata map[string]interface{}) []string {
+	v, ok := metadata[MetadataCounters]
+	if !ok {
+		return nil
+	}
+	a, _ := v.([]interface{})
+	var res []string
+	for _, el := range a {
+		if s, ok := el.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func (c *SessionDocumentCounters) fetch(counters ...string) (*CountersDetail, error) {
+	if err := c.session.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	op := NewGetCountersOperation(c.docID, counters...)
+	err := c.session.GetOperations().SendWithContext(c.session.ctx, op, c.session.sessionInfo)
+	if err != nil {
+		return nil, err
+	}
+	return op.Command.Result, nil
+}
+
+// Get returns the value of a counter or nil if it doesn't exist
+func (c *SessionDocumentCounters) Get(counter string) (*int64, error) {
+	values, err := c.GetMulti([]string{counter})
+	if err != nil {
+		return nil, err
+	}
+	return values[counter], nil
+}
+
+// GetMulti returns values of given counters. Counters that don't exist have
+// nil value
+func (c *SessionDocumentCounters) GetMulti(counters []string) (map[string]*int64, error) {
+	cache := c.session.getCountersCacheEntry(c.docID)
+	if cache == nil {
+		cache = newCountersCacheEntry()
+	}
+
+	res := map[string]*int64{}
+	var toFetch []string
+	for _, counter := range counters {
+		if value, ok := cache.get(counter); ok {
+			res[counter] = value
+			continue
+		}
+		if c.shouldFetch(cache, counter) {
+			toFetch = append(toFetch, counter)
+		} else {
+			res[counter] = nil
+		}
+	}
+
+	if len(toFetch) > 0 {
+		details, err := c.fetch(toFetch...)
+		if err != nil {
+			return nil, err
+		}
+		for _, counter := range toFetch {
+			res[counter] = nil
+		}
+		if details != nil {
+			for _, detail := range details.Counters {
+				if detail == nil {
+					continue
+				}
+				value := detail.TotalValue
+				for _, counter := range toFetch {
+					if strings.EqualFold(counter, detail.CounterName) {
+						res[counter] = &value
+					}
+				}
+			}
+		}
+	}
+
+	for counter, value := range res {
+		cache.set(counter, value)
+	}
+	c.session.countersByDocID[strings.ToLower(c.docID)] = cache
+	return res, nil
+}
+
+// GetAll returns values of all counters of the document
+func (c *SessionDocumentCounters) GetAll() (map[string]int64, error) {
+	cache := c.session.getCountersCacheEntry(c.docID)
+	if cache == nil {
+		cache = newCountersCacheEntry()
+	}
+
+	missingCounters := !cache.gotAll
+	document := c.session.documentsByID.getValue(c.docID)
+	if document != nil {
+		metadataCounters := getMetadataCounters(document.metadata)
+		missingCounters = false
+		for _, counter := range metadataCounters {
+			if _, ok := cache.get(counter); !ok {
+				missingCounters = true
+				break
+			}
+		}
+	}
+
+	if missingCounters {
+		details, err := c.fetch()
+		if err != nil {
+			return nil, err
+		}
+		cache.values = map[string]*int64{}
+		if details != nil {
+			for _, detail := range details.Counters {
+				if detail == nil {
+					continue
+				}
+				value := detail.TotalValue
+				cache.set(detail.CounterName, &value)
+			}
+		}
+	}
+
+	cache.gotAll = true
+	c.session.countersByDocID[strings.ToLower(c.docID)] = cache
+
+	res := map[string]int64{}
+	for name, value := range cache.values {
+		if value != nil {
+			res[name] = *value
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,223 @@
+package ravendb
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// SessionDocumentTimeSeries gives access to a time series of a document in
+// a session. Append and Delete are sent to the server on SaveChanges.
+// Entries included in load or query results are cached in the session
+type SessionDocumentTimeSeries struct {
+	session *InMemoryDocumentSessionOperations
+	docID   string
+	name    string
+}
+
+func newSessionDocumentTimeSeries(session *InMemoryDocumentSessionOperations, docID string, name string) (*SessionDocumentTimeSeries, error) {
+	if stringIsBlank(name) {
+		return nil, newIllegalArgumentError("name cannot be empty")
+	}
+	return &SessionDocumentTimeSeries{
+		session: session,
+		docID:   docID,
+		name:    name,
+	}, nil
+}
+
+// TimeSeriesFor returns a time series with a given name of a given entity,
+// which must be tracked by the session
+func (s *DocumentSession) TimeSeriesFor(entity interface{}, name string) (*SessionDocumentTimeSeries, error) {
+	if err := checkValidEntityIn(entity, "entity"); err != nil {
+		return nil, err
+	}
+	document := getDocumentInfoByEntity(s.documentsByEntity, entity)
+	if document == nil {
+		return nil, throwEntityNotInSession(entity)
+	}
+	return newSessionDocumentTimeSeries(s.InMemoryDocumentSessionOperations, document.id, name)
+}
+
+// TimeSeriesForDocumentID returns a time series with a given name of
+// a document with a given id
+func (s *DocumentSession) TimeSeriesForDocumentID(docID string, name string) (*SessionDocumentTimeSeries, error) {
+	if stringIsBlank(docID) {
+		return nil, newIllegalArgumentError("docID cannot be empty")
+	}
+	return newSessionDocumentTimeSeries(s.InMemoryDocumentSessionOperations, docID, name)
+}
+
+// GetTimeSeriesNames returns names of time series of a given entity,
+// which must be tracked by the session
+func (s *DocumentSession) GetTimeSeriesNames(entity interface{}) ([]string, error) {
+	if err := checkValidEntityIn(entity, "entity"); err != nil {
+		return nil, err
+	}
+	document := getDocumentInfoByEntity(s.documentsByEntity, entity)
+	if document == nil {
+		return nil, throwEntityNotInSession(entity)
+	}
+	return getMetadataStrings(document.metadata, MetadataTimeSeries), nil
+}
+
+// GetDocumentID returns id of the document whose time series is accessed
+func (t *SessionDocumentTimeSeries) GetDocumentID() string {
+	return t.docID
+}
+
+// GetName returns name of the time series
+func (t *SessionDocumentTimeSeries) GetName() string {
+	return t.name
+}
+
+func (t *SessionDocumentTimeSeries) isDocumentDeletedInSession() bool {
+	documentInfo := t.session.documentsByID.getValue(t.docID)
+	return documentInfo != nil && t.session.deletedEntities.contains(documentInfo.entity)
+}
+
+// getOrCreateDeferredCommand returns deferred command for this time series
+// so that all appends and deletes are sent in a single command
+func (t *SessionDocumentTimeSeries) getOrCreateDeferredCommand() (*TimeSeriesBatchCommandData, error) {
+	key := newIDTypeAndName(t.docID, CommandTimeSeries, t.name)
+	if command, ok := t.session.deferredCommandsMap[key]; ok {
+		return command.(*TimeSeriesBatchCommandData), nil
+	}
+	command, err := NewTimeSeriesBatchCommandData(t.docID, t.name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	t.session.Defer(command)
+	return command, nil
+}
+
+// Append appends an entry with given values on SaveChanges. An existing
+// entry with the same timestamp is overwritten
+func (t *SessionDocumentTimeSeries) Append(timestamp time.Time, values ...float64) error {
+	return t.AppendWithTag(timestamp, "", values...)
+}
+
+// AppendWithTag is like Append but also sets a tag of the entry
+func (t *SessionDocumentTimeSeries) AppendWithTag(timestamp time.Time, tag string, values ...float64) error {
+	if len(values) == 0 {
+		return newIllegalArgumentError("values cannot be empty")
+	}
+	if t.isDocumentDeletedInSession() {
+		return newIllegalStateError("Can't append new time series value to document %s, the document was already deleted in this session", t.docID)
+	}
+
+	command, err := t.getOrCreateDeferredCommand()
+	if err != nil {
+		return err
+	}
+	command.TimeSeries.Append(timestamp, tag, values...)
+	t.session.removeTimeSeriesCacheEntry(t.docID, t.name)
+	return nil
+}
+
+// DeleteAt deletes an entry with a given timestamp on SaveChanges
+func (t *SessionDocumentTimeSeries) DeleteAt(timestamp time.Time) error {
+	return t.Delete(&timestamp, &timestamp)
+}
+
+// DeleteAll deletes all entries on SaveChanges
+func (t *SessionDocumentTimeSeries) DeleteAll() error {
+	return t.Delete(nil, nil)
+}
+
+// Delete deletes entries within [from, to] on SaveChanges. nil from or to
+// means that the range is not bounded
+func (t *SessionDocumentTimeSeries) Delete(from *time.Time, to *time.Time) error {
+	if t.isDocumentDeletedInSession() {
+		return newIllegalStateError("Can't delete time series from document %s, the document was already deleted in this session", t.docID)
+	}
+
+	command, err := t.getOrCreateDeferredCommand()
+	if err != nil {
+		return err
+	}
+	command.TimeSeries.Delete(from, to)
+	t.session.removeTimeSeriesCacheEntry(t.docID, t.name)
+	return nil
+}
+
+// GetAll returns all entries of the time series
+func (t *SessionDocumentTimeSeries) GetAll() ([]*TimeSeriesEntry, error) {
+	return t.Get(nil, nil, 0, math.MaxInt32)
+}
+
+// Get returns at most pageSize entries within [from, to], skipping first
+// start entries. nil from or to means that the range is not bounded.
+// Returns nil if the document or time series doesn't exist
+func (t *SessionDocumentTimeSeries) Get(from *time.Time, to *time.Time, start int, pageSize int) ([]*TimeSeriesEntry, error) {
+	if start == 0 && pageSize == math.MaxInt32 {
+		if entries, ok := t.session.getTimeSeriesFromCache(t.docID, t.name, from, to); ok {
+			return entries, nil
+		}
+	}
+
+	if document := t.session.documentsByID.getValue(t.docID); document != nil {
+		// we know from the metadata that the time series doesn't exist
+		if !t.isInMetadata(document.metadata) {
+			return nil, nil
+		}
+	}
+
+	if err := t.session.incrementRequestCount(); err != nil {
+		return nil, err
+	}
+	op, err := NewGetTimeSeriesOperationWithPaging(t.docID, t.name, from, to, start, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	err = t.session.GetOperations().SendWithContext(t.session.ctx, op, t.session.sessionInfo)
+	if err != nil {
+		return nil, err
+	}
+	result := op.Command.Result
+	if result == nil {
+		return nil, nil
+	}
+	if start == 0 && pageSize == math.MaxInt32 {
+		result.From = timeToServerTimePtr(from)
+		result.To = timeToServerTimePtr(to)
+		t.session.addTimeSeriesToCache(t.docID, t.name, result)
+	}
+	return result.Entries, nil
+}
+
+func (t *SessionDocumentTimeSeries) isInMetadata(metadata map[string]interface{}) bool {
+	if _, ok := metadata[MetadataTimeSeries]; !ok {
+		// not known
+		return true
+	}
+	for _, name := range getMetadataStrings(metadata, MetadataTimeSeries) {
+		if strings.EqualFold(name, t.name) {
+			return true
+		}
+	}
+	return false
+}
+
+func getMetadataStrings(metadata map[string]interface{}, key string) []string {
+	v, ok := metadata[key]
+	if !ok {
+		return nil
+	}
+	a, _ := v.([]interface{})
+	var res []string
+	for _, el := range a {
+		if s, ok := el.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func timeToServerTimePtr(t *time.Time) *Time {
+	if t == nil {
+		return nil
+	}
+	res := Time(t.UTC())
+	return &res
+}
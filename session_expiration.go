@@ -0,0 +1,91 @@
+package ravendb
+
+import (
+	"time"
+)
+
+// SetExpiration sets @expires metadata of a tracked entity so that the
+// document is deleted by the server after expires (if expiration is
+// enabled with ConfigureExpirationOperation). Saved on SaveChanges
+func (s *InMemoryDocumentSessionOperations) SetExpiration(entity interface{}, expires time.Time) error {
+	return s.setMetadataTime(entity, MetadataExpires, expires)
+}
+
+// GetExpiration returns time from @expires metadata of a tracked entity
+// or nil if it doesn't expire
+func (s *InMemoryDocumentSessionOperations) GetExpiration(entity interface{}) (*time.Time, error) {
+	return s.getMetadataTime(entity, MetadataExpires)
+}
+
+// ClearExpiration removes @expires metadata of a tracked entity
+func (s *InMemoryDocumentSessionOperations) ClearExpiration(entity interface{}) error {
+	return s.removeMetadata(entity, MetadataExpires)
+}
+
+// SetRefresh sets @refresh metadata of a tracked entity so that the
+// document is updated by the server after refresh (if refresh is
+// enabled with ConfigureRefreshOperation), which triggers e.g.
+// subscriptions and ETL. Saved on SaveChanges
+func (s *InMemoryDocumentSessionOperations) SetRefresh(entity interface{}, refresh time.Time) error {
+	return s.setMetadataTime(entity, MetadataRefresh, refresh)
+}
+
+// GetRefresh returns time from @refresh metadata of a tracked entity
+// or nil if it's not set
+func (s *InMemoryDocumentSessionOperations) GetRefresh(entity interface{}) (*time.Time, error) {
+	return s.getMetadataTime(entity, MetadataRefresh)
+}
+
+// ClearRefresh removes @refresh metadata of a tracked entity
+func (s *InMemoryDocumentSessionOperations) ClearRefresh(entity interface{}) error {
+	return s.removeMetadata(entity, MetadataRefresh)
+}
+
+func (s *InMemoryDocumentSessionOperations) setMetadataTime(entity interface{}, key string, t time.Time) error {
+	metadata, err := s.GetMetadataFor(entity)
+	if err != nil {
+		return err
+	}
+	metadata.Put(key, timeToUTCString(t))
+	return nil
+}
+
+func (s *InMemoryDocumentSessionOperations) getMetadataTime(entity interface{}, key string) (*time.Time, error) {
+	metadata, err := s.GetMetadataFor(entity)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := metadata.Get(key)
+	if !ok || v == nil {
+		return nil, nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return nil, newIllegalStateError("%s metadata of the entity is %T and not a string", key, v)
+	}
+	t, err := ParseTime(str)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *InMemoryDocumentSessionOperations) removeMetadata(entity interface{}, key string) error {
+	metadata, err := s.GetMetadataFor(entity)
+	if err != nil {
+		return err
+	}
+	if !metadata.ContainsKey(key) {
+		return nil
+	}
+	// Remove is a no-op for not initialized metadata
+	metadata.EntrySet()
+	metadata.Remove(key)
+	// UpdateMetadataModifications only copies keys that exist in metadata
+	documentInfo, err := s.getDocumentInfo(entity)
+	if err != nil {
+		return err
+	}
+	delete(documentInfo.metadata, key)
+	return nil
+}
@@ -0,0 +1,90 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type expiringToken struct {
+	ID    string
+	Value string
+}
+
+func TestSessionExpirationAndRefresh(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	token := &expiringToken{Value: "secret"}
+	err := session.StoreWithID(token, "tokens/1")
+	assert.NoError(t, err)
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	expires := time.Date(2020, 1, 2, 5, 4, 5, 120000000, loc)
+	err = session.Advanced().SetExpiration(token, expires)
+	assert.NoError(t, err)
+
+	metadata, err := session.Advanced().GetMetadataFor(token)
+	assert.NoError(t, err)
+	v, _ := metadata.Get(MetadataExpires)
+	assert.Equal(t, "2020-01-02T03:04:05.1200000Z", v)
+
+	got, err := session.Advanced().GetExpiration(token)
+	assert.NoError(t, err)
+	assert.True(t, got.Equal(expires))
+
+	documentInfo, err := session.getDocumentInfo(token)
+	assert.NoError(t, err)
+	assert.True(t, session.UpdateMetadataModifications(documentInfo))
+	assert.Equal(t, "2020-01-02T03:04:05.1200000Z", documentInfo.metadata[MetadataExpires])
+
+	err = session.Advanced().ClearExpiration(token)
+	assert.NoError(t, err)
+	got, err = session.Advanced().GetExpiration(token)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	session.UpdateMetadataModifications(documentInfo)
+	_, ok := documentInfo.metadata[MetadataExpires]
+	assert.False(t, ok)
+
+	got, err = session.Advanced().GetRefresh(token)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	err = session.Advanced().SetRefresh(token, expires)
+	assert.NoError(t, err)
+	got, err = session.Advanced().GetRefresh(token)
+	assert.NoError(t, err)
+	assert.True(t, got.Equal(expires))
+
+	err = session.Advanced().SetExpiration(&expiringToken{}, expires)
+	assert.Error(t, err)
+}
+
+func TestConfigureExpirationAndRefreshCommandsRequests(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+	frequency := int64(60)
+
+	cmd, err := NewConfigureExpirationOperation(&ExpirationConfiguration{DeleteFrequencyInSec: &frequency}).GetCommand(nil)
+	assert.NoError(t, err)
+	req, err := cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/databases/db/admin/expiration/config", req.URL.String())
+	d, err := ioutil.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Disabled":false,"DeleteFrequencyInSec":60}`, string(d))
+
+	cmd, err = NewConfigureRefreshOperation(&RefreshConfiguration{RefreshFrequencyInSec: &frequency}).GetCommand(nil)
+	assert.NoError(t, err)
+	req, err = cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/databases/db/admin/refresh/config", req.URL.String())
+	d, err = ioutil.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Disabled":false,"RefreshFrequencyInSec":60}`, string(d))
+
+	_, err = NewConfigureExpirationOperation(nil).GetCommand(nil)
+	assert.Error(t, err)
+}
@@ -1,7 +1,17 @@
 package ravendb
 
+import "context"
+
 // SessionOptions describes session options
 type SessionOptions struct {
 	Database        string
 	RequestExecutor *RequestExecutor
+
+	// Context, if set, bounds all requests sent to the server by the session
+	// (loads, queries, SaveChanges etc.). If nil, context.Background() is used
+	Context context.Context
+
+	// TransactionMode is TransactionModeSingleNode if not set.
+	// Use TransactionModeClusterWide for cluster-wide transactions
+	TransactionMode TransactionMode
 }
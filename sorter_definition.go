@@ -0,0 +1,8 @@
+package ravendb
+
+// SorterDefinition describes a custom sorter
+type SorterDefinition struct {
+	Name string `json:"Name"`
+	// Code is C# source code of a class implementing the sorter
+	Code string `json:"Code"`
+}
@@ -0,0 +1,72 @@
+package ravendb
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSorterCommandsRequests(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+
+	{
+		sorter := &SorterDefinition{
+			Name: "MySorter",
+			Code: "public class MySorter : FieldComparator {}",
+		}
+		cmd, err := NewPutSortersOperation(sorter).GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/sorters", req.URL.String())
+		d, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		exp := `{"Sorters":[{"Name":"MySorter","Code":"public class MySorter : FieldComparator {}"}]}`
+		assert.Equal(t, exp, string(d))
+
+		_, err = NewPutSortersOperation().GetCommand(nil)
+		assert.Error(t, err)
+		_, err = NewPutSortersOperation(nil).GetCommand(nil)
+		assert.Error(t, err)
+		_, err = NewPutSortersOperation(&SorterDefinition{Code: "class"}).GetCommand(nil)
+		assert.Error(t, err)
+	}
+
+	{
+		cmd, err := NewDeleteSorterOperation("My Sorter").GetCommand(nil)
+		assert.NoError(t, err)
+		req, err := cmd.createRequest(node)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "http://localhost:8080/databases/db/admin/sorters?name=My+Sorter", req.URL.String())
+
+		_, err = NewDeleteSorterOperation(" ").GetCommand(nil)
+		assert.Error(t, err)
+	}
+}
+
+func TestQueryOrderByCustom(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+
+	q := session.QueryCollection("Products").
+		OrderByCustom("Name", "MySorter").
+		OrderByCustomDescending("Supplier.Rank", "RankSorter").
+		OrderBy("PricePerUnit")
+	iq, err := q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Products order by custom(Name, 'MySorter'), custom(Supplier.Rank, 'RankSorter') desc, PricePerUnit", iq.GetQuery())
+
+	q = session.QueryIndex("Products/ByName").OrderByCustom("Name", "MySorter")
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from index 'Products/ByName' order by custom(Name, 'MySorter')", iq.GetQuery())
+
+	q = session.QueryCollection("Products").OrderByCustom("Name", "")
+	assert.Error(t, q.Err())
+}
@@ -0,0 +1,21 @@
+package ravendb
+
+// SqlConnectionString represents connection string for a relational
+// database used by SQL ETL
+type SqlConnectionString struct {
+	Name string               `json:"Name"`
+	Type ConnectionStringType `json:"Type"`
+	// ConnectionString is a connection string of the database in a format
+	// expected by the driver e.g. "Data Source=localhost;Initial Catalog=Orders"
+	ConnectionString string `json:"ConnectionString"`
+	// FactoryName is a name of .NET provider factory e.g.
+	// "System.Data.SqlClient", "MySql.Data.MySqlClient" or "Npgsql"
+	FactoryName string `json:"FactoryName"`
+}
+
+// NewSqlConnectionString returns new SqlConnectionString
+func NewSqlConnectionString() *SqlConnectionString {
+	return &SqlConnectionString{
+		Type: ConnectionStringTypeSQL,
+	}
+}
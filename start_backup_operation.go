@@ -0,0 +1,69 @@
+package ravendb
+
+import (
+	"net/http"
+	"strconv"
+)
+
+var (
+	_ IMaintenanceOperation = &StartBackupOperation{}
+)
+
+// StartBackupOperation starts a backup of a periodic backup task immediately.
+// Use MaintenanceOperationExecutor.SendAsync to get an Operation that can
+// be waited on
+type StartBackupOperation struct {
+	isFullBackup bool
+	taskID       int64
+
+	Command *StartBackupCommand
+}
+
+// StartBackupOperationResult is a result of StartBackupOperation
+type StartBackupOperationResult struct {
+	// ResponsibleNode is a tag of the node that executes the backup
+	ResponsibleNode string `json:"ResponsibleNode"`
+	OperationID     int64  `json:"OperationId"`
+}
+
+// NewStartBackupOperation returns new StartBackupOperation
+func NewStartBackupOperation(isFullBackup bool, taskID int64) *StartBackupOperation {
+	return &StartBackupOperation{
+		isFullBackup: isFullBackup,
+		taskID:       taskID,
+	}
+}
+
+func (o *StartBackupOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	o.Command = &StartBackupCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		isFullBackup: o.isFullBackup,
+		taskID:       o.taskID,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &StartBackupCommand{}
+
+// StartBackupCommand is a command for StartBackupOperation
+type StartBackupCommand struct {
+	RavenCommandBase
+
+	isFullBackup bool
+	taskID       int64
+
+	Result *StartBackupOperationResult
+}
+
+func (c *StartBackupCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/backup/database?isFullBackup=" + strconv.FormatBool(c.isFullBackup) + "&taskId=" + i64toa(c.taskID)
+	return newHttpPost(url, nil)
+}
+
+func (c *StartBackupCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
@@ -1,7 +1,6 @@
 package ravendb
 
 import (
-	"log"
 	"reflect"
 )
 
@@ -52,21 +51,39 @@ type SubscriptionBatch struct {
 	store           *DocumentStore
 	dbName          string
 
-	logger                      *log.Logger
+	logger                      Logger
 	generateEntityIdOnTheClient *generateEntityIDOnTheClient
 
 	Items []*SubscriptionBatchItem
+
+	// documents included by the subscription query, sent by the server
+	// together with the batch
+	includes []map[string]interface{}
 }
 
+// OpenSession opens a session for processing the batch. Documents included
+// by the subscription query are already in the session so loading them
+// doesn't require a round-trip to the server
 func (b *SubscriptionBatch) OpenSession() (*DocumentSession, error) {
 	sessionOptions := &SessionOptions{
 		Database:        b.dbName,
 		RequestExecutor: b.requestExecutor,
 	}
-	return b.store.OpenSessionWithOptions(sessionOptions)
+	session, err := b.store.OpenSessionWithOptions(sessionOptions)
+	if err != nil {
+		return nil, err
+	}
+	b.initSession(session)
+	return session, nil
+}
+
+func (b *SubscriptionBatch) initSession(session *DocumentSession) {
+	for _, includes := range b.includes {
+		session.registerIncludes(includes)
+	}
 }
 
-func newSubscriptionBatch(clazz reflect.Type, revisions bool, requestExecutor *RequestExecutor, store *DocumentStore, dbName string, logger *log.Logger) *SubscriptionBatch {
+func newSubscriptionBatch(clazz reflect.Type, revisions bool, requestExecutor *RequestExecutor, store *DocumentStore, dbName string, logger Logger) *SubscriptionBatch {
 	res := &SubscriptionBatch{
 		clazz:           clazz,
 		revisions:       revisions,
@@ -86,10 +103,17 @@ func newSubscriptionBatch(clazz reflect.Type, revisions bool, requestExecutor *R
 
 func (b *SubscriptionBatch) initialize(batch []*subscriptionConnectionServerMessage) (string, error) {
 	b.Items = nil
+	b.includes = nil
 
 	lastReceivedChangeVector := ""
 
 	for _, item := range batch {
+		if item.Type == subscriptionServerMessageIncludes {
+			if item.Includes != nil {
+				b.includes = append(b.includes, item.Includes)
+			}
+			continue
+		}
 		curDoc := item.Data
 		metadataI, ok := curDoc[MetadataKey]
 		if !ok {
@@ -106,9 +130,7 @@ func (b *SubscriptionBatch) initialize(batch []*subscriptionConnectionServerMess
 			return "", throwRequired("@change-vector field")
 		}
 		lastReceivedChangeVector = changeVector
-		if b.logger != nil {
-			b.logger.Printf("Got %s (change vector: [%s], size: %d)", id, lastReceivedChangeVector, len(curDoc))
-		}
+		b.logger.Log(LogLevelDebug, "subscription got document", "id", id, "changeVector", lastReceivedChangeVector, "size", len(curDoc))
 		var instance interface{}
 
 		if item.Exception == "" {
@@ -8,6 +8,7 @@ const (
 	subscriptionServerMessageConnectionStatus = "ConnectionStatus"
 	subscriptionServerMessageEndOfBatch       = "EndOfBatch"
 	subscriptionServerMessageData             = "Data"
+	subscriptionServerMessageIncludes         = "Includes"
 	subscriptionServerMessageConfirm          = "Confirm"
 	subscriptionServerMessageError            = "Error"
 )
@@ -41,6 +42,7 @@ type subscriptionConnectionServerMessage struct {
 	Type      subscriptionServerMessageType `json:"Type"`
 	Status    subscriptionConnectionStatus  `json:"Status"`
 	Data      map[string]interface{}        `json:"Data"`
+	Includes  map[string]interface{}        `json:"Includes"`
 	Exception string                        `json:"Exception"`
 	Message   string                        `json:"Message"`
 }
@@ -1,5 +1,7 @@
 package ravendb
 
+import "strings"
+
 // SubscriptionCreationOptions describes options for creating a subscription
 type SubscriptionCreationOptions struct {
 	// must omitempty Name or else the server will try to find a subscription
@@ -8,4 +10,19 @@ type SubscriptionCreationOptions struct {
 	Query        string  `json:"Query"`
 	ChangeVector *string `json:"ChangeVector"`
 	MentorNode   string  `json:"MentorNode,omitempty"`
+
+	// Includes are paths of related documents sent together with
+	// the documents of a batch. They're added to Query as include clause
+	// so Query must not have one
+	Includes []string `json:"-"`
+}
+
+func (o *SubscriptionCreationOptions) getQueryWithIncludes() string {
+	if len(o.Includes) == 0 {
+		return o.Query
+	}
+	var queryText strings.Builder
+	queryText.WriteString(o.Query)
+	writeSubscriptionIncludes(&queryText, o.Includes)
+	return queryText.String()
 }
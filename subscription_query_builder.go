@@ -0,0 +1,102 @@
+package ravendb
+
+import "strings"
+
+// SubscriptionQueryBuilder helps to build a query for
+// SubscriptionCreationOptions.Query. The document is available in the
+// filter and projection as doc e.g.:
+//
+//	query := NewSubscriptionQueryBuilder("Orders").
+//		Where("doc.Freight > 10").
+//		Include("Company", "Employee").
+//		GetQuery()
+//
+// GetQuery returns an error if the collection is empty
+type SubscriptionQueryBuilder struct {
+	collection string
+	revisions  bool
+	filter     string
+	projection string
+	includes   []string
+}
+
+// NewSubscriptionQueryBuilder returns a builder for subscription over
+// documents in a given collection
+func NewSubscriptionQueryBuilder(collection string) *SubscriptionQueryBuilder {
+	return &SubscriptionQueryBuilder{
+		collection: collection,
+	}
+}
+
+// Revisions makes the subscription send revisions (Previous and Current
+// version of changed documents) instead of documents
+func (b *SubscriptionQueryBuilder) Revisions() *SubscriptionQueryBuilder {
+	b.revisions = true
+	return b
+}
+
+// Where sets a filter (RQL where clause without the where keyword) e.g.
+// "doc.Age > 18". Calling it again combines filters with and
+func (b *SubscriptionQueryBuilder) Where(filter string) *SubscriptionQueryBuilder {
+	if b.filter == "" {
+		b.filter = filter
+	} else {
+		b.filter = "(" + b.filter + ") and (" + filter + ")"
+	}
+	return b
+}
+
+// Select sets a projection (RQL select clause without the select keyword)
+// e.g. "{ Name: doc.FirstName + ' ' + doc.LastName }"
+func (b *SubscriptionQueryBuilder) Select(projection string) *SubscriptionQueryBuilder {
+	b.projection = projection
+	return b
+}
+
+// Include adds paths of related documents that are sent together with
+// the documents of a batch. They can be loaded in a session opened with
+// SubscriptionBatch.OpenSession without a round-trip to the server
+func (b *SubscriptionQueryBuilder) Include(paths ...string) *SubscriptionQueryBuilder {
+	b.includes = append(b.includes, paths...)
+	return b
+}
+
+// GetQuery returns the query
+func (b *SubscriptionQueryBuilder) GetQuery() (string, error) {
+	if stringIsBlank(b.collection) {
+		return "", newIllegalArgumentError("collection cannot be empty")
+	}
+	var queryText strings.Builder
+	queryText.WriteString("from ")
+	queryText.WriteString(b.collection)
+	if b.revisions {
+		queryText.WriteString(" (Revisions = true)")
+	}
+	queryText.WriteString(" as doc")
+	if b.filter != "" {
+		queryText.WriteString(" where ")
+		queryText.WriteString(b.filter)
+	}
+	if b.projection != "" {
+		queryText.WriteString(" select ")
+		queryText.WriteString(b.projection)
+	}
+	writeSubscriptionIncludes(&queryText, b.includes)
+	return queryText.String(), nil
+}
+
+func writeSubscriptionIncludes(queryText *strings.Builder, includes []string) {
+	seen := map[string]bool{}
+	for _, include := range includes {
+		if seen[include] {
+			continue
+		}
+		if len(seen) == 0 {
+			queryText.WriteString(" include ")
+		} else {
+			queryText.WriteString(",")
+		}
+		seen[include] = true
+		includesUtilWriteInclude(queryText, include)
+	}
+}
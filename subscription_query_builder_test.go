@@ -0,0 +1,79 @@
+package ravendb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionQueryBuilder(t *testing.T) {
+	query, err := NewSubscriptionQueryBuilder("Orders").GetQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Orders as doc", query)
+
+	query, err = NewSubscriptionQueryBuilder("Orders").
+		Where("doc.Freight > 10").
+		Where("doc.ShipTo.Country = 'UK'").
+		Select("{ Company: doc.Company, Freight: doc.Freight }").
+		Include("Employee", "Company", "Lines[].Product", "Company").
+		GetQuery()
+	assert.NoError(t, err)
+	exp := "from Orders as doc where (doc.Freight > 10) and (doc.ShipTo.Country = 'UK') select { Company: doc.Company, Freight: doc.Freight } include Employee,Company,'Lines[].Product'"
+	assert.Equal(t, exp, query)
+
+	query, err = NewSubscriptionQueryBuilder("Users").Revisions().Include("Address").GetQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users (Revisions = true) as doc include Address", query)
+
+	query, err = NewSubscriptionQueryBuilder("").Where("doc.Age > 18").GetQuery()
+	_, ok := err.(*IllegalArgumentError)
+	assert.True(t, ok, "err is %T", err)
+	assert.Equal(t, "", query)
+}
+
+func TestSubscriptionCreationOptionsIncludes(t *testing.T) {
+	opts := &SubscriptionCreationOptions{
+		Query:    "from Orders as doc",
+		Includes: []string{"Company"},
+	}
+	assert.Equal(t, "from Orders as doc include Company", opts.getQueryWithIncludes())
+	opts.Includes = nil
+	assert.Equal(t, "from Orders as doc", opts.getQueryWithIncludes())
+}
+
+func TestSubscriptionBatchIncludes(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	clazz := reflect.TypeOf(map[string]interface{}{})
+	batch := newSubscriptionBatch(clazz, false, session.GetRequestExecutor(), session.documentStore, "db", nopLogger{})
+
+	doc := func(id string) map[string]interface{} {
+		return map[string]interface{}{
+			"Name": id,
+			MetadataKey: map[string]interface{}{
+				MetadataID:           id,
+				MetadataChangeVector: "A:1-abc",
+			},
+		}
+	}
+	messages := []*subscriptionConnectionServerMessage{
+		{Type: subscriptionServerMessageData, Data: doc("orders/1")},
+		{Type: subscriptionServerMessageIncludes, Includes: map[string]interface{}{
+			"companies/1": doc("companies/1"),
+		}},
+	}
+	_, err := batch.initialize(messages)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(batch.Items))
+	assert.Equal(t, "orders/1", batch.Items[0].ID)
+
+	batch.initSession(session)
+	assert.True(t, session.IsLoaded("companies/1"))
+	var company *struct {
+		Name string
+	}
+	// served from included documents, there's no server to ask
+	err = session.Load(&company, "companies/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "companies/1", company.Name)
+}
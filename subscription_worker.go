@@ -3,7 +3,6 @@ package ravendb
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net"
 	"reflect"
 	"strconv"
@@ -24,7 +23,7 @@ var (
 type SubscriptionWorker struct {
 	clazz     reflect.Type
 	revisions bool
-	logger    *log.Logger
+	logger    Logger
 	store     *DocumentStore
 	dbName    string
 
@@ -35,6 +34,8 @@ type SubscriptionWorker struct {
 	disposed              int32 // atomic
 	// this channel is closed when worker
 	chDone chan struct{}
+	// this channel is closed when cancellation is requested, see cancelled()
+	chCancel chan struct{}
 
 	afterAcknowledgment           []func(*SubscriptionBatch)
 	onSubscriptionConnectionRetry []func(error)
@@ -64,11 +65,23 @@ func (w *SubscriptionWorker) isCancellationRequested() bool {
 	return v > 0
 }
 
+// cancelled returns a channel that is closed when cancellation is requested
+func (w *SubscriptionWorker) cancelled() chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.chCancel == nil {
+		w.chCancel = make(chan struct{})
+	}
+	return w.chCancel
+}
+
 // Cancel requests the worker to finish. It doesn't happen immediately.
 // To check if the worker finished, use HasFinished
 // To wait
 func (w *SubscriptionWorker) Cancel() {
-	atomic.AddInt32(&w.cancellationRequested, 1)
+	if atomic.AddInt32(&w.cancellationRequested, 1) == 1 {
+		close(w.cancelled())
+	}
 	// we might be reading from a connection, so break that loop
 	// by closing the connection
 	w.closeTcpClient()
@@ -170,6 +183,7 @@ func NewSubscriptionWorker(clazz reflect.Type, options *SubscriptionWorkerOption
 		options:   options,
 		revisions: withRevisions,
 		store:     documentStore,
+		logger:    documentStore.GetConventions().GetLogger(),
 		dbName:    dbName,
 	}
 
@@ -462,6 +476,7 @@ func (w *SubscriptionWorker) processSubscriptionInner(cb func(batch *Subscriptio
 		// only copy the fields needed in OpenSession
 		batchCopy := &SubscriptionBatch{
 			Items:           batch.Items,
+			includes:        batch.includes,
 			store:           batch.store,
 			requestExecutor: batch.requestExecutor,
 			dbName:          batch.dbName,
@@ -515,7 +530,7 @@ func (w *SubscriptionWorker) readSingleSubscriptionBatchFromServer(batch *Subscr
 		}
 
 		switch receivedMessage.Type {
-		case subscriptionServerMessageData:
+		case subscriptionServerMessageData, subscriptionServerMessageIncludes:
 			incomingBatch = append(incomingBatch, receivedMessage)
 		case subscriptionServerMessageEndOfBatch:
 			endOfBatch = true
@@ -592,9 +607,7 @@ func (w *SubscriptionWorker) runSubscriptionAsync(cb func(*SubscriptionBatch) er
 	for !w.isCancellationRequested() {
 		w.closeTcpClient()
 
-		if w.logger != nil {
-			w.logger.Print("Subscription " + w.options.SubscriptionName + ". Connecting to server...")
-		}
+		w.logger.Log(LogLevelInfo, "subscription connecting to server", "subscription", w.options.SubscriptionName, "database", w.dbName)
 
 		//fmt.Printf("before w.processSubscription\n")
 		ex := w.processSubscription(cb)
@@ -613,10 +626,12 @@ func (w *SubscriptionWorker) runSubscriptionAsync(cb func(*SubscriptionBatch) er
 		//fmt.Printf("shouldTryReconnect() returned err='%s'\n", err)
 		if err != nil || !shouldReconnect {
 			if err != nil {
+				w.logger.Log(LogLevelError, "subscription failed", "subscription", w.options.SubscriptionName, "error", err)
 				w.err.Store(err)
 			}
 			return
 		}
+		w.logger.Log(LogLevelWarn, "subscription connection failed, reconnecting", "subscription", w.options.SubscriptionName, "error", ex)
 		time.Sleep(time.Duration(w.options.TimeToWaitBeforeConnectionRetry))
 		for _, cb := range w.onSubscriptionConnectionRetry {
 			cb(ex)
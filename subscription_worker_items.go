@@ -0,0 +1,109 @@
+package ravendb
+
+import (
+	"sync"
+	"time"
+)
+
+// RunItems is like Run but calls handler for every item of a batch, with
+// up to options.MaxConcurrency items processed at the same time, so the
+// handler must be safe for concurrent use. Items within a batch are not
+// processed in order.
+//
+// Items for which handler returned an error are retried (up to
+// options.MaxItemRetries times). The batch is acknowledged only after all
+// its items were processed successfully, and batches are processed (and
+// acknowledged) one after another. If some items still fail, the worker
+// fails with SubscriptionItemsError and (like when the callback of Run
+// returns an error) reconnects and receives the batch again, so every item
+// is processed at least once. If options.IgnoreSubscriberErrors is set,
+// failed items are skipped and the batch is acknowledged
+func (w *SubscriptionWorker) RunItems(handler func(batch *SubscriptionBatch, item *SubscriptionBatchItem) error) error {
+	if handler == nil {
+		return newIllegalArgumentError("handler cannot be nil")
+	}
+	return w.Run(func(batch *SubscriptionBatch) error {
+		return w.processBatchItems(batch, handler)
+	})
+}
+
+func (w *SubscriptionWorker) processBatchItems(batch *SubscriptionBatch, handler func(*SubscriptionBatch, *SubscriptionBatchItem) error) error {
+	items := batch.Items
+	var errs []error
+	for attempt := 0; ; attempt++ {
+		errs = w.processItemsConcurrently(batch, items, handler)
+		if w.isCancellationRequested() {
+			return throwCancellationRequested()
+		}
+
+		var failed []*SubscriptionBatchItem
+		var failedErrs []error
+		for i, err := range errs {
+			if err != nil {
+				failed = append(failed, items[i])
+				failedErrs = append(failedErrs, err)
+			}
+		}
+		items, errs = failed, failedErrs
+		if len(items) == 0 {
+			return nil
+		}
+		if attempt >= w.options.MaxItemRetries {
+			break
+		}
+		w.logger.Log(LogLevelDebug, "subscription retrying failed items", "subscription", w.options.SubscriptionName, "count", len(items), "attempt", attempt+1)
+		select {
+		case <-w.cancelled():
+			return throwCancellationRequested()
+		case <-time.After(time.Duration(w.options.ItemRetryDelay)):
+		}
+	}
+
+	failures := map[string]error{}
+	for i, item := range items {
+		failures[item.ID] = errs[i]
+	}
+	if w.options.IgnoreSubscriberErrors {
+		w.logger.Log(LogLevelWarn, "subscription ignoring failed items", "subscription", w.options.SubscriptionName, "count", len(items), "error", errs[0])
+		return nil
+	}
+	return newSubscriptionItemsError(failures, items[0].ID)
+}
+
+// processItemsConcurrently calls handler for items and returns errors
+// returned for each item
+func (w *SubscriptionWorker) processItemsConcurrently(batch *SubscriptionBatch, items []*SubscriptionBatchItem, handler func(*SubscriptionBatch, *SubscriptionBatchItem) error) []error {
+	errs := make([]error, len(items))
+	concurrency := w.options.MaxConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency == 1 {
+		for i, item := range items {
+			if w.isCancellationRequested() {
+				break
+			}
+			errs[i] = handler(batch, item)
+		}
+		return errs
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		if w.isCancellationRequested() {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item *SubscriptionBatchItem) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = handler(batch, item)
+		}(i, item)
+	}
+	wg.Wait()
+	return errs
+}
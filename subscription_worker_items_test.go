@@ -0,0 +1,130 @@
+package ravendb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestItemsWorker(concurrency int, retries int) *SubscriptionWorker {
+	options := NewSubscriptionWorkerOptions("sub")
+	options.MaxConcurrency = concurrency
+	options.MaxItemRetries = retries
+	return &SubscriptionWorker{
+		options: options,
+		logger:  nopLogger{},
+	}
+}
+
+func newTestItemsBatch(n int) *SubscriptionBatch {
+	batch := &SubscriptionBatch{}
+	for i := 0; i < n; i++ {
+		batch.Items = append(batch.Items, &SubscriptionBatchItem{ID: fmt.Sprintf("users/%d", i)})
+	}
+	return batch
+}
+
+func TestSubscriptionWorkerProcessesItemsConcurrently(t *testing.T) {
+	w := newTestItemsWorker(4, 0)
+	batch := newTestItemsBatch(20)
+
+	var running, maxRunning int32
+	var mu sync.Mutex
+	processed := map[string]int{}
+	handler := func(b *SubscriptionBatch, item *SubscriptionBatchItem) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 5)
+		atomic.AddInt32(&running, -1)
+		mu.Lock()
+		processed[item.ID]++
+		mu.Unlock()
+		return nil
+	}
+	err := w.processBatchItems(batch, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, len(processed))
+	for _, n := range processed {
+		assert.Equal(t, 1, n)
+	}
+	assert.True(t, maxRunning > 1)
+	assert.True(t, maxRunning <= 4)
+}
+
+func TestSubscriptionWorkerRetriesFailedItems(t *testing.T) {
+	w := newTestItemsWorker(3, 2)
+	batch := newTestItemsBatch(5)
+
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	handler := func(b *SubscriptionBatch, item *SubscriptionBatchItem) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[item.ID]++
+		// users/1 succeeds on the third attempt, users/3 never succeeds
+		if (item.ID == "users/1" && attempts[item.ID] < 3) || item.ID == "users/3" {
+			return errors.New("failed " + item.ID)
+		}
+		return nil
+	}
+	err := w.processBatchItems(batch, handler)
+	itemsErr, ok := err.(*SubscriptionItemsError)
+	assert.True(t, ok, "err is %T", err)
+	assert.Equal(t, 1, len(itemsErr.Errors))
+	assert.Equal(t, "failed users/3", itemsErr.Errors["users/3"].Error())
+	assert.Equal(t, 3, attempts["users/1"])
+	assert.Equal(t, 3, attempts["users/3"])
+	assert.Equal(t, 1, attempts["users/0"])
+
+	// with IgnoreSubscriberErrors the batch is acknowledged anyway
+	attempts = map[string]int{}
+	w.options.IgnoreSubscriberErrors = true
+	err = w.processBatchItems(batch, handler)
+	assert.NoError(t, err)
+}
+
+func TestSubscriptionWorkerProcessesItemsSequentiallyByDefault(t *testing.T) {
+	w := newTestItemsWorker(0, 0)
+	batch := newTestItemsBatch(5)
+
+	var ids []string
+	handler := func(b *SubscriptionBatch, item *SubscriptionBatchItem) error {
+		ids = append(ids, item.ID)
+		return nil
+	}
+	err := w.processBatchItems(batch, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"users/0", "users/1", "users/2", "users/3", "users/4"}, ids)
+}
+
+func TestSubscriptionWorkerCancelStopsWaitingForRetry(t *testing.T) {
+	w := newTestItemsWorker(1, 1)
+	w.options.ItemRetryDelay = Duration(time.Hour)
+	batch := newTestItemsBatch(1)
+
+	failed := make(chan struct{}, 1)
+	handler := func(b *SubscriptionBatch, item *SubscriptionBatchItem) error {
+		failed <- struct{}{}
+		return errors.New("failed " + item.ID)
+	}
+	go func() {
+		<-failed
+		w.Cancel()
+	}()
+
+	start := time.Now()
+	err := w.processBatchItems(batch, handler)
+	_, ok := err.(*OperationCancelledError)
+	assert.True(t, ok, "err is %T", err)
+	assert.True(t, time.Since(start) < time.Minute)
+}
@@ -11,6 +11,16 @@ type SubscriptionWorkerOptions struct {
 	MaxDocsPerBatch                 int                         `json:"MaxDocsPerBatch"`
 	MaxErroneousPeriod              Duration                    `json:"MaxErroneousPeriod"`
 	CloseWhenNoDocsLeft             bool                        `json:"CloseWhenNoDocsLeft"`
+
+	// MaxConcurrency is the maximum number of items of a batch processed
+	// at the same time by SubscriptionWorker.RunItems. Values < 1 mean 1
+	MaxConcurrency int `json:"-"`
+	// MaxItemRetries is how many times SubscriptionWorker.RunItems retries
+	// an item that failed before giving up on the batch
+	MaxItemRetries int `json:"-"`
+	// ItemRetryDelay is time to wait before retrying failed items. Cancel and
+	// Close stop the wait
+	ItemRetryDelay Duration `json:"-"`
 }
 
 // NewSubscriptionWorkerOptions returns new SubscriptionWorkerOptions
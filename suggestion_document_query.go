@@ -40,7 +40,7 @@ func (q *SuggestionDocumentQuery) Execute() (map[string]*SuggestionResult, error
 	if err = q.session.incrementRequestCount(); err != nil {
 		return nil, err
 	}
-	if err = q.session.GetRequestExecutor().ExecuteCommand(command, nil); err != nil {
+	if err = q.session.GetRequestExecutor().ExecuteCommandWithContext(q.session.ctx, command, nil); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func backupTestCanBackupAndRestore(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	backupDir, err := ioutil.TempDir("", "ravendb-backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("Marcin")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	config := ravendb.NewLocalPeriodicBackupConfiguration("backup", ravendb.BackupTypeBackup, backupDir)
+	config.FullBackupFrequency = "0 2 * * *"
+	updateOp := ravendb.NewUpdatePeriodicBackupOperation(config)
+	err = store.Maintenance().Send(updateOp)
+	assert.NoError(t, err)
+	taskID := updateOp.Command.Result.TaskID
+	assert.True(t, taskID > 0)
+
+	{
+		op := ravendb.NewGetDatabaseRecordOperation(store.GetDatabase())
+		err = store.Maintenance().Server().Send(op)
+		assert.NoError(t, err)
+		backups := op.Command.Result.PeriodicBackups
+		assert.Equal(t, 1, len(backups))
+		assert.Equal(t, taskID, backups[0].TaskID)
+		assert.Equal(t, backupDir, backups[0].LocalSettings.FolderPath)
+	}
+
+	startOp := ravendb.NewStartBackupOperation(true, taskID)
+	operation, err := store.Maintenance().SendAsync(startOp)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, startOp.Command.Result.ResponsibleNode)
+	err = operation.WaitForCompletion()
+	assert.NoError(t, err)
+
+	statusOp := ravendb.NewGetPeriodicBackupStatusOperation(taskID)
+	err = store.Maintenance().Send(statusOp)
+	assert.NoError(t, err)
+	status := statusOp.Command.Result.Status
+	assert.NotNil(t, status)
+	assert.True(t, status.IsFull)
+	assert.Nil(t, status.Error)
+	assert.NotNil(t, status.LastFullBackup)
+	assert.NotEmpty(t, status.LocalBackup.BackupDirectory)
+
+	restoredName := store.GetDatabase() + "_restored"
+	restoreConfig := &ravendb.RestoreBackupConfiguration{
+		DatabaseName:   restoredName,
+		BackupLocation: status.LocalBackup.BackupDirectory,
+	}
+	restoreOp := ravendb.NewRestoreBackupOperation(restoreConfig)
+	operation, err = store.Maintenance().Server().SendAsync(restoreOp)
+	assert.NoError(t, err)
+	err = operation.WaitForCompletion()
+	assert.NoError(t, err)
+	defer func() {
+		_ = store.Maintenance().Server().Send(ravendb.NewDeleteDatabasesOperation(restoredName, true))
+	}()
+
+	{
+		session, err := store.OpenSessionWithOptions(&ravendb.SessionOptions{
+			Database: restoredName,
+		})
+		assert.NoError(t, err)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		assert.Equal(t, "Marcin", *user.Name)
+		session.Close()
+	}
+}
+
+func TestBackup(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	backupTestCanBackupAndRestore(t, driver)
+}
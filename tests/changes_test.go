@@ -410,6 +410,51 @@ func changesTestNotificationOnWrongDatabaseShouldNotCrashServer(t *testing.T, dr
 	assert.NoError(t, err)
 }
 
+func changesTestDocumentsInCollectionChan(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	changes := store.Changes("")
+	err = changes.EnsureConnectedNow()
+	assert.NoError(t, err)
+
+	opts := &ravendb.ChangesChannelOptions{
+		BufferSize:     16,
+		OverflowPolicy: ravendb.ChangesOverflowDropOldest,
+	}
+	chChanges, cancel, err := changes.ForDocumentsInCollectionChan("Users", opts)
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		err = session.StoreWithID(&User{}, "users/1")
+		assert.NoError(t, err)
+		err = session.StoreWithID(&Employee{}, "employees/1")
+		assert.NoError(t, err)
+		err = session.StoreWithID(&User{}, "users/2")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	for _, expID := range []string{"users/1", "users/2"} {
+		select {
+		case change := <-chChanges:
+			assert.Equal(t, expID, change.ID)
+			assert.Equal(t, ravendb.DocumentChangePut, change.Type)
+		case <-time.After(_reasonableWaitTime):
+			assert.Fail(t, "timed out waiting for changes")
+		}
+	}
+
+	cancel()
+	// channel is closed after cancel
+	for range chChanges {
+	}
+}
+
 func TestChanges(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -426,4 +471,5 @@ func TestChanges(t *testing.T) {
 	// TODO: order different than Java's
 	changesTestCanCanNotificationAboutDocumentsStartingWiths(t, driver)
 	changesTestCanCanNotificationAboutDocumentsFromCollection(t, driver)
+	changesTestDocumentsInCollectionChan(t, driver)
 }
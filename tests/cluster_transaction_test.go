@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	ravendb "github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func openClusterSessionMust(t *testing.T, store *ravendb.DocumentStore) *ravendb.DocumentSession {
+	session, err := store.OpenSessionWithOptions(&ravendb.SessionOptions{
+		TransactionMode: ravendb.TransactionModeClusterWide,
+	})
+	assert.NoError(t, err)
+	return session
+}
+
+func clusterTransactionCanCreateClusterTransactionRequest(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openClusterSessionMust(t, store)
+		user := &User{}
+		user.setName("Karmel")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+
+		ops, err := session.Advanced().ClusterTransaction()
+		assert.NoError(t, err)
+		err = ops.CreateCompareExchangeValue("usernames/ayende", "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		res, err := ops.GetCompareExchangeValue(reflect.TypeOf(""), "usernames/ayende")
+		assert.NoError(t, err)
+		assert.Equal(t, "users/1", res.Value)
+		session.Close()
+	}
+
+	{
+		// creating the same compare exchange value must fail the whole transaction
+		session := openClusterSessionMust(t, store)
+		user := &User{}
+		user.setName("Other")
+		err = session.StoreWithID(user, "users/2")
+		assert.NoError(t, err)
+		ops, err := session.ClusterTransaction()
+		assert.NoError(t, err)
+		err = ops.CreateCompareExchangeValue("usernames/ayende", "users/2")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.Error(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/2")
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+		session.Close()
+	}
+}
+
+func clusterTransactionCanDeleteCompareExchangeValue(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openClusterSessionMust(t, store)
+		ops, err := session.ClusterTransaction()
+		assert.NoError(t, err)
+		err = ops.CreateCompareExchangeValue("usernames/ayende", "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openClusterSessionMust(t, store)
+		ops, err := session.ClusterTransaction()
+		assert.NoError(t, err)
+		res, err := ops.GetCompareExchangeValue(reflect.TypeOf(""), "usernames/ayende")
+		assert.NoError(t, err)
+		err = ops.DeleteCompareExchangeValueItem(res)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		res, err = ops.GetCompareExchangeValue(reflect.TypeOf(""), "usernames/ayende")
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		_, err = session.ClusterTransaction()
+		assert.Error(t, err)
+		session.Close()
+	}
+}
+
+func TestClusterTransaction(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	clusterTransactionCanCreateClusterTransactionRequest(t, driver)
+	clusterTransactionCanDeleteCompareExchangeValue(t, driver)
+}
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ravendb "github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func countersIncrementAndGet(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("Aviv")
+		err = session.StoreWithID(user, "users/1-A")
+		assert.NoError(t, err)
+
+		counters, err := session.CountersFor(user)
+		assert.NoError(t, err)
+		err = counters.Increment("likes", 10)
+		assert.NoError(t, err)
+		err = counters.Increment("likes", 5)
+		assert.NoError(t, err)
+		err = counters.Increment("dislikes", 1)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		// values are known from SaveChanges result
+		n := session.Advanced().GetNumberOfRequests()
+		v, err := counters.Get("likes")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), *v)
+		assert.Equal(t, n, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		counters, err := session.CountersForDocumentID("users/1-A")
+		assert.NoError(t, err)
+		v, err := counters.Get("LIKES")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), *v)
+
+		v, err = counters.Get("missing")
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+
+		all, err := counters.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(all))
+		assert.Equal(t, int64(1), all["dislikes"])
+
+		// GetAll caches all values
+		n := session.Advanced().GetNumberOfRequests()
+		m, err := counters.GetMulti([]string{"likes", "dislikes", "other"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), *m["likes"])
+		assert.Equal(t, int64(1), *m["dislikes"])
+		assert.Nil(t, m["other"])
+		assert.Equal(t, n, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		counters, err := session.CountersForDocumentID("users/1-A")
+		assert.NoError(t, err)
+		err = counters.Delete("dislikes")
+		assert.NoError(t, err)
+		err = counters.Increment("dislikes", 1)
+		assert.Error(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		op := ravendb.NewGetCountersOperation("users/1-A")
+		err = store.Operations().Send(op, nil)
+		assert.NoError(t, err)
+		res := op.Command.Result
+		assert.Equal(t, 1, len(res.Counters))
+		assert.Equal(t, "likes", res.Counters[0].CounterName)
+		assert.Equal(t, int64(15), res.Counters[0].TotalValue)
+	}
+}
+
+func countersBatchOperation(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		err = session.StoreWithID(&User{}, "users/1-A")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	batch := &ravendb.CounterBatch{
+		Documents: []*ravendb.DocumentCountersOperation{
+			{
+				DocumentID: "users/1-A",
+				Operations: []*ravendb.CounterOperation{
+					{
+						Type:        ravendb.CounterOperationTypeIncrement,
+						CounterName: "likes",
+						Delta:       3,
+					},
+					{
+						Type:        ravendb.CounterOperationTypeIncrement,
+						CounterName: "likes",
+						Delta:       4,
+					},
+				},
+			},
+		},
+	}
+	op := ravendb.NewCounterBatchOperation(batch)
+	err = store.Operations().Send(op, nil)
+	assert.NoError(t, err)
+	res := op.Command.Result
+	assert.Equal(t, 2, len(res.Counters))
+	assert.Equal(t, int64(7), res.Counters[1].TotalValue)
+}
+
+func countersIncludes(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("Oren")
+		err = session.StoreWithID(user, "users/1-A")
+		assert.NoError(t, err)
+		counters, err := session.CountersFor(user)
+		assert.NoError(t, err)
+		err = counters.Increment("likes", 100)
+		assert.NoError(t, err)
+		err = counters.Increment("downloads", 3)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.IncludeCounter("likes").IncludeCounter("missing").Load(&user, "users/1-A")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+
+		counters, err := session.CountersFor(user)
+		assert.NoError(t, err)
+		v, err := counters.Get("likes")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), *v)
+		v, err = counters.Get("missing")
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		q := session.QueryCollectionForType(reflect.TypeOf(&User{})).IncludeAllCounters()
+		iq, err := q.GetIndexQuery()
+		assert.NoError(t, err)
+		assert.Equal(t, "from Users include counters()", iq.GetQuery())
+
+		var users []*User
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+
+		counters, err := session.CountersFor(users[0])
+		assert.NoError(t, err)
+		all, err := counters.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(all))
+		assert.Equal(t, int64(3), all["downloads"])
+		assert.Equal(t, 1, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		q := session.QueryCollectionForType(reflect.TypeOf(&User{})).Include("friendId").IncludeCounter("likes")
+		iq, err := q.GetIndexQuery()
+		assert.NoError(t, err)
+		assert.Equal(t, "from Users include friendId,counters('likes')", iq.GetQuery())
+		session.Close()
+	}
+}
+
+func countersChanges(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	changes := store.Changes("")
+	err = changes.EnsureConnectedNow()
+	assert.NoError(t, err)
+	defer changes.Close()
+
+	chChanges := make(chan *ravendb.CounterChange, 8)
+	cancel, err := changes.ForCounterOfDocument("users/1-A", "likes", func(change *ravendb.CounterChange) {
+		chChanges <- change
+	})
+	assert.NoError(t, err)
+	defer cancel()
+
+	{
+		session := openSessionMust(t, store)
+		err = session.StoreWithID(&User{}, "users/1-A")
+		assert.NoError(t, err)
+		counters, err := session.CountersForDocumentID("users/1-A")
+		assert.NoError(t, err)
+		err = counters.Increment("likes", 1)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	select {
+	case change := <-chChanges:
+		assert.Equal(t, "users/1-A", change.DocumentID)
+		assert.Equal(t, "likes", change.Name)
+		assert.Equal(t, int64(1), change.Value)
+		assert.Equal(t, ravendb.CounterChangePut, change.Type)
+	case <-time.After(_reasonableWaitTime):
+		assert.Fail(t, "timed out waiting for counter change")
+	}
+}
+
+func TestCounters(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	countersIncrementAndGet(t, driver)
+	countersBatchOperation(t, driver)
+	countersIncludes(t, driver)
+	countersChanges(t, driver)
+}
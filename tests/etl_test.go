@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForEtlDocument(t *testing.T, store *ravendb.DocumentStore, id string, timeout time.Duration) *User {
+	start := time.Now()
+	for time.Since(start) < timeout {
+		session := openSessionMust(t, store)
+		var user *User
+		err := session.Load(&user, id)
+		session.Close()
+		assert.NoError(t, err)
+		if user != nil {
+			return user
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	return nil
+}
+
+func etlTestCanAddUpdateResetAndDeleteRavenEtl(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	src := driver.getDocumentStoreMust(t)
+	defer src.Close()
+	dst := driver.getDocumentStoreMust(t)
+	defer dst.Close()
+
+	connectionString := ravendb.NewRavenConnectionString()
+	connectionString.Name = "to-dst"
+	connectionString.Database = dst.GetDatabase()
+	connectionString.TopologyDiscoveryUrls = dst.GetUrls()
+	err = src.Maintenance().Send(ravendb.NewPutConnectionStringOperation(connectionString))
+	assert.NoError(t, err)
+
+	config := ravendb.NewRavenEtlConfiguration()
+	config.Name = "users-etl"
+	config.ConnectionStringName = connectionString.Name
+	config.Transforms = []*ravendb.Transformation{
+		{
+			Name:        "users",
+			Collections: []string{"Users"},
+			Script:      "this.name = this.name.toUpperCase(); loadToUsers(this);",
+		},
+	}
+	addOp := ravendb.NewAddEtlOperation(config)
+	err = src.Maintenance().Send(addOp)
+	assert.NoError(t, err)
+	taskID := addOp.Command.Result.TaskID
+	assert.True(t, taskID > 0)
+
+	{
+		session := openSessionMust(t, src)
+		user := &User{}
+		user.setName("joe")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	user := waitForEtlDocument(t, dst, "users/1", time.Second*10)
+	assert.NotNil(t, user)
+	assert.Equal(t, "JOE", *user.Name)
+
+	infoOp := ravendb.NewGetOngoingTaskInfoOperation(taskID, ravendb.OngoingTaskTypeRavenEtl)
+	err = src.Maintenance().Send(infoOp)
+	assert.NoError(t, err)
+	details := infoOp.Command.Result.(*ravendb.OngoingTaskRavenEtlDetails)
+	assert.Equal(t, "users-etl", details.TaskName)
+	assert.Equal(t, ravendb.OngoingTaskStateEnabled, details.TaskState)
+	assert.Equal(t, dst.GetDatabase(), details.DestinationDatabase)
+	assert.Equal(t, 1, len(details.Configuration.Transforms))
+
+	config.TaskID = taskID
+	config.Transforms[0].Script = "loadToUsers(this);"
+	updateOp := ravendb.NewUpdateEtlOperation(taskID, config)
+	err = src.Maintenance().Send(updateOp)
+	assert.NoError(t, err)
+	taskID = updateOp.Command.Result.TaskID
+
+	err = src.Maintenance().Send(ravendb.NewResetEtlOperation("users-etl", "users"))
+	assert.NoError(t, err)
+
+	deleteOp := ravendb.NewDeleteOngoingTaskOperation(taskID, ravendb.OngoingTaskTypeRavenEtl)
+	err = src.Maintenance().Send(deleteOp)
+	assert.NoError(t, err)
+
+	infoOp = ravendb.NewGetOngoingTaskInfoOperation(taskID, ravendb.OngoingTaskTypeRavenEtl)
+	err = src.Maintenance().Send(infoOp)
+	assert.NoError(t, err)
+	assert.Nil(t, infoOp.Command.Result)
+}
+
+func TestEtl(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	etlTestCanAddUpdateResetAndDeleteRavenEtl(t, driver)
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func expirationTestCanDeleteExpiredDocument(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	frequency := int64(1)
+	configureOp := ravendb.NewConfigureExpirationOperation(&ravendb.ExpirationConfiguration{
+		DeleteFrequencyInSec: &frequency,
+	})
+	err = store.Maintenance().Send(configureOp)
+	assert.NoError(t, err)
+	assert.True(t, configureOp.Command.Result.RaftCommandIndex > 0)
+
+	expires := time.Now().Add(time.Second)
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("token")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.Advanced().SetExpiration(user, expires)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		got, err := session.Advanced().GetExpiration(user)
+		assert.NoError(t, err)
+		assert.Equal(t, expires.UTC().Truncate(time.Millisecond), got.Truncate(time.Millisecond))
+		session.Close()
+	}
+
+	deleted := false
+	for start := time.Now(); time.Since(start) < time.Second*15; {
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		session.Close()
+		assert.NoError(t, err)
+		if user == nil {
+			deleted = true
+			break
+		}
+		time.Sleep(time.Millisecond * 200)
+	}
+	assert.True(t, deleted)
+}
+
+func expirationTestCanRefreshDocument(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	frequency := int64(1)
+	err = store.Maintenance().Send(ravendb.NewConfigureRefreshOperation(&ravendb.RefreshConfiguration{
+		RefreshFrequencyInSec: &frequency,
+	}))
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("refreshed")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+		err = session.Advanced().SetRefresh(user, time.Now())
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	// the server removes @refresh when the document is refreshed
+	refreshed := false
+	for start := time.Now(); time.Since(start) < time.Second*15; {
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.Load(&user, "users/1")
+		assert.NoError(t, err)
+		refresh, err := session.Advanced().GetRefresh(user)
+		assert.NoError(t, err)
+		session.Close()
+		if refresh == nil {
+			refreshed = true
+			break
+		}
+		time.Sleep(time.Millisecond * 200)
+	}
+	assert.True(t, refreshed)
+}
+
+func TestExpiration(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	expirationTestCanDeleteExpiredDocument(t, driver)
+	expirationTestCanRefreshDocument(t, driver)
+}
@@ -17,6 +17,41 @@ func getDatabaseRecordCanGetDatabaseRecord(t *testing.T, driver *RavenTestDriver
 	assert.Equal(t, op.Command.Result.DatabaseName, store.GetDatabase())
 }
 
+func getDatabaseRecordCanUpdateDatabaseRecord(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	getRecord := func() *ravendb.DatabaseRecordWithEtag {
+		op := ravendb.NewGetDatabaseRecordOperation(store.GetDatabase())
+		err = store.Maintenance().Server().Send(op)
+		assert.NoError(t, err)
+		return op.Command.Result
+	}
+
+	record := getRecord()
+	assert.NotNil(t, record.Topology)
+	assert.Equal(t, 1, len(record.Topology.Members))
+	assert.True(t, record.Etag > 0)
+
+	deleteFrequency := int64(60)
+	record.Expiration = &ravendb.ExpirationConfiguration{
+		DeleteFrequencyInSec: &deleteFrequency,
+	}
+	err = store.Maintenance().Server().Send(ravendb.NewUpdateDatabaseRecordOperation(record))
+	assert.NoError(t, err)
+
+	updated := getRecord()
+	assert.True(t, updated.Etag > record.Etag)
+	assert.Equal(t, int64(60), *updated.Expiration.DeleteFrequencyInSec)
+
+	// record was modified since it was read
+	record.Expiration.Disabled = true
+	err = store.Maintenance().Server().Send(ravendb.NewUpdateDatabaseRecordOperation(record))
+	_, ok := err.(*ravendb.ConcurrencyError)
+	assert.True(t, ok)
+}
+
 func TestGetDatabaseRecord(t *testing.T) {
 	driver := createTestDriver(t)
 	destroy := func() { destroyDriver(t, driver) }
@@ -24,4 +59,5 @@ func TestGetDatabaseRecord(t *testing.T) {
 
 	// matches order of Java tests
 	getDatabaseRecordCanGetDatabaseRecord(t, driver)
+	getDatabaseRecordCanUpdateDatabaseRecord(t, driver)
 }
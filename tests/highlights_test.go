@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPostsByDescIndex() *ravendb.IndexCreationTask {
+	res := ravendb.NewIndexCreationTask("Posts/ByDesc")
+	res.Map = "from post in docs.Posts select new { post.desc }"
+	res.Store("desc", ravendb.FieldStorageYes)
+	res.Index("desc", ravendb.FieldIndexingSearch)
+	res.TermVector("desc", ravendb.FieldTermVectorWithPositionsAndOffsets)
+	return res
+}
+
+func highlightsCanHighlightSearchResults(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	index := newPostsByDescIndex()
+	err = index.Execute(store, nil, "")
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		posts := []*Post{
+			{ID: "posts/1", Desc: "RavenDB is a NoSQL document database"},
+			{ID: "posts/2", Desc: "a post about something else"},
+			{ID: "posts/3", Desc: "the go client of the database"},
+		}
+		for _, post := range posts {
+			err = session.Store(post)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+	err = driver.waitForIndexing(store, store.GetDatabase(), 0)
+	assert.NoError(t, err)
+
+	{
+		session := openSessionMust(t, store)
+		var highlightings *ravendb.Highlightings
+		options := &ravendb.HighlightingOptions{
+			PreTags:  []string{"<b>"},
+			PostTags: []string{"</b>"},
+		}
+		q := session.QueryIndex(index.IndexName)
+		q = q.Search("desc", "database").Highlight("desc", 128, 1, options, &highlightings)
+		var posts []*Post
+		err = q.GetResults(&posts)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(posts))
+
+		assert.Equal(t, []string{"posts/1", "posts/3"}, highlightings.GetResultIndents())
+		fragments := highlightings.GetFragments("posts/1")
+		assert.Equal(t, 1, len(fragments))
+		assert.True(t, strings.Contains(fragments[0], "<b>database</b>"))
+		assert.Nil(t, highlightings.GetFragments("posts/2"))
+		session.Close()
+	}
+}
+
+func TestHighlights(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	highlightsCanHighlightSearchResults(t, driver)
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryTimingsCanIncludeTimingsAndExplanations(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		for _, name := range []string{"John", "Jane", "John Doe"} {
+			user := &User{}
+			user.setName(name)
+			err = session.Store(user)
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var timings *ravendb.QueryTimings
+		var explanations *ravendb.Explanations
+		q := session.QueryCollectionForType(userType)
+		q = q.Search("name", "john").WaitForNonStaleResults(0)
+		q = q.Timings(&timings).IncludeExplanations(nil, &explanations)
+		var users []*User
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(users))
+
+		assert.True(t, timings.DurationInMs >= 0)
+		assert.NotNil(t, timings.Timings["Query"])
+
+		for _, user := range users {
+			assert.NotEmpty(t, explanations.GetExplanations(user.ID))
+		}
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var timings *ravendb.QueryTimings
+		q := session.RawQuery("from Users where name = 'Jane'").Timings(&timings)
+		var users []*User
+		err = q.GetResults(&users)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(users))
+		assert.NotNil(t, timings.Timings)
+		session.Close()
+	}
+}
+
+func TestQueryTimings(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	queryTimingsCanIncludeTimingsAndExplanations(t, driver)
+}
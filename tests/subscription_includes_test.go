@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func subscriptionIncludes_shouldLoadIncludedDocumentsWithoutRequests(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		company := &Company{
+			Name: "HR",
+		}
+		err = session.StoreWithID(company, "companies/1")
+		assert.NoError(t, err)
+		order := &Order{
+			Company: "companies/1",
+			Freight: 20,
+		}
+		err = session.StoreWithID(order, "orders/1")
+		assert.NoError(t, err)
+		// filtered out
+		order = &Order{
+			Company: "companies/1",
+			Freight: 5,
+		}
+		err = session.StoreWithID(order, "orders/2")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	query, err := ravendb.NewSubscriptionQueryBuilder("Orders").
+		Where("doc.freight > 10").
+		Include("company").
+		GetQuery()
+	assert.NoError(t, err)
+	opts := &ravendb.SubscriptionCreationOptions{
+		Query: query,
+	}
+	id, err := store.Subscriptions().Create(opts, "")
+	assert.NoError(t, err)
+
+	wopts := ravendb.NewSubscriptionWorkerOptions(id)
+	subscription, err := store.Subscriptions().GetSubscriptionWorker(reflect.TypeOf(&Order{}), wopts, "")
+	assert.NoError(t, err)
+
+	results := make(chan string, 16)
+	err = subscription.Run(func(batch *ravendb.SubscriptionBatch) error {
+		session, err := batch.OpenSession()
+		if err != nil {
+			return err
+		}
+		defer session.Close()
+		for _, item := range batch.Items {
+			var order *Order
+			if err := item.GetResult(&order); err != nil {
+				return err
+			}
+			var company *Company
+			if err := session.Load(&company, order.Company); err != nil {
+				return err
+			}
+			assert.Equal(t, 0, session.Advanced().GetNumberOfRequests())
+			results <- item.ID + ":" + company.Name
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	select {
+	case res := <-results:
+		assert.Equal(t, "orders/1:HR", res)
+	case <-time.After(_reasonableWaitTime):
+		assert.Fail(t, "timed out waiting for batch")
+	}
+
+	err = subscription.Close()
+	assert.NoError(t, err)
+}
+
+func TestSubscriptionIncludes(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	subscriptionIncludes_shouldLoadIncludedDocumentsWithoutRequests(t, driver)
+}
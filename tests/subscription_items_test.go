@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func subscriptionItems_shouldProcessAllItemsConcurrently(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	id, err := store.Subscriptions().CreateForType(reflect.TypeOf(&User{}), nil, "")
+	assert.NoError(t, err)
+
+	const nUsers = 20
+	{
+		session := openSessionMust(t, store)
+		for i := 0; i < nUsers; i++ {
+			user := &User{
+				Age: i,
+			}
+			err = session.StoreWithID(user, fmt.Sprintf("users/%d", i))
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	opts := ravendb.NewSubscriptionWorkerOptions(id)
+	opts.MaxConcurrency = 4
+	opts.MaxItemRetries = 2
+	opts.ItemRetryDelay = ravendb.Duration(time.Millisecond * 10)
+	subscription, err := store.Subscriptions().GetSubscriptionWorker(reflect.TypeOf(&User{}), opts, "")
+	assert.NoError(t, err)
+
+	var mu sync.Mutex
+	failed := map[string]bool{}
+	processed := make(chan string, nUsers*2)
+	err = subscription.RunItems(func(batch *ravendb.SubscriptionBatch, item *ravendb.SubscriptionBatchItem) error {
+		var u *User
+		if err := item.GetResult(&u); err != nil {
+			return err
+		}
+		mu.Lock()
+		// fail every item once to exercise retries
+		firstTry := !failed[item.ID]
+		failed[item.ID] = true
+		mu.Unlock()
+		if firstTry {
+			return fmt.Errorf("failing %s on the first try", item.ID)
+		}
+		processed <- item.ID
+		return nil
+	})
+	assert.NoError(t, err)
+
+	seen := map[string]bool{}
+loop:
+	for len(seen) < nUsers {
+		select {
+		case docID := <-processed:
+			seen[docID] = true
+		case <-time.After(_reasonableWaitTime):
+			assert.Fail(t, "timed out waiting for items")
+			break loop
+		}
+	}
+	assert.Equal(t, nUsers, len(seen))
+
+	err = subscription.Close()
+	assert.NoError(t, err)
+}
+
+func TestSubscriptionItems(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	subscriptionItems_shouldProcessAllItemsConcurrently(t, driver)
+}
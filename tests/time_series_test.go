@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ravendb "github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+var timeSeriesBaseLine = time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+
+func timeSeriesAppendAndGet(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		user := &User{}
+		user.setName("Oren")
+		err = session.StoreWithID(user, "users/1")
+		assert.NoError(t, err)
+
+		ts, err := session.TimeSeriesFor(user, "HeartRate")
+		assert.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			err = ts.AppendWithTag(timeSeriesBaseLine.Add(time.Duration(i)*time.Minute), "watches/fitbit", float64(60+i))
+			assert.NoError(t, err)
+		}
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		names, err := session.GetTimeSeriesNames(user)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"HeartRate"}, names)
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		ts, err := session.TimeSeriesForDocumentID("users/1", "HeartRate")
+		assert.NoError(t, err)
+
+		entries, err := ts.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, 10, len(entries))
+		assert.Equal(t, 60.0, entries[0].GetValue())
+		assert.Equal(t, "watches/fitbit", entries[0].Tag)
+		assert.True(t, entries[0].GetTime().Equal(timeSeriesBaseLine))
+
+		// served from the session cache
+		n := session.Advanced().GetNumberOfRequests()
+		from := timeSeriesBaseLine.Add(2 * time.Minute)
+		to := timeSeriesBaseLine.Add(4 * time.Minute)
+		entries, err = ts.Get(&from, &to, 0, 1024*1024*1024)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(entries))
+		assert.Equal(t, n, session.Advanced().GetNumberOfRequests())
+
+		entries, err = ts.Get(nil, nil, 5, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, 65.0, entries[0].GetValue())
+
+		err = ts.Delete(nil, &from)
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+
+		entries, err = ts.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, 7, len(entries))
+
+		missing, err := session.TimeSeriesForDocumentID("users/1", "Missing")
+		assert.NoError(t, err)
+		entries, err = missing.GetAll()
+		assert.NoError(t, err)
+		assert.Nil(t, entries)
+		session.Close()
+	}
+}
+
+func timeSeriesOperations(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		session := openSessionMust(t, store)
+		err = session.StoreWithID(&User{}, "users/1")
+		assert.NoError(t, err)
+		err = session.SaveChanges()
+		assert.NoError(t, err)
+		session.Close()
+	}
+
+	tsOp := ravendb.NewTimeSeriesOperation("Heartrate")
+	tsOp.Append(timeSeriesBaseLine, "", 59)
+	tsOp.Append(timeSeriesBaseLine.Add(time.Second), "", 60)
+	tsOp.Append(timeSeriesBaseLine.Add(2*time.Second), "", 61)
+	op, err := ravendb.NewTimeSeriesBatchOperation("users/1", tsOp)
+	assert.NoError(t, err)
+	err = store.Operations().Send(op, nil)
+	assert.NoError(t, err)
+
+	getOp, err := ravendb.NewGetTimeSeriesOperation("users/1", "Heartrate", nil, nil)
+	assert.NoError(t, err)
+	err = store.Operations().Send(getOp, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(getOp.Command.Result.Entries))
+
+	to := timeSeriesBaseLine.Add(time.Second)
+	getMultiOp, err := ravendb.NewGetMultipleTimeSeriesOperation("users/1", []*ravendb.TimeSeriesRange{
+		{Name: "Heartrate", To: &to},
+	})
+	assert.NoError(t, err)
+	err = store.Operations().Send(getMultiOp, nil)
+	assert.NoError(t, err)
+	ranges := getMultiOp.Command.Result.Values["Heartrate"]
+	assert.Equal(t, 1, len(ranges))
+	assert.Equal(t, 2, len(ranges[0].Entries))
+}
+
+func timeSeriesIncludesAndQueries(t *testing.T, driver *RavenTestDriver) {
+	var err error
+	store := driver.getDocumentStoreMust(t)
+	defer store.Close()
+
+	{
+		bulkInsert := store.BulkInsert("")
+		err = bulkInsert.StoreWithID(&User{Age: 30}, "users/1", nil)
+		assert.NoError(t, err)
+		ts, err := bulkInsert.TimeSeriesFor("users/1", "HeartRate")
+		assert.NoError(t, err)
+		for i := 0; i < 120; i++ {
+			err = ts.Append(timeSeriesBaseLine.Add(time.Duration(i)*time.Minute), float64(i%60))
+			assert.NoError(t, err)
+		}
+		err = bulkInsert.Close()
+		assert.NoError(t, err)
+	}
+
+	{
+		session := openSessionMust(t, store)
+		var user *User
+		err = session.IncludeTimeSeries("HeartRate", nil, nil).Load(&user, "users/1")
+		assert.NoError(t, err)
+		assert.NotNil(t, user)
+
+		n := session.Advanced().GetNumberOfRequests()
+		ts, err := session.TimeSeriesFor(user, "HeartRate")
+		assert.NoError(t, err)
+		entries, err := ts.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, 120, len(entries))
+		assert.Equal(t, n, session.Advanced().GetNumberOfRequests())
+		session.Close()
+	}
+
+	{
+		session := openSessionMust(t, store)
+		q := session.QueryCollectionForType(reflect.TypeOf(&User{}))
+		q = q.SelectTimeSeries(reflect.TypeOf(&ravendb.TimeSeriesAggregationResult{}), "from HeartRate group by '1 hour' select max(), min()")
+		var results []*ravendb.TimeSeriesAggregationResult
+		err = q.GetResults(&results)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, int64(120), results[0].Count)
+		assert.Equal(t, 2, len(results[0].Results))
+		assert.Equal(t, []float64{59}, results[0].Results[0].Max)
+		assert.Equal(t, []float64{0}, results[0].Results[0].Min)
+		session.Close()
+	}
+}
+
+func TestTimeSeries(t *testing.T) {
+	driver := createTestDriver(t)
+	destroy := func() { destroyDriver(t, driver) }
+	defer recoverTest(t, destroy)
+
+	timeSeriesAppendAndGet(t, driver)
+	timeSeriesOperations(t, driver)
+	timeSeriesIncludesAndQueries(t, driver)
+}
@@ -0,0 +1,49 @@
+package ravendb
+
+import "time"
+
+var _ ICommandData = &TimeSeriesBatchCommandData{} // verify interface match
+
+// TimeSeriesBatchCommandData represents data for a batch command that
+// appends to and deletes from a time series of a document
+type TimeSeriesBatchCommandData struct {
+	CommandData
+
+	TimeSeries *TimeSeriesOperation
+}
+
+// NewTimeSeriesBatchCommandData creates ICommandData for operations on
+// a time series of a document with a given id
+func NewTimeSeriesBatchCommandData(documentID string, name string, appends []*TimeSeriesAppendOperation, deletes []*TimeSeriesDeleteOperation) (*TimeSeriesBatchCommandData, error) {
+	if stringIsBlank(documentID) {
+		return nil, newIllegalArgumentError("DocumentId cannot be empty")
+	}
+	if stringIsBlank(name) {
+		return nil, newIllegalArgumentError("Name cannot be empty")
+	}
+
+	op := NewTimeSeriesOperation(name)
+	for _, a := range appends {
+		op.Append(time.Time(a.Timestamp), a.Tag, a.Values...)
+	}
+	op.Deletes = append(op.Deletes, deletes...)
+
+	res := &TimeSeriesBatchCommandData{
+		CommandData: CommandData{
+			ID:   documentID,
+			Name: name,
+			Type: CommandTimeSeries,
+		},
+		TimeSeries: op,
+	}
+	return res, nil
+}
+
+func (d *TimeSeriesBatchCommandData) serialize(conventions *DocumentConventions) (interface{}, error) {
+	res := map[string]interface{}{
+		"Id":         d.ID,
+		"TimeSeries": d.TimeSeries,
+		"Type":       CommandTimeSeries,
+	}
+	return res, nil
+}
@@ -0,0 +1,69 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IOperation = &TimeSeriesBatchOperation{}
+)
+
+// TimeSeriesBatchOperation appends to and deletes from a time series
+// of a document
+type TimeSeriesBatchOperation struct {
+	documentID string
+	operation  *TimeSeriesOperation
+
+	Command *TimeSeriesBatchCommand
+}
+
+// NewTimeSeriesBatchOperation returns new TimeSeriesBatchOperation
+func NewTimeSeriesBatchOperation(documentID string, operation *TimeSeriesOperation) (*TimeSeriesBatchOperation, error) {
+	if stringIsBlank(documentID) {
+		return nil, newIllegalArgumentError("Document id cannot be empty")
+	}
+	if operation == nil {
+		return nil, newIllegalArgumentError("Operation cannot be nil")
+	}
+	return &TimeSeriesBatchOperation{
+		documentID: documentID,
+		operation:  operation,
+	}, nil
+}
+
+func (o *TimeSeriesBatchOperation) GetCommand(store *DocumentStore, conventions *DocumentConventions, cache *httpCache) (RavenCommand, error) {
+	o.Command = NewTimeSeriesBatchCommand(o.documentID, o.operation)
+	return o.Command, nil
+}
+
+var _ RavenCommand = &TimeSeriesBatchCommand{}
+
+// TimeSeriesBatchCommand is a command for TimeSeriesBatchOperation
+type TimeSeriesBatchCommand struct {
+	RavenCommandBase
+
+	documentID string
+	operation  *TimeSeriesOperation
+}
+
+// NewTimeSeriesBatchCommand returns new TimeSeriesBatchCommand
+func NewTimeSeriesBatchCommand(documentID string, operation *TimeSeriesOperation) *TimeSeriesBatchCommand {
+	cmd := &TimeSeriesBatchCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		documentID: documentID,
+		operation:  operation,
+	}
+	cmd.ResponseType = RavenCommandResponseTypeEmpty
+	return cmd
+}
+
+func (c *TimeSeriesBatchCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/timeseries?docId=" + urlUtilsEscapeDataString(c.documentID)
+
+	d, err := jsonMarshal(c.operation)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPost(url, d)
+}
@@ -0,0 +1,87 @@
+package ravendb
+
+import (
+	"time"
+)
+
+// TimeSeriesEntry is a single entry of a time series
+type TimeSeriesEntry struct {
+	Timestamp Time      `json:"Timestamp"`
+	Tag       string    `json:"Tag"`
+	Values    []float64 `json:"Values"`
+	IsRollup  bool      `json:"IsRollup"`
+}
+
+// GetTime returns Timestamp as time.Time
+func (e *TimeSeriesEntry) GetTime() time.Time {
+	return time.Time(e.Timestamp)
+}
+
+// GetValue returns the first value of the entry, which is convenient for
+// time series with a single value per entry
+func (e *TimeSeriesEntry) GetValue() float64 {
+	if len(e.Values) == 0 {
+		return 0
+	}
+	return e.Values[0]
+}
+
+// TimeSeriesRangeResult describes entries of a time series in a time range
+type TimeSeriesRangeResult struct {
+	// From and To are nil if the range is not bounded
+	From    *Time              `json:"From"`
+	To      *Time              `json:"To"`
+	Entries []*TimeSeriesEntry `json:"Entries"`
+	// TotalResults is number of entries in the range, not only those
+	// returned in this page. Might be nil
+	TotalResults *int64                 `json:"TotalResults"`
+	Includes     map[string]interface{} `json:"Includes"`
+}
+
+// TimeSeriesDetails describes ranges of many time series of a document
+type TimeSeriesDetails struct {
+	ID     string                              `json:"Id"`
+	Values map[string][]*TimeSeriesRangeResult `json:"Values"`
+}
+
+// TimeSeriesRange describes a time range of a time series. From and To
+// are nil if the range is not bounded
+type TimeSeriesRange struct {
+	Name string
+	From *time.Time
+	To   *time.Time
+}
+
+// returns true if r covers [from, to]
+func (r *TimeSeriesRangeResult) covers(from *time.Time, to *time.Time) bool {
+	if r.From != nil && (from == nil || from.Before(r.From.toTime())) {
+		return false
+	}
+	if r.To != nil && (to == nil || to.After(r.To.toTime())) {
+		return false
+	}
+	return true
+}
+
+// entriesBetween returns entries with timestamps within [from, to]
+func (r *TimeSeriesRangeResult) entriesBetween(from *time.Time, to *time.Time) []*TimeSeriesEntry {
+	var res []*TimeSeriesEntry
+	for _, entry := range r.Entries {
+		t := entry.GetTime()
+		if from != nil && t.Before(*from) {
+			continue
+		}
+		if to != nil && t.After(*to) {
+			continue
+		}
+		res = append(res, entry)
+	}
+	return res
+}
+
+func timePtrToServerString(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return Time(t.UTC()).Format()
+}
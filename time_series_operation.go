@@ -0,0 +1,76 @@
+package ravendb
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeSeriesAppendOperation appends an entry to a time series.
+// An entry with the same timestamp is overwritten
+type TimeSeriesAppendOperation struct {
+	Timestamp Time      `json:"Timestamp"`
+	Values    []float64 `json:"Values"`
+	Tag       string    `json:"Tag,omitempty"`
+}
+
+// TimeSeriesDeleteOperation deletes entries of a time series within
+// [From, To]. nil From or To means that the range is not bounded
+type TimeSeriesDeleteOperation struct {
+	From *Time `json:"From"`
+	To   *Time `json:"To"`
+}
+
+// NewTimeSeriesDeleteOperation returns an operation that deletes entries
+// within [from, to]
+func NewTimeSeriesDeleteOperation(from *time.Time, to *time.Time) *TimeSeriesDeleteOperation {
+	res := &TimeSeriesDeleteOperation{}
+	if from != nil {
+		t := Time(from.UTC())
+		res.From = &t
+	}
+	if to != nil {
+		t := Time(to.UTC())
+		res.To = &t
+	}
+	return res
+}
+
+// TimeSeriesOperation describes appends to and deletes from a time series
+type TimeSeriesOperation struct {
+	Name    string                       `json:"Name"`
+	Appends []*TimeSeriesAppendOperation `json:"Appends"`
+	Deletes []*TimeSeriesDeleteOperation `json:"Deletes"`
+}
+
+// NewTimeSeriesOperation returns operation on a time series with a given name
+func NewTimeSeriesOperation(name string) *TimeSeriesOperation {
+	return &TimeSeriesOperation{
+		Name: name,
+	}
+}
+
+// Append adds an entry. Appends are kept sorted by timestamp and an append
+// with the same timestamp as a previous one replaces it
+func (o *TimeSeriesOperation) Append(timestamp time.Time, tag string, values ...float64) {
+	op := &TimeSeriesAppendOperation{
+		Timestamp: Time(timestamp.UTC()),
+		Values:    values,
+		Tag:       tag,
+	}
+	t := timestamp.UTC()
+	i := sort.Search(len(o.Appends), func(i int) bool {
+		return !time.Time(o.Appends[i].Timestamp).Before(t)
+	})
+	if i < len(o.Appends) && time.Time(o.Appends[i].Timestamp).Equal(t) {
+		o.Appends[i] = op
+		return
+	}
+	o.Appends = append(o.Appends, nil)
+	copy(o.Appends[i+1:], o.Appends[i:])
+	o.Appends[i] = op
+}
+
+// Delete adds a delete of entries within [from, to]
+func (o *TimeSeriesOperation) Delete(from *time.Time, to *time.Time) {
+	o.Deletes = append(o.Deletes, NewTimeSeriesDeleteOperation(from, to))
+}
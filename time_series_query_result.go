@@ -0,0 +1,30 @@
+package ravendb
+
+// TimeSeriesRawResult is a result of a time series query without
+// aggregation, see DocumentQuery.SelectTimeSeries
+type TimeSeriesRawResult struct {
+	Count   int64              `json:"Count"`
+	Results []*TimeSeriesEntry `json:"Results"`
+}
+
+// TimeSeriesAggregationResult is a result of a time series query with
+// group by, see DocumentQuery.SelectTimeSeries
+type TimeSeriesAggregationResult struct {
+	Count   int64                         `json:"Count"`
+	Results []*TimeSeriesRangeAggregation `json:"Results"`
+}
+
+// TimeSeriesRangeAggregation describes aggregated values of entries within
+// [From, To). Each slice has a value for every value of the entries.
+// Only aggregations selected in the query are set
+type TimeSeriesRangeAggregation struct {
+	From    Time      `json:"From"`
+	To      Time      `json:"To"`
+	Count   []int64   `json:"Count"`
+	Max     []float64 `json:"Max"`
+	Min     []float64 `json:"Min"`
+	Last    []float64 `json:"Last"`
+	First   []float64 `json:"First"`
+	Average []float64 `json:"Average"`
+	Sum     []float64 `json:"Sum"`
+}
@@ -0,0 +1,150 @@
+package ravendb
+
+import (
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSeriesOperationAppend(t *testing.T) {
+	base := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	op := NewTimeSeriesOperation("HeartRate")
+	op.Append(base.Add(time.Minute), "", 2)
+	op.Append(base, "watches/1", 1)
+	op.Append(base.Add(2*time.Minute), "", 3)
+	op.Append(base.Add(time.Minute), "", 4)
+
+	assert.Equal(t, 3, len(op.Appends))
+	assert.Equal(t, []float64{1}, op.Appends[0].Values)
+	assert.Equal(t, "watches/1", op.Appends[0].Tag)
+	assert.Equal(t, []float64{4}, op.Appends[1].Values)
+	assert.Equal(t, []float64{3}, op.Appends[2].Values)
+}
+
+func TestTimeSeriesBatchCommandDataSerialize(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	appends := []*TimeSeriesAppendOperation{
+		{Timestamp: Time(ts), Values: []float64{60, 1.5}, Tag: "watches/1"},
+	}
+	deletes := []*TimeSeriesDeleteOperation{NewTimeSeriesDeleteOperation(nil, &ts)}
+	cmd, err := NewTimeSeriesBatchCommandData("users/1", "HeartRate", appends, deletes)
+	assert.NoError(t, err)
+	assert.Equal(t, "HeartRate", cmd.getName())
+
+	v, err := cmd.serialize(nil)
+	assert.NoError(t, err)
+	d, err := jsonMarshal(v)
+	assert.NoError(t, err)
+	exp := `{"Id":"users/1","TimeSeries":{"Name":"HeartRate","Appends":[{"Timestamp":"2020-01-02T03:04:05.0000000Z","Values":[60,1.5],"Tag":"watches/1"}],"Deletes":[{"From":null,"To":"2020-01-02T03:04:05.0000000Z"}]},"Type":"TimeSeries"}`
+	assert.Equal(t, exp, string(d))
+
+	_, err = NewTimeSeriesBatchCommandData("users/1", "", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestGetTimeSeriesCommandRequest(t *testing.T) {
+	node := &ServerNode{
+		URL:      "http://localhost:8080",
+		Database: "db",
+	}
+	from := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	op, err := NewGetTimeSeriesOperationWithPaging("users/1", "HeartRate", &from, nil, 10, 5)
+	assert.NoError(t, err)
+	cmd, err := op.GetCommand(nil, nil, nil)
+	assert.NoError(t, err)
+	req, err := cmd.createRequest(node)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "/databases/db/timeseries", req.URL.Path)
+	q := req.URL.Query()
+	assert.Equal(t, "users/1", q.Get("docId"))
+	assert.Equal(t, "HeartRate", q.Get("name"))
+	assert.Equal(t, "10", q.Get("start"))
+	assert.Equal(t, "5", q.Get("pageSize"))
+	assert.Equal(t, "2020-01-02T03:04:05.0000000Z", q.Get("from"))
+	_, ok := q["to"]
+	assert.False(t, ok)
+}
+
+func TestSessionTimeSeriesMergesDeferredCommands(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	ts, err := session.TimeSeriesForDocumentID("users/1", "HeartRate")
+	assert.NoError(t, err)
+
+	base := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, ts.Append(base, 60))
+	assert.NoError(t, ts.AppendWithTag(base.Add(time.Second), "watches/1", 61))
+	assert.NoError(t, ts.DeleteAt(base.Add(-time.Hour)))
+	assert.Error(t, ts.Append(base))
+
+	other, err := session.TimeSeriesForDocumentID("users/1", "Steps")
+	assert.NoError(t, err)
+	assert.NoError(t, other.Append(base, 100))
+
+	assert.Equal(t, 2, len(session.deferredCommands))
+	cmd := session.deferredCommands[0].(*TimeSeriesBatchCommandData)
+	assert.Equal(t, "HeartRate", cmd.TimeSeries.Name)
+	assert.Equal(t, 2, len(cmd.TimeSeries.Appends))
+	assert.Equal(t, 1, len(cmd.TimeSeries.Deletes))
+}
+
+func TestSessionTimeSeriesGetFromCache(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	base := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	from, to := base, base.Add(time.Hour)
+	var entries []*TimeSeriesEntry
+	for i := 0; i < 4; i++ {
+		entries = append(entries, &TimeSeriesEntry{
+			Timestamp: Time(base.Add(time.Duration(i) * 20 * time.Minute)),
+			Values:    []float64{float64(i)},
+		})
+	}
+	session.registerTimeSeries(map[string]map[string][]*TimeSeriesRangeResult{
+		"users/1": {
+			"HeartRate": {
+				{From: timeToServerTimePtr(&from), To: timeToServerTimePtr(&to), Entries: entries},
+			},
+		},
+	})
+
+	ts, err := session.TimeSeriesForDocumentID("USERS/1", "heartrate")
+	assert.NoError(t, err)
+	// served from cache, there's no server to ask
+	inner := base.Add(10 * time.Minute)
+	got, err := ts.Get(&inner, &to, 0, math.MaxInt32)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(got))
+	assert.Equal(t, 1.0, got[0].GetValue())
+
+	_, ok := session.getTimeSeriesFromCache("users/1", "HeartRate", nil, &to)
+	assert.False(t, ok)
+
+	// a wider range replaces ranges it covers
+	session.addTimeSeriesToCache("users/1", "HeartRate", &TimeSeriesRangeResult{Entries: entries})
+	assert.Equal(t, 1, len(session.timeSeriesByDocID["users/1"]["heartrate"]))
+	_, ok = session.getTimeSeriesFromCache("users/1", "HeartRate", nil, nil)
+	assert.True(t, ok)
+
+	assert.NoError(t, ts.Append(base, 1))
+	_, ok = session.getTimeSeriesFromCache("users/1", "HeartRate", nil, nil)
+	assert.False(t, ok)
+}
+
+func TestQueryTimeSeries(t *testing.T) {
+	session := newTestSession(TransactionModeSingleNode)
+	from := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	q := session.QueryCollection("Users").IncludeCounter("likes").IncludeTimeSeries("HeartRate", &from, nil)
+	iq, err := q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users include counters('likes'),timeseries('HeartRate', '2020-01-02T03:04:05.0000000Z', '9999-12-31T23:59:59.9999999Z')", iq.GetQuery())
+
+	q = session.QueryCollection("Users").SelectTimeSeries(reflect.TypeOf(&TimeSeriesAggregationResult{}), "from HeartRate between $start and $end group by '1 hour' select max()")
+	iq, err = q.GetIndexQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "from Users select timeseries(from HeartRate between $start and $end group by '1 hour' select max())", iq.GetQuery())
+}
@@ -5,6 +5,12 @@ import (
 	"time"
 )
 
+// timeToUTCString converts t to UTC and formats it the way RavenDB
+// server expects in metadata e.g. @expires
+func timeToUTCString(t time.Time) string {
+	return Time(t.UTC()).Format()
+}
+
 // TODO: implementation could be improved
 func durationToTimeSpan(duration time.Duration) string {
 	tm := int64(duration / time.Millisecond)
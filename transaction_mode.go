@@ -0,0 +1,14 @@
+package ravendb
+
+// TransactionMode describes how SaveChanges of a session is applied
+type TransactionMode = string
+
+const (
+	// TransactionModeSingleNode applies changes on a single node, from which
+	// they are replicated to other nodes
+	TransactionModeSingleNode = "SingleNode"
+	// TransactionModeClusterWide applies changes atomically on all nodes of
+	// the cluster, together with compare exchange values modified via
+	// ClusterTransactionOperations
+	TransactionModeClusterWide = "ClusterWide"
+)
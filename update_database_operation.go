@@ -0,0 +1,47 @@
+package ravendb
+
+var _ IServerOperation = &UpdateDatabaseOperation{}
+
+// UpdateDatabaseOperation replaces a database record. The update fails with
+// ConcurrencyError if the record was modified since it was read
+// (i.e. its etag is different than etag)
+type UpdateDatabaseOperation struct {
+	databaseRecord *DatabaseRecord
+	etag           int64
+
+	Command *CreateDatabaseCommand
+}
+
+// NewUpdateDatabaseOperation returns UpdateDatabaseOperation. etag should be
+// DatabaseRecordWithEtag.Etag of the record returned by GetDatabaseRecordOperation
+func NewUpdateDatabaseOperation(databaseRecord *DatabaseRecord, etag int64) *UpdateDatabaseOperation {
+	return &UpdateDatabaseOperation{
+		databaseRecord: databaseRecord,
+		etag:           etag,
+	}
+}
+
+// NewUpdateDatabaseRecordOperation returns UpdateDatabaseOperation for
+// a record (modified after being) returned by GetDatabaseRecordOperation
+func NewUpdateDatabaseRecordOperation(databaseRecord *DatabaseRecordWithEtag) *UpdateDatabaseOperation {
+	return NewUpdateDatabaseOperation(&databaseRecord.DatabaseRecord, databaseRecord.Etag)
+}
+
+// GetCommand returns command for this operation
+func (o *UpdateDatabaseOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if o.databaseRecord == nil {
+		return nil, newIllegalArgumentError("databaseRecord cannot be nil")
+	}
+	replicationFactor := 1
+	if o.databaseRecord.Topology != nil && o.databaseRecord.Topology.ReplicationFactor > 0 {
+		replicationFactor = o.databaseRecord.Topology.ReplicationFactor
+	}
+	cmd, err := NewCreateDatabaseCommand(conventions, o.databaseRecord, replicationFactor)
+	if err != nil {
+		return nil, err
+	}
+	etag := o.etag
+	cmd.etag = &etag
+	o.Command = cmd
+	return cmd, nil
+}
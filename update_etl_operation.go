@@ -0,0 +1,74 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &UpdateEtlOperation{}
+)
+
+// UpdateEtlOperation updates an existing ETL task
+type UpdateEtlOperation struct {
+	taskID        int64
+	configuration interface{}
+
+	Command *UpdateEtlCommand
+}
+
+// UpdateEtlOperationResult is a result of UpdateEtlOperation
+type UpdateEtlOperationResult struct {
+	RaftCommandIndex int64 `json:"RaftCommandIndex"`
+	TaskID           int64 `json:"TaskId"`
+}
+
+// NewUpdateEtlOperation returns new UpdateEtlOperation. configuration should
+// be *RavenEtlConfiguration or *SqlEtlConfiguration
+func NewUpdateEtlOperation(taskID int64, configuration interface{}) *UpdateEtlOperation {
+	return &UpdateEtlOperation{
+		taskID:        taskID,
+		configuration: configuration,
+	}
+}
+
+func (o *UpdateEtlOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if err := checkEtlConfiguration(o.configuration); err != nil {
+		return nil, err
+	}
+	o.Command = &UpdateEtlCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		taskID:        o.taskID,
+		configuration: o.configuration,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &UpdateEtlCommand{}
+
+// UpdateEtlCommand is a command for UpdateEtlOperation
+type UpdateEtlCommand struct {
+	RavenCommandBase
+
+	taskID        int64
+	configuration interface{}
+
+	Result *UpdateEtlOperationResult
+}
+
+func (c *UpdateEtlCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/etl?id=" + i64toa(c.taskID)
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPut(url, d)
+}
+
+func (c *UpdateEtlCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}
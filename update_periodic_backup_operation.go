@@ -0,0 +1,70 @@
+package ravendb
+
+import (
+	"net/http"
+)
+
+var (
+	_ IMaintenanceOperation = &UpdatePeriodicBackupOperation{}
+)
+
+// UpdatePeriodicBackupOperation creates or updates (if TaskID is set)
+// a periodic backup task of a database
+type UpdatePeriodicBackupOperation struct {
+	configuration *PeriodicBackupConfiguration
+
+	Command *UpdatePeriodicBackupCommand
+}
+
+// UpdatePeriodicBackupOperationResult is a result of UpdatePeriodicBackupOperation
+type UpdatePeriodicBackupOperationResult struct {
+	RaftCommandIndex int64 `json:"RaftCommandIndex"`
+	TaskID           int64 `json:"TaskId"`
+}
+
+// NewUpdatePeriodicBackupOperation returns new UpdatePeriodicBackupOperation
+func NewUpdatePeriodicBackupOperation(configuration *PeriodicBackupConfiguration) *UpdatePeriodicBackupOperation {
+	return &UpdatePeriodicBackupOperation{
+		configuration: configuration,
+	}
+}
+
+func (o *UpdatePeriodicBackupOperation) GetCommand(conventions *DocumentConventions) (RavenCommand, error) {
+	if o.configuration == nil {
+		return nil, newIllegalArgumentError("Configuration cannot be nil")
+	}
+	o.Command = &UpdatePeriodicBackupCommand{
+		RavenCommandBase: NewRavenCommandBase(),
+
+		configuration: o.configuration,
+	}
+	return o.Command, nil
+}
+
+var _ RavenCommand = &UpdatePeriodicBackupCommand{}
+
+// UpdatePeriodicBackupCommand is a command for UpdatePeriodicBackupOperation
+type UpdatePeriodicBackupCommand struct {
+	RavenCommandBase
+
+	configuration *PeriodicBackupConfiguration
+
+	Result *UpdatePeriodicBackupOperationResult
+}
+
+func (c *UpdatePeriodicBackupCommand) createRequest(node *ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/periodic-backup"
+
+	d, err := jsonMarshal(c.configuration)
+	if err != nil {
+		return nil, err
+	}
+	return newHttpPost(url, d)
+}
+
+func (c *UpdatePeriodicBackupCommand) setResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return throwInvalidResponse()
+	}
+	return jsonUnmarshal(response, &c.Result)
+}